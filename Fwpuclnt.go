@@ -444,7 +444,7 @@ func (fv *FwpConditionValue0) GetPUint64() (*uint64, error) {
 	if fv.Type != FWP_UINT64 {
 		return nil, fmt.Errorf("Type %v != FWP_UINT64", fv.Type)
 	}
-	return (*uint64)(unsafe.Pointer(uintptr(fv.Data))), nil
+	return *(**uint64)(unsafe.Pointer(&fv.Data)), nil
 }
 
 // Note that FwpConditionValue0 will not retain the pointer reference, the caller remembers to execute runtime.KeepAlive to prevent v from being garbage collected.
@@ -461,7 +461,7 @@ func (fv *FwpConditionValue0) GetByteBlob() (*FwpByteBlob, error) {
 	if fv.Type != FWP_BYTE_BLOB_TYPE {
 		return nil, fmt.Errorf("Type %v != FWP_BYTE_BLOB_TYPE", fv.Type)
 	}
-	return (*FwpByteBlob)(unsafe.Pointer(uintptr(fv.Data))), nil
+	return *(**FwpByteBlob)(unsafe.Pointer(&fv.Data)), nil
 }
 
 //typedef struct FWPM_ACTION0_
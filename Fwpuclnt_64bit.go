@@ -1,3 +1,5 @@
+// +build amd64 arm64
+
 package gowindows
 
 //typedef struct FWPM_FILTER0_
@@ -34,7 +36,8 @@ type FwpmFilter0 struct {
 	NumFilterConditions uint32
 	FilterCondition     *FwpmFilterCondition0
 	Action              FwpmAction0
-	ProviderContextKey  GUID // Another possibility, UINT64 rawContext
+	_                   uint32 // The union is aligned to 8 bytes by UINT64 rawContext, GUID is aligned to 4 bytes.
+	ProviderContextKey  GUID   // Another possibility, UINT64 rawContext
 	Reserved            *GUID
 	FilterId            uint64
 	EffectiveWeight     FwpValue0
@@ -1,7 +1,6 @@
 package gowindows
 
 import (
-	"fmt"
 	"unsafe"
 
 	"golang.org/x/sys/windows"
@@ -19,18 +18,6 @@ var (
 	fwpmFilterDeleteById0     = fwpuclnt.NewProc("FwpmFilterDeleteById0")
 )
 
-type FwpmError struct {
-	r1 DWord
-}
-
-func newFwpmError(r1 DWord) error {
-	return &FwpmError{r1: r1}
-}
-
-func (e *FwpmError) Error() string {
-	return fmt.Sprintf("r1:%X", e.r1)
-}
-
 // FwpmEngineOpen0
 // The FwpmEngineOpen0 function opens a session to the filter engine.
 // https://docs.microsoft.com/en-us/windows/desktop/api/fwpmu/nf-fwpmu-fwpmengineopen0
@@ -56,13 +43,9 @@ func FwpmEngineOpen0(serverName string, authnService RpcCAuthnType, authIdentity
 		}
 	}
 
-	r1, _, e1 := fwpmEngineOpen0.Call(uintptr(unsafe.Pointer(_serverName)), uintptr(authnService), uintptr(authIdentity), uintptr(unsafe.Pointer(session)), uintptr(unsafe.Pointer(engineHandle)))
+	r1, _, _ := fwpmEngineOpen0.Call(uintptr(unsafe.Pointer(_serverName)), uintptr(authnService), uintptr(authIdentity), uintptr(unsafe.Pointer(session)), uintptr(unsafe.Pointer(engineHandle)))
 	if r1 != 0 {
-		if e1 != ERROR_SUCCESS {
-			return e1
-		} else {
-			return newFwpmError(DWord(r1))
-		}
+		return newFwpmCallError(fwpuclnt.Name, fwpmEngineOpen0.Name, r1)
 	}
 	return nil
 }
@@ -77,13 +60,9 @@ func FwpmEngineClose0(engineHandle Handle) error {
 		return err
 	}
 
-	r1, _, e1 := fwpmEngineClose0.Call(uintptr(engineHandle))
+	r1, _, _ := fwpmEngineClose0.Call(uintptr(engineHandle))
 	if r1 != 0 {
-		if e1 != ERROR_SUCCESS {
-			return e1
-		} else {
-			return newFwpmError(DWord(r1))
-		}
+		return newFwpmCallError(fwpuclnt.Name, fwpmEngineClose0.Name, r1)
 	}
 	return nil
 }
@@ -102,13 +81,9 @@ func FwpmSubLayerAdd0(engineHandle Handle, subLayer *FwpmSublayer0, sd PSecurity
 		return err
 	}
 
-	r1, _, e1 := fwpmSubLayerAdd0.Call(uintptr(engineHandle), uintptr(unsafe.Pointer(subLayer)), uintptr(sd))
+	r1, _, _ := fwpmSubLayerAdd0.Call(uintptr(engineHandle), uintptr(unsafe.Pointer(subLayer)), uintptr(sd))
 	if r1 != 0 {
-		if e1 != ERROR_SUCCESS {
-			return e1
-		} else {
-			return newFwpmError(DWord(r1))
-		}
+		return newFwpmCallError(fwpuclnt.Name, fwpmSubLayerAdd0.Name, r1)
 	}
 	return nil
 }
@@ -124,13 +99,9 @@ func FwpmSubLayerDeleteByKey0(engineHandle Handle, key *GUID) error {
 		return err
 	}
 
-	r1, _, e1 := fwpmSubLayerDeleteByKey0.Call(uintptr(engineHandle), uintptr(unsafe.Pointer(key)))
+	r1, _, _ := fwpmSubLayerDeleteByKey0.Call(uintptr(engineHandle), uintptr(unsafe.Pointer(key)))
 	if r1 != 0 {
-		if e1 != ERROR_SUCCESS {
-			return e1
-		} else {
-			return newFwpmError(DWord(r1))
-		}
+		return newFwpmCallError(fwpuclnt.Name, fwpmSubLayerDeleteByKey0.Name, r1)
 	}
 	return nil
 }
@@ -152,13 +123,9 @@ func FwpmGetAppIdFromFileName0(fileName string, appId **FwpByteBlob) error {
 		return err
 	}
 
-	r1, _, e1 := fwpmGetAppIdFromFileName0.Call(uintptr(unsafe.Pointer(_fileName)), uintptr(unsafe.Pointer(appId)))
+	r1, _, _ := fwpmGetAppIdFromFileName0.Call(uintptr(unsafe.Pointer(_fileName)), uintptr(unsafe.Pointer(appId)))
 	if r1 != 0 {
-		if e1 != ERROR_SUCCESS {
-			return e1
-		} else {
-			return newFwpmError(DWord(r1))
-		}
+		return newFwpmCallError(fwpuclnt.Name, fwpmGetAppIdFromFileName0.Name, r1)
 	}
 
 	return nil
@@ -191,13 +158,9 @@ func FwpmFilterAdd0(engineHandle Handle, filter *FwpmFilter0, sd PSecurityDescri
 		return err
 	}
 
-	r1, _, e1 := fwpmFilterAdd0.Call(uintptr(engineHandle), uintptr(unsafe.Pointer(filter)), uintptr(sd), uintptr(unsafe.Pointer(id)))
+	r1, _, _ := fwpmFilterAdd0.Call(uintptr(engineHandle), uintptr(unsafe.Pointer(filter)), uintptr(sd), uintptr(unsafe.Pointer(id)))
 	if r1 != 0 {
-		if e1 != ERROR_SUCCESS {
-			return e1
-		} else {
-			return newFwpmError(DWord(r1))
-		}
+		return newFwpmCallError(fwpuclnt.Name, fwpmFilterAdd0.Name, r1)
 	}
 
 	return nil
@@ -215,19 +178,14 @@ func FwpmFilterDeleteById0(engineHandle Handle, id FilterId) error {
 	}
 
 	var r1 uintptr
-	var e1 error
 
 	if ptrSize == 8 {
-		r1, _, e1 = fwpmFilterDeleteById0.Call(uintptr(engineHandle), uintptr(id))
+		r1, _, _ = fwpmFilterDeleteById0.Call(uintptr(engineHandle), uintptr(id))
 	} else {
-		r1, _, e1 = fwpmFilterDeleteById0.Call(uintptr(engineHandle), uintptr(id), uintptr(id>>32))
+		r1, _, _ = fwpmFilterDeleteById0.Call(uintptr(engineHandle), uintptr(id), uintptr(id>>32))
 	}
 	if r1 != 0 {
-		if e1 != ERROR_SUCCESS {
-			return e1
-		} else {
-			return newFwpmError(DWord(r1))
-		}
+		return newFwpmCallError(fwpuclnt.Name, fwpmFilterDeleteById0.Name, r1)
 	}
 
 	return nil
@@ -114,7 +114,6 @@ typedef struct _IP_ADAPTER_ADDRESSES_LH {
   PIP_ADAPTER_DNS_SUFFIX             FirstDnsSuffix;
 } IP_ADAPTER_ADDRESSES_LH, *PIP_ADAPTER_ADDRESSES_LH;
 */
-//TODO: There should be a problem with the structure. There should be some fields before the GUID!
 type IpAdapterAddresses struct {
 	Length                uint32
 	IfIndex               uint32
@@ -157,8 +156,9 @@ type IpAdapterAddresses struct {
 	dhcpv6ClientDuidLength uint32
 	dhcpv6Iaid             uint32
 
-	// The following is added after windows Vista SP1 and windows server 2008
-	firstDnsSuffix *IpAdapterDnsSuffix
+	// The following is added after windows Vista SP1 and windows server 2008,
+	// firstDnsSuffix and the padding of 386.
+	ipAdapterAddressesTail
 }
 
 //typedef struct _IP_ADAPTER_WINS_SERVER_ADDRESS_LH {
@@ -173,17 +173,17 @@ type IpAdapterAddresses struct {
 //    SOCKET_ADDRESS Address;
 //} IP_ADAPTER_WINS_SERVER_ADDRESS_LH, *PIP_ADAPTER_WINS_SERVER_ADDRESS_LH;
 type IpAdapterWinsServerAddress struct {
-	Length   uint32
-	Reserved int32
-	Next     *IpAdapterWinsServerAddress
-	Address  windows.SocketAddress
+	Length               uint32
+	Reserved             int32
+	Next                 *IpAdapterWinsServerAddress
+	ipAdapterAddressTail // Address and the padding of 386
 }
 
 type IpAdapterGatewayAddress struct {
-	Length   uint32
-	Reserved int32
-	Next     *IpAdapterGatewayAddress
-	Address  windows.SocketAddress
+	Length               uint32
+	Reserved             int32
+	Next                 *IpAdapterGatewayAddress
+	ipAdapterAddressTail // Address and the padding of 386
 }
 
 //typedef struct _MIB_IPADDRTABLE {
@@ -513,10 +513,9 @@ func GetIpForwardTable() ([]MibIpForwardRow, error) {
 	buf := []byte{0}
 	bufSize := uint32(len(buf))
 	var r1 uintptr
-	var e1 error
 	for i := 0; i < 10; i++ {
 		buf = make([]byte, bufSize)
-		r1, _, e1 = getIpForwardTable.Call(uintptr(unsafe.Pointer(&buf[0])), uintptr(unsafe.Pointer(&bufSize)), 0)
+		r1, _, _ = getIpForwardTable.Call(uintptr(unsafe.Pointer(&buf[0])), uintptr(unsafe.Pointer(&bufSize)), 0)
 		if r1 == ERROR_INSUFFICIENT_BUFFER {
 			// 空间不足
 			continue
@@ -526,11 +525,8 @@ func GetIpForwardTable() ([]MibIpForwardRow, error) {
 	}
 
 	if r1 != 0 {
-		if e1 != ERROR_SUCCESS {
-			return nil, e1
-		} else {
-			return nil, fmt.Errorf("r1:%v", r1)
-		}
+		// The return value is the error code
+		return nil, newErrnoCallError(iphlpapi.Name, getIpForwardTable.Name, r1)
 	}
 
 	table := (*MibIpForwardTable)(unsafe.Pointer(&buf[0]))
@@ -546,13 +542,9 @@ func GetIpForwardTable() ([]MibIpForwardRow, error) {
 }
 
 func CreateIpForwardEntry(row *MibIpForwardRow) error {
-	r1, _, e1 := createIpForwardEntry.Call(uintptr(unsafe.Pointer(row)))
+	r1, _, _ := createIpForwardEntry.Call(uintptr(unsafe.Pointer(row)))
 	if r1 != 0 {
-		if e1 != ERROR_SUCCESS {
-			return e1
-		} else {
-			return fmt.Errorf("r1:%v", r1)
-		}
+		return newErrnoCallError(iphlpapi.Name, createIpForwardEntry.Name, r1)
 	}
 
 	return nil
@@ -560,13 +552,9 @@ func CreateIpForwardEntry(row *MibIpForwardRow) error {
 
 // The following members must be provided：dwForwardIfIndex，dwForwardDest，dwForwardMask，dwForwardNextHop和dwForwardProto
 func DeleteIpForwardEntry(row *MibIpForwardRow) error {
-	r1, _, e1 := deleteIpForwardEntry.Call(uintptr(unsafe.Pointer(row)))
+	r1, _, _ := deleteIpForwardEntry.Call(uintptr(unsafe.Pointer(row)))
 	if r1 != 0 {
-		if e1 != ERROR_SUCCESS {
-			return e1
-		} else {
-			return fmt.Errorf("r1:%v", r1)
-		}
+		return newErrnoCallError(iphlpapi.Name, deleteIpForwardEntry.Name, r1)
 	}
 
 	return nil
@@ -578,7 +566,7 @@ func DeleteIpForwardEntry(row *MibIpForwardRow) error {
 //  LPOVERLAPPED overlapped
 //);
 func NotifyAddrChange(handle *Handle, overlapped *Overlapped) error {
-	r1, _, e1 := notifyAddrChange.Call(uintptr(unsafe.Pointer(handle)), uintptr(unsafe.Pointer(overlapped)))
+	r1, _, _ := notifyAddrChange.Call(uintptr(unsafe.Pointer(handle)), uintptr(unsafe.Pointer(overlapped)))
 	if handle == nil && overlapped == nil {
 		if r1 == NO_ERROR {
 			return nil
@@ -589,11 +577,7 @@ func NotifyAddrChange(handle *Handle, overlapped *Overlapped) error {
 		}
 	}
 
-	if e1 != ERROR_SUCCESS {
-		return e1
-	} else {
-		return fmt.Errorf("r1:%v", r1)
-	}
+	return newErrnoCallError(iphlpapi.Name, notifyAddrChange.Name, r1)
 }
 
 //DWORD NotifyRouteChange(
@@ -602,7 +586,7 @@ func NotifyAddrChange(handle *Handle, overlapped *Overlapped) error {
 //);
 //https://docs.microsoft.com/en-us/windows/desktop/api/iphlpapi/nf-iphlpapi-notifyroutechange
 func NotifyRouteChange(handle *Handle, overlapped *Overlapped) error {
-	r1, _, e1 := notifyRouteChange.Call(uintptr(unsafe.Pointer(handle)), uintptr(unsafe.Pointer(overlapped)))
+	r1, _, _ := notifyRouteChange.Call(uintptr(unsafe.Pointer(handle)), uintptr(unsafe.Pointer(overlapped)))
 	if handle == nil && overlapped == nil {
 		if r1 == NO_ERROR {
 			return nil
@@ -613,11 +597,7 @@ func NotifyRouteChange(handle *Handle, overlapped *Overlapped) error {
 		}
 	}
 
-	if e1 != ERROR_SUCCESS {
-		return e1
-	} else {
-		return fmt.Errorf("r1:%v", r1)
-	}
+	return newErrnoCallError(iphlpapi.Name, notifyRouteChange.Name, r1)
 }
 
 // BOOL CancelIPChangeNotify(
@@ -854,11 +834,10 @@ func GetIpAddrTable(order bool) ([]MibIpAddrRowW2k, error) {
 	bufSize := 1024
 	var buf []byte
 	var r1 uintptr
-	var e1 error
 	for {
 		buf = make([]byte, bufSize)
 
-		r1, _, e1 = getIpAddrTable.Call(uintptr(unsafe.Pointer(&buf[0])), uintptr(unsafe.Pointer(&bufSize)), uintptr(_order))
+		r1, _, _ = getIpAddrTable.Call(uintptr(unsafe.Pointer(&buf[0])), uintptr(unsafe.Pointer(&bufSize)), uintptr(_order))
 		if r1 == ERROR_INSUFFICIENT_BUFFER {
 			continue
 		}
@@ -866,11 +845,7 @@ func GetIpAddrTable(order bool) ([]MibIpAddrRowW2k, error) {
 	}
 
 	if r1 != NO_ERROR {
-		if e1 != ERROR_SUCCESS {
-			return nil, e1
-		} else {
-			return nil, fmt.Errorf("r1:%v", r1)
-		}
+		return nil, newErrnoCallError(iphlpapi.Name, getIpAddrTable.Name, r1)
 	}
 
 	table := (*MibIpAddrTable)(unsafe.Pointer(&buf[0]))
@@ -0,0 +1,16 @@
+package gowindows
+
+import "golang.org/x/sys/windows"
+
+// The last fields of the structures which begin with union { ULONGLONG Alignment; ... },
+// C pads the structures to 8 bytes on 386, but go aligns uint64 to 4 bytes.
+
+type ipAdapterAddressesTail struct {
+	firstDnsSuffix *IpAdapterDnsSuffix
+	_              uint32
+}
+
+type ipAdapterAddressTail struct {
+	Address windows.SocketAddress
+	_       uint32
+}
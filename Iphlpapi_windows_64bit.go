@@ -0,0 +1,17 @@
+// +build windows
+// +build amd64 arm64
+
+package gowindows
+
+import "golang.org/x/sys/windows"
+
+// The last fields of the structures which begin with union { ULONGLONG Alignment; ... },
+// the structures are aligned to 8 bytes by the pointers, there is no padding.
+
+type ipAdapterAddressesTail struct {
+	firstDnsSuffix *IpAdapterDnsSuffix
+}
+
+type ipAdapterAddressTail struct {
+	Address windows.SocketAddress
+}
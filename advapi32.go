@@ -132,3 +132,124 @@ const (
 
 type SecurityInformation DWord
 type PSId Pointer
+
+// Integrity level, the RID of the mandatory label SID (S-1-16-RID).
+// https://docs.microsoft.com/en-us/windows/win32/secauthz/well-known-sids
+type IntegrityLevel uint32
+
+const (
+	SECURITY_MANDATORY_UNTRUSTED_RID         IntegrityLevel = 0x00000000
+	SECURITY_MANDATORY_LOW_RID               IntegrityLevel = 0x00001000
+	SECURITY_MANDATORY_MEDIUM_RID            IntegrityLevel = 0x00002000
+	SECURITY_MANDATORY_MEDIUM_PLUS_RID       IntegrityLevel = SECURITY_MANDATORY_MEDIUM_RID + 0x100
+	SECURITY_MANDATORY_HIGH_RID              IntegrityLevel = 0x00003000
+	SECURITY_MANDATORY_SYSTEM_RID            IntegrityLevel = 0x00004000
+	SECURITY_MANDATORY_PROTECTED_PROCESS_RID IntegrityLevel = 0x00005000
+)
+
+// Access policy of the mandatory label ACE, it is stored in the access mask of the ACE.
+// https://docs.microsoft.com/en-us/windows/win32/api/winnt/ns-winnt-system_mandatory_label_ace
+type MandatoryPolicy uint32
+
+const (
+	// A principal with a lower mandatory level than the object cannot write to the object.
+	SYSTEM_MANDATORY_LABEL_NO_WRITE_UP MandatoryPolicy = 0x1
+	// A principal with a lower mandatory level than the object cannot read the object.
+	SYSTEM_MANDATORY_LABEL_NO_READ_UP MandatoryPolicy = 0x2
+	// A principal with a lower mandatory level than the object cannot execute the object.
+	SYSTEM_MANDATORY_LABEL_NO_EXECUTE_UP MandatoryPolicy = 0x4
+
+	SYSTEM_MANDATORY_LABEL_VALID_MASK = SYSTEM_MANDATORY_LABEL_NO_WRITE_UP | SYSTEM_MANDATORY_LABEL_NO_READ_UP | SYSTEM_MANDATORY_LABEL_NO_EXECUTE_UP
+)
+
+const (
+	ACL_REVISION    = 2
+	ACL_REVISION_DS = 4
+)
+
+const (
+	SYSTEM_MANDATORY_LABEL_ACE_TYPE = 0x11
+)
+
+// SID_IDENTIFIER_AUTHORITY of the mandatory label SID
+var SECURITY_MANDATORY_LABEL_AUTHORITY = [6]byte{0, 0, 0, 0, 0, 16}
+
+// AceType
+// https://docs.microsoft.com/en-us/windows/win32/api/winnt/ns-winnt-ace_header
+const (
+	ACCESS_ALLOWED_ACE_TYPE                 = 0x0
+	ACCESS_DENIED_ACE_TYPE                  = 0x1
+	SYSTEM_AUDIT_ACE_TYPE                   = 0x2
+	SYSTEM_ALARM_ACE_TYPE                   = 0x3
+	ACCESS_ALLOWED_COMPOUND_ACE_TYPE        = 0x4
+	ACCESS_ALLOWED_OBJECT_ACE_TYPE          = 0x5
+	ACCESS_DENIED_OBJECT_ACE_TYPE           = 0x6
+	SYSTEM_AUDIT_OBJECT_ACE_TYPE            = 0x7
+	SYSTEM_ALARM_OBJECT_ACE_TYPE            = 0x8
+	ACCESS_ALLOWED_CALLBACK_ACE_TYPE        = 0x9
+	ACCESS_DENIED_CALLBACK_ACE_TYPE         = 0xA
+	ACCESS_ALLOWED_CALLBACK_OBJECT_ACE_TYPE = 0xB
+	ACCESS_DENIED_CALLBACK_OBJECT_ACE_TYPE  = 0xC
+	SYSTEM_AUDIT_CALLBACK_ACE_TYPE          = 0xD
+	SYSTEM_ALARM_CALLBACK_ACE_TYPE          = 0xE
+	SYSTEM_AUDIT_CALLBACK_OBJECT_ACE_TYPE   = 0xF
+	SYSTEM_ALARM_CALLBACK_OBJECT_ACE_TYPE   = 0x10
+	SYSTEM_RESOURCE_ATTRIBUTE_ACE_TYPE      = 0x12
+	SYSTEM_SCOPED_POLICY_ID_ACE_TYPE        = 0x13
+	SYSTEM_PROCESS_TRUST_LABEL_ACE_TYPE     = 0x14
+)
+
+// AceFlags
+const (
+	OBJECT_INHERIT_ACE         = 0x1
+	CONTAINER_INHERIT_ACE      = 0x2
+	NO_PROPAGATE_INHERIT_ACE   = 0x4
+	INHERIT_ONLY_ACE           = 0x8
+	INHERITED_ACE              = 0x10
+	SUCCESSFUL_ACCESS_ACE_FLAG = 0x40
+	FAILED_ACCESS_ACE_FLAG     = 0x80
+)
+
+// Flags of ACCESS_ALLOWED_OBJECT_ACE
+const (
+	ACE_OBJECT_TYPE_PRESENT           = 0x1
+	ACE_INHERITED_OBJECT_TYPE_PRESENT = 0x2
+)
+
+// SECURITY_DESCRIPTOR_CONTROL
+// https://docs.microsoft.com/en-us/windows/win32/secauthz/security-descriptor-control
+type SecurityDescriptorControl Word
+
+const (
+	SE_OWNER_DEFAULTED       SecurityDescriptorControl = 0x0001
+	SE_GROUP_DEFAULTED       SecurityDescriptorControl = 0x0002
+	SE_DACL_PRESENT          SecurityDescriptorControl = 0x0004
+	SE_DACL_DEFAULTED        SecurityDescriptorControl = 0x0008
+	SE_SACL_PRESENT          SecurityDescriptorControl = 0x0010
+	SE_SACL_DEFAULTED        SecurityDescriptorControl = 0x0020
+	SE_DACL_AUTO_INHERIT_REQ SecurityDescriptorControl = 0x0100
+	SE_SACL_AUTO_INHERIT_REQ SecurityDescriptorControl = 0x0200
+	SE_DACL_AUTO_INHERITED   SecurityDescriptorControl = 0x0400
+	SE_SACL_AUTO_INHERITED   SecurityDescriptorControl = 0x0800
+	SE_DACL_PROTECTED        SecurityDescriptorControl = 0x1000
+	SE_SACL_PROTECTED        SecurityDescriptorControl = 0x2000
+	SE_RM_CONTROL_VALID      SecurityDescriptorControl = 0x4000
+	SE_SELF_RELATIVE         SecurityDescriptorControl = 0x8000
+)
+
+const SECURITY_DESCRIPTOR_REVISION = 1
+
+// ACCESS_MASK
+const (
+	DELETE                 = 0x00010000
+	READ_CONTROL           = 0x00020000
+	WRITE_DAC              = 0x00040000
+	WRITE_OWNER            = 0x00080000
+	SYNCHRONIZE            = 0x00100000
+	ACCESS_SYSTEM_SECURITY = 0x01000000
+	MAXIMUM_ALLOWED        = 0x02000000
+	GENERIC_ALL            = 0x10000000
+	GENERIC_EXECUTE        = 0x20000000
+	GENERIC_WRITE          = 0x40000000
+	GENERIC_READ           = 0x80000000
+)
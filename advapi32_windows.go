@@ -17,55 +17,37 @@ var (
 	getSecurityDescriptorSacl                                = modadvapi32.NewProc("GetSecurityDescriptorSacl")
 	setSecurityInfo                                          = modadvapi32.NewProc("SetSecurityInfo")
 	setNamedSecurityInfo                                     = modadvapi32.NewProc("SetNamedSecurityInfoW")
+	procGetSecurityInfo                                      = modadvapi32.NewProc("GetSecurityInfo")
+	procGetNamedSecurityInfoW                                = modadvapi32.NewProc("GetNamedSecurityInfoW")
+	procGetSecurityDescriptorLength                          = modadvapi32.NewProc("GetSecurityDescriptorLength")
 )
 
-func adjustTokenPrivileges(token windows.Token, disableAllPrivileges bool, newstate *TOKEN_PRIVILEGES, buflen uint32, prevstate *TOKEN_PRIVILEGES, returnlen *uint32) (ret uint32, err error) {
+// The AdjustTokenPrivileges function allows or prohibits specific privileged access tokens. TOKEN_ADJUST_PRIVILEGES access permission is required to enable or disable permissions in the access token.
+// https://msdn.microsoft.com/en-us/library/windows/desktop/aa375202(v=vs.85).aspx
+// The function succeeds with ERROR_NOT_ALL_ASSIGNED if the token does not have some of the privileges,
+// it is returned as the error, errors.Is(err, windows.ERROR_NOT_ALL_ASSIGNED) checks it.
+func AdjustTokenPrivileges(token windows.Token, disableAllPrivileges bool, newstate *TOKEN_PRIVILEGES, buflen uint32, prevstate *TOKEN_PRIVILEGES, returnlen *uint32) error {
 	var _p0 uint32
 	if disableAllPrivileges {
 		_p0 = 1
 	} else {
 		_p0 = 0
 	}
-	r0, _, e1 := syscall.Syscall6(procAdjustTokenPrivileges.Addr(), 6, uintptr(token), uintptr(_p0), uintptr(unsafe.Pointer(newstate)), uintptr(buflen), uintptr(unsafe.Pointer(prevstate)), uintptr(unsafe.Pointer(returnlen)))
-	ret = uint32(r0)
-	if true {
-		if e1 != 0 {
-			err = e1
-		} else {
-			err = syscall.EINVAL
-		}
-	}
-	return
-}
-
-// The AdjustTokenPrivileges function allows or prohibits specific privileged access tokens. TOKEN_ADJUST_PRIVILEGES access permission is required to enable or disable permissions in the access token.
-// https://msdn.microsoft.com/en-us/library/windows/desktop/aa375202(v=vs.85).aspx
-func AdjustTokenPrivileges(token windows.Token, disableAllPrivileges bool, newstate *TOKEN_PRIVILEGES, buflen uint32, prevstate *TOKEN_PRIVILEGES, returnlen *uint32) error {
-	ret, err := adjustTokenPrivileges(token, disableAllPrivileges, newstate, buflen, prevstate, returnlen)
-	if ret == 0 {
-		// AdjustTokenPrivileges call failed
-		return err
-	}
-	// AdjustTokenPrivileges call succeeded
-	if err == syscall.EINVAL {
-		// GetLastError returned ERROR_SUCCESS
-		return nil
+	r1, _, e1 := syscall.Syscall6(procAdjustTokenPrivileges.Addr(), 6, uintptr(token), uintptr(_p0), uintptr(unsafe.Pointer(newstate)), uintptr(buflen), uintptr(unsafe.Pointer(prevstate)), uintptr(unsafe.Pointer(returnlen)))
+	if r1 == 0 || e1 == windows.ERROR_NOT_ALL_ASSIGNED {
+		return newLastCallError(modadvapi32.Name, procAdjustTokenPrivileges.Name, r1, e1)
 	}
-	return err
+	return nil
 }
 
 // The LookupPrivilegeValue function retrieves a Locally Unique Identifier (LUID), a localized name used on the designated system to represent the designated authority.
 // https://docs.microsoft.com/en-us/windows/desktop/api/winbase/nf-winbase-lookupprivilegevaluea
-func LookupPrivilegeValue(systemname *uint16, name *uint16, luid *LUID) (err error) {
+func LookupPrivilegeValue(systemname *uint16, name *uint16, luid *LUID) error {
 	r1, _, e1 := syscall.Syscall(procLookupPrivilegeValueW.Addr(), 3, uintptr(unsafe.Pointer(systemname)), uintptr(unsafe.Pointer(name)), uintptr(unsafe.Pointer(luid)))
 	if r1 == 0 {
-		if e1 != 0 {
-			err = e1
-		} else {
-			err = syscall.EINVAL
-		}
+		return newLastCallError(modadvapi32.Name, procLookupPrivilegeValueW.Name, r1, e1)
 	}
-	return
+	return nil
 }
 
 //
@@ -91,16 +73,30 @@ func ConvertStringSecurityDescriptorToSecurityDescriptor(stringSecurityDescripto
 		uintptr(unsafe.Pointer(securityDescriptor)), uintptr(unsafe.Pointer(securityDescriptorSize)))
 
 	if r1 == 0 {
-		if e1 != ERROR_SUCCESS {
-			return e1
-		} else {
-			return syscall.EINVAL
-		}
+		return newLastCallError(modadvapi32.Name, procConvertStringSecurityDescriptorToSecurityDescriptorW.Name, r1, e1)
 	}
 
 	return nil
 }
 
+// The security attributes of the SDDL string, nil for the empty string.
+// free must be called after the object is created.
+func newSecurityAttributes(securityDescriptor string) (*windows.SecurityAttributes, func(), error) {
+	if len(securityDescriptor) == 0 {
+		return nil, func() {}, nil
+	}
+
+	security := new(windows.SecurityAttributes)
+	security.Length = uint32(unsafe.Sizeof(*security))
+	err := ConvertStringSecurityDescriptorToSecurityDescriptor(securityDescriptor, SDDL_REVISION_1, (*SecurityDescriptor)(unsafe.Pointer(&security.SecurityDescriptor)), nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("ConvertStringSecurityDescriptorToSecurityDescriptor, %w", err)
+	}
+	return security, func() {
+		LocalFree(windows.Pointer(unsafe.Pointer(security.SecurityDescriptor)))
+	}, nil
+}
+
 // https://docs.microsoft.com/en-us/windows/desktop/api/securitybaseapi/nf-securitybaseapi-getsecuritydescriptorsacl
 //BOOL GetSecurityDescriptorSacl(
 //  PSECURITY_DESCRIPTOR pSecurityDescriptor,
@@ -111,11 +107,7 @@ func ConvertStringSecurityDescriptorToSecurityDescriptor(stringSecurityDescripto
 func GetSecurityDescriptorSacl(securityDescriptor SecurityDescriptor, lpbSaclPresent *Bool, sacl **ACL, saclDefaulted *Bool) error {
 	r1, _, e1 := getSecurityDescriptorSacl.Call(uintptr(unsafe.Pointer(securityDescriptor)), uintptr(unsafe.Pointer(lpbSaclPresent)), uintptr(unsafe.Pointer(sacl)), uintptr(unsafe.Pointer(saclDefaulted)))
 	if r1 == 0 {
-		if e1 != ERROR_SUCCESS {
-			return e1
-		} else {
-			return syscall.EINVAL
-		}
+		return newLastCallError(modadvapi32.Name, getSecurityDescriptorSacl.Name, r1, e1)
 	}
 	return nil
 }
@@ -136,15 +128,12 @@ SetSecurityInfo(
 */
 func SetSecurityInfo(handle Handle, objectType SeObjectType, securityInfo SecurityInformation,
 	psidOwner, psidGroup PSId, pDacl, pSacl *ACL) error {
-	r1, _, e1 := setSecurityInfo.Call(uintptr(handle), uintptr(objectType), uintptr(securityInfo),
+	r1, _, _ := setSecurityInfo.Call(uintptr(handle), uintptr(objectType), uintptr(securityInfo),
 		uintptr(unsafe.Pointer(psidOwner)), uintptr(unsafe.Pointer(psidGroup)), uintptr(unsafe.Pointer(pDacl)),
 		uintptr(unsafe.Pointer(pSacl)))
 	if r1 != 0 {
-		if e1 != ERROR_SUCCESS {
-			return e1
-		} else {
-			return syscall.EINVAL
-		}
+		// The return value is the error code
+		return newErrnoCallError(modadvapi32.Name, setSecurityInfo.Name, r1)
 	}
 	return nil
 }
@@ -167,22 +156,162 @@ func SetNamedSecurityInfoW(objectName string, objectType SeObjectType, securityI
 		return err
 	}
 
-	r1, _, e1 := setNamedSecurityInfo.Call(uintptr(unsafe.Pointer(_objectName)), uintptr(objectType), uintptr(securityInfo),
+	r1, _, _ := setNamedSecurityInfo.Call(uintptr(unsafe.Pointer(_objectName)), uintptr(objectType), uintptr(securityInfo),
 		uintptr(unsafe.Pointer(psidOwner)), uintptr(unsafe.Pointer(psidGroup)), uintptr(unsafe.Pointer(pDacl)),
 		uintptr(unsafe.Pointer(pSacl)))
 	if r1 != 0 {
-		if e1 != ERROR_SUCCESS {
-			return e1
-		} else {
-			return syscall.EINVAL
-		}
+		// The return value is the error code
+		return newErrnoCallError(modadvapi32.Name, setNamedSecurityInfo.Name, r1)
+	}
+	return nil
+}
+
+// https://docs.microsoft.com/en-us/windows/win32/api/aclapi/nf-aclapi-getsecurityinfo
+//DWORD GetSecurityInfo(
+//  HANDLE               handle,
+//  SE_OBJECT_TYPE       ObjectType,
+//  SECURITY_INFORMATION SecurityInfo,
+//  PSID                 *ppsidOwner,
+//  PSID                 *ppsidGroup,
+//  PACL                 *ppDacl,
+//  PACL                 *ppSacl,
+//  PSECURITY_DESCRIPTOR *ppSecurityDescriptor
+//);
+// 注意：需要自己调用 LocalFree 释放 securityDescriptor 占用空间，ppsidOwner 等指针指向 securityDescriptor 内部。
+func getSecurityInfo(handle Handle, objectType SeObjectType, securityInfo SecurityInformation,
+	ppsidOwner, ppsidGroup *PSId, ppDacl, ppSacl **ACL, securityDescriptor *SecurityDescriptor) error {
+	r1, _, _ := procGetSecurityInfo.Call(uintptr(handle), uintptr(objectType), uintptr(securityInfo),
+		uintptr(unsafe.Pointer(ppsidOwner)), uintptr(unsafe.Pointer(ppsidGroup)), uintptr(unsafe.Pointer(ppDacl)),
+		uintptr(unsafe.Pointer(ppSacl)), uintptr(unsafe.Pointer(securityDescriptor)))
+	if r1 != 0 {
+		// The return value is the error code
+		return newErrnoCallError(modadvapi32.Name, procGetSecurityInfo.Name, r1)
+	}
+	return nil
+}
+
+// https://docs.microsoft.com/en-us/windows/win32/api/aclapi/nf-aclapi-getnamedsecurityinfow
+//DWORD GetNamedSecurityInfoW(
+//  LPCWSTR              pObjectName,
+//  SE_OBJECT_TYPE       ObjectType,
+//  SECURITY_INFORMATION SecurityInfo,
+//  PSID                 *ppsidOwner,
+//  PSID                 *ppsidGroup,
+//  PACL                 *ppDacl,
+//  PACL                 *ppSacl,
+//  PSECURITY_DESCRIPTOR *ppSecurityDescriptor
+//);
+// 注意：需要自己调用 LocalFree 释放 securityDescriptor 占用空间。
+func getNamedSecurityInfo(objectName string, objectType SeObjectType, securityInfo SecurityInformation,
+	ppsidOwner, ppsidGroup *PSId, ppDacl, ppSacl **ACL, securityDescriptor *SecurityDescriptor) error {
+	_objectName, err := windows.UTF16PtrFromString(objectName)
+	if err != nil {
+		return err
+	}
+
+	r1, _, _ := procGetNamedSecurityInfoW.Call(uintptr(unsafe.Pointer(_objectName)), uintptr(objectType), uintptr(securityInfo),
+		uintptr(unsafe.Pointer(ppsidOwner)), uintptr(unsafe.Pointer(ppsidGroup)), uintptr(unsafe.Pointer(ppDacl)),
+		uintptr(unsafe.Pointer(ppSacl)), uintptr(unsafe.Pointer(securityDescriptor)))
+	if r1 != 0 {
+		// The return value is the error code
+		return newErrnoCallError(modadvapi32.Name, procGetNamedSecurityInfoW.Name, r1)
+	}
+	return nil
+}
+
+// Copy the ACL pointed to by the windows api into go memory
+func aclToBytes(acl *ACL) []byte {
+	if acl == nil {
+		return nil
 	}
+	b := make([]byte, acl.AclSize)
+	copy(b, ToBytes(uintptr(unsafe.Pointer(acl)), int(acl.AclSize), int(acl.AclSize)))
+	return b
+}
+
+// Objects without a mandatory label are treated as medium integrity by the system
+func mandatoryLabelFromSacl(pSacl *ACL) (IntegrityLevel, MandatoryPolicy, error) {
+	if pSacl == nil {
+		return SECURITY_MANDATORY_MEDIUM_RID, SYSTEM_MANDATORY_LABEL_NO_WRITE_UP, nil
+	}
+
+	level, policy, found, err := ParseMandatoryLabelSacl(aclToBytes(pSacl))
+	if err != nil {
+		return 0, 0, fmt.Errorf("ParseMandatoryLabelSacl(), %w", err)
+	}
+	if found == false {
+		return SECURITY_MANDATORY_MEDIUM_RID, SYSTEM_MANDATORY_LABEL_NO_WRITE_UP, nil
+	}
+	return level, policy, nil
+}
+
+// Set the mandatory integrity label of the object
+func SetObjectIntegrityLevel(h Handle, objectType SeObjectType, level IntegrityLevel, policy MandatoryPolicy) error {
+	sacl, err := NewMandatoryLabelSacl(level, policy)
+	if err != nil {
+		return fmt.Errorf("NewMandatoryLabelSacl(), %w", err)
+	}
+
+	err = SetSecurityInfo(h, objectType, LABEL_SECURITY_INFORMATION,
+		nil, nil, nil, (*ACL)(unsafe.Pointer(&sacl[0])))
+	if err != nil {
+		return fmt.Errorf("SetSecurityInfo(), %w", err)
+	}
+
 	return nil
 }
 
+// Get the mandatory integrity label of the object
+func GetObjectIntegrityLevel(h Handle, objectType SeObjectType) (IntegrityLevel, MandatoryPolicy, error) {
+	var securityDescriptor SecurityDescriptor
+	var pSacl *ACL
+
+	err := getSecurityInfo(h, objectType, LABEL_SECURITY_INFORMATION, nil, nil, nil, &pSacl, &securityDescriptor)
+	if err != nil {
+		return 0, 0, fmt.Errorf("GetSecurityInfo(), %w", err)
+	}
+	defer func() {
+		LocalFree(windows.Pointer(securityDescriptor))
+	}()
+
+	return mandatoryLabelFromSacl(pSacl)
+}
+
+// Set the mandatory integrity label of the named object
+func SetObjectIntegrityLevelWithName(objectName string, objectType SeObjectType, level IntegrityLevel, policy MandatoryPolicy) error {
+	sacl, err := NewMandatoryLabelSacl(level, policy)
+	if err != nil {
+		return fmt.Errorf("NewMandatoryLabelSacl(), %w", err)
+	}
+
+	err = SetNamedSecurityInfoW(objectName, objectType, LABEL_SECURITY_INFORMATION,
+		nil, nil, nil, (*ACL)(unsafe.Pointer(&sacl[0])))
+	if err != nil {
+		return fmt.Errorf("SetNamedSecurityInfoW(), %w", err)
+	}
+
+	return nil
+}
+
+// Get the mandatory integrity label of the named object
+func GetObjectIntegrityLevelWithName(objectName string, objectType SeObjectType) (IntegrityLevel, MandatoryPolicy, error) {
+	var securityDescriptor SecurityDescriptor
+	var pSacl *ACL
+
+	err := getNamedSecurityInfo(objectName, objectType, LABEL_SECURITY_INFORMATION, nil, nil, nil, &pSacl, &securityDescriptor)
+	if err != nil {
+		return 0, 0, fmt.Errorf("GetNamedSecurityInfo(), %w", err)
+	}
+	defer func() {
+		LocalFree(windows.Pointer(securityDescriptor))
+	}()
+
+	return mandatoryLabelFromSacl(pSacl)
+}
+
 // Reduce the security level of kernel objects
 func SetObjectToLowIntegrity(h Handle) error {
-	return SetObjectIntegrity(h, LOW_INTEGRITY_SDDL_SACL_W)
+	return SetObjectIntegrityLevel(h, SE_KERNEL_OBJECT, SECURITY_MANDATORY_LOW_RID, SYSTEM_MANDATORY_LABEL_NO_WRITE_UP)
 }
 
 func SetObjectIntegrity(h Handle, stringSecurityDescriptor string) error {
@@ -193,7 +322,7 @@ func SetObjectIntegrity(h Handle, stringSecurityDescriptor string) error {
 	err := ConvertStringSecurityDescriptorToSecurityDescriptor(stringSecurityDescriptor, SDDL_REVISION_1,
 		&securityDescriptor, &securityDescriptorSize)
 	if err != nil {
-		return fmt.Errorf("ConvertStringSecurityDescriptorToSecurityDescriptor(), %v", err)
+		return fmt.Errorf("ConvertStringSecurityDescriptorToSecurityDescriptor(), %w", err)
 	}
 	defer func() {
 		LocalFree(windows.Pointer(securityDescriptor))
@@ -205,13 +334,13 @@ func SetObjectIntegrity(h Handle, stringSecurityDescriptor string) error {
 
 	err = GetSecurityDescriptorSacl(securityDescriptor, &fSaclPresent, &pSacl, &fSaclDefaulted)
 	if err != nil {
-		return fmt.Errorf("GetSecurityDescriptorSacl(), %v", err)
+		return fmt.Errorf("GetSecurityDescriptorSacl(), %w", err)
 	}
 
 	err = SetSecurityInfo(h, SE_KERNEL_OBJECT, LABEL_SECURITY_INFORMATION,
 		nil, nil, nil, pSacl)
 	if err != nil {
-		return fmt.Errorf("SetSecurityInfo(), %v", err)
+		return fmt.Errorf("SetSecurityInfo(), %w", err)
 	}
 
 	return nil
@@ -220,7 +349,7 @@ func SetObjectIntegrity(h Handle, stringSecurityDescriptor string) error {
 // Reduce the security level of kernel objects
 // I don't know what the reason is, it is invalid for mmap, mmap can be specified directly during creation.
 func SetObjectToLowIntegrityWithName(objectName string) error {
-	return SetObjectIntegrityWithName(objectName, LOW_INTEGRITY_SDDL_SACL_W)
+	return SetObjectIntegrityLevelWithName(objectName, SE_KERNEL_OBJECT, SECURITY_MANDATORY_LOW_RID, SYSTEM_MANDATORY_LABEL_NO_WRITE_UP)
 }
 
 func SetObjectIntegrityWithName(objectName string, stringSecurityDescriptor string) error {
@@ -231,7 +360,7 @@ func SetObjectIntegrityWithName(objectName string, stringSecurityDescriptor stri
 	err := ConvertStringSecurityDescriptorToSecurityDescriptor(stringSecurityDescriptor, SDDL_REVISION_1,
 		&securityDescriptor, &securityDescriptorSize)
 	if err != nil {
-		return fmt.Errorf("ConvertStringSecurityDescriptorToSecurityDescriptor(), %v", err)
+		return fmt.Errorf("ConvertStringSecurityDescriptorToSecurityDescriptor(), %w", err)
 	}
 	defer func() {
 		LocalFree(windows.Pointer(securityDescriptor))
@@ -243,14 +372,72 @@ func SetObjectIntegrityWithName(objectName string, stringSecurityDescriptor stri
 
 	err = GetSecurityDescriptorSacl(securityDescriptor, &fSaclPresent, &pSacl, &fSaclDefaulted)
 	if err != nil {
-		return fmt.Errorf("GetSecurityDescriptorSacl(), %v", err)
+		return fmt.Errorf("GetSecurityDescriptorSacl(), %w", err)
 	}
 
 	err = SetNamedSecurityInfoW(objectName, SE_KERNEL_OBJECT, LABEL_SECURITY_INFORMATION,
 		nil, nil, nil, pSacl)
 	if err != nil {
-		return fmt.Errorf("SetSecurityInfo(), %v", err)
+		return fmt.Errorf("SetSecurityInfo(), %w", err)
 	}
 
 	return nil
 }
+
+// https://docs.microsoft.com/en-us/windows/win32/api/securitybaseapi/nf-securitybaseapi-getsecuritydescriptorlength
+//DWORD GetSecurityDescriptorLength(
+//  PSECURITY_DESCRIPTOR pSecurityDescriptor
+//);
+func GetSecurityDescriptorLength(securityDescriptor SecurityDescriptor) uint32 {
+	r1, _, _ := procGetSecurityDescriptorLength.Call(uintptr(unsafe.Pointer(securityDescriptor)))
+	return uint32(r1)
+}
+
+// Copy the self-relative security descriptor returned by the windows api into go memory and decode it
+func securityDescriptorToInfo(securityDescriptor SecurityDescriptor) (*SecurityDescriptorInfo, error) {
+	size := int(GetSecurityDescriptorLength(securityDescriptor))
+	if size == 0 {
+		return nil, fmt.Errorf("GetSecurityDescriptorLength() == 0")
+	}
+
+	b := make([]byte, size)
+	copy(b, ToBytes(uintptr(unsafe.Pointer(securityDescriptor)), size, size))
+
+	sd, err := ParseSecurityDescriptor(b)
+	if err != nil {
+		return nil, fmt.Errorf("ParseSecurityDescriptor(), %w", err)
+	}
+	return sd, nil
+}
+
+// Get the security descriptor of the object
+// securityInfo selects the parts to be returned, use SDDL() to get the string form.
+func GetSecurityInfo(h Handle, objectType SeObjectType, securityInfo SecurityInformation) (*SecurityDescriptorInfo, error) {
+	var securityDescriptor SecurityDescriptor
+
+	err := getSecurityInfo(h, objectType, securityInfo, nil, nil, nil, nil, &securityDescriptor)
+	if err != nil {
+		return nil, fmt.Errorf("GetSecurityInfo(%v), %w", objectType, err)
+	}
+	defer func() {
+		LocalFree(windows.Pointer(securityDescriptor))
+	}()
+
+	return securityDescriptorToInfo(securityDescriptor)
+}
+
+// Get the security descriptor of the named object
+// securityInfo selects the parts to be returned, use SDDL() to get the string form.
+func GetNamedSecurityInfo(objectName string, objectType SeObjectType, securityInfo SecurityInformation) (*SecurityDescriptorInfo, error) {
+	var securityDescriptor SecurityDescriptor
+
+	err := getNamedSecurityInfo(objectName, objectType, securityInfo, nil, nil, nil, nil, &securityDescriptor)
+	if err != nil {
+		return nil, fmt.Errorf("GetNamedSecurityInfo(%v, %v), %w", objectName, objectType, err)
+	}
+	defer func() {
+		LocalFree(windows.Pointer(securityDescriptor))
+	}()
+
+	return securityDescriptorToInfo(securityDescriptor)
+}
@@ -1,6 +1,8 @@
 package gowindows
 
 import (
+	"io/ioutil"
+	"os"
 	"runtime"
 	"testing"
 
@@ -73,3 +75,83 @@ func TestSE_KERNEL_OBJECT(t *testing.T) {
 		t.Fatal("!=")
 	}
 }
+
+func TestSetObjectIntegrityLevel(t *testing.T) {
+	m, err := CreateMmap("gowindows_integrity_level", 1024, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer m.Close()
+
+	err = SetObjectIntegrityLevel(m.GetHandle(), SE_KERNEL_OBJECT, SECURITY_MANDATORY_LOW_RID,
+		SYSTEM_MANDATORY_LABEL_NO_WRITE_UP|SYSTEM_MANDATORY_LABEL_NO_READ_UP)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	level, policy, err := GetObjectIntegrityLevel(m.GetHandle(), SE_KERNEL_OBJECT)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if level != SECURITY_MANDATORY_LOW_RID {
+		t.Errorf("%v!=Low", level)
+	}
+	if policy != SYSTEM_MANDATORY_LABEL_NO_WRITE_UP|SYSTEM_MANDATORY_LABEL_NO_READ_UP {
+		t.Errorf("%v!=NO_WRITE_UP|NO_READ_UP", policy)
+	}
+}
+
+// Setting the label by name is invalid for mmap, a file supports it.
+func TestSetObjectIntegrityLevelWithName(t *testing.T) {
+	f, err := ioutil.TempFile("", "gowindows_integrity_level_name")
+	if err != nil {
+		t.Fatal(err)
+	}
+	name := f.Name()
+	f.Close()
+	defer os.Remove(name)
+
+	err = SetObjectIntegrityLevelWithName(name, SE_FILE_OBJECT, SECURITY_MANDATORY_LOW_RID, SYSTEM_MANDATORY_LABEL_NO_WRITE_UP)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	level, policy, err := GetObjectIntegrityLevelWithName(name, SE_FILE_OBJECT)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if level != SECURITY_MANDATORY_LOW_RID || policy != SYSTEM_MANDATORY_LABEL_NO_WRITE_UP {
+		t.Errorf("%v %v", level, policy)
+	}
+}
+
+func TestGetSecurityInfo(t *testing.T) {
+	m, err := CreateMmapWithSecurityDescriptor("gowindows_get_security_info", 1024, true, "D:(A;;GA;;;WD)S:(ML;;NW;;;LW)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer m.Close()
+
+	sd, err := GetSecurityInfo(m.GetHandle(), SE_KERNEL_OBJECT, OWNER_SECURITY_INFORMATION|DACL_SECURITY_INFORMATION|LABEL_SECURITY_INFORMATION)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sd.Owner == nil || sd.Dacl == nil {
+		t.Fatal(sd.SDDL())
+	}
+	if len(sd.Dacl.Aces) != 1 || sd.Dacl.Aces[0].Sid.SDDL() != "WD" {
+		t.Errorf("dacl %v", sd.SDDL())
+	}
+	if level, _, found := sd.MandatoryLabel(); found == false || level != SECURITY_MANDATORY_LOW_RID {
+		t.Errorf("sacl %v", sd.SDDL())
+	}
+
+	sd2, err := GetNamedSecurityInfo("gowindows_get_security_info", SE_KERNEL_OBJECT, DACL_SECURITY_INFORMATION)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// GA is mapped to the specific rights of the section object
+	if sd2.Owner != nil || sd2.Dacl == nil || len(sd2.Dacl.Aces) != 1 {
+		t.Errorf("%v", sd2.SDDL())
+	}
+}
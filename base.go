@@ -5,8 +5,6 @@ import (
 	"syscall"
 	"unsafe"
 
-	"golang.org/x/sys/windows"
-
 	"reflect"
 )
 
@@ -20,7 +18,7 @@ const NO_ERROR = 0
 
 const ERROR_IO_PENDING = 997
 
-const INFINITE = syscall.INFINITE
+const INFINITE = 0xFFFFFFFF
 
 // Convert to []byte slice
 // Note, please ensure that the memory reference, according to the documentation, reflect.SliceHeader will not save the data pointer, may be garbage collected.
@@ -65,23 +63,9 @@ type HMODULE = Handle
 type WCHAR = wchar_t
 type wchar_t = uint16
 
-type Overlapped = windows.Overlapped
-
 // https://blog.csdn.net/ixsea/article/details/7272909
 type HRESULT uint32
 
 func (h HRESULT) IsSucceeded() bool {
 	return uint32(h)&(uint32(1)<<31) == 0
 }
-
-type CallError struct {
-	r1 DWord
-}
-
-func newCallError(r1 DWord) error {
-	return &CallError{r1: r1}
-}
-
-func (e *CallError) Error() string {
-	return fmt.Sprintf("r1:%X", e.r1)
-}
@@ -11,6 +11,7 @@ type GUID = windows.GUID
 type SID = windows.SID
 type Pointer = windows.Pointer
 type Handle = syscall.Handle
+type Overlapped = windows.Overlapped
 
 func FormatMessage(errno uint32, msgSrc uintptr) (string, error) {
 	const flags uint32 = windows.FORMAT_MESSAGE_ALLOCATE_BUFFER | windows.FORMAT_MESSAGE_FROM_HMODULE | windows.FORMAT_MESSAGE_FROM_SYSTEM | windows.FORMAT_MESSAGE_ARGUMENT_ARRAY | windows.FORMAT_MESSAGE_IGNORE_INSERTS
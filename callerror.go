@@ -0,0 +1,203 @@
+package gowindows
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"syscall"
+)
+
+// The error of the windows api, the code is the decoded return value or the last error.
+// The message is formatted by the tables of the package instead of FormatMessage, it is the same on windows and linux.
+//
+//	err := FwpmFilterAdd0(engine, &filter, 0, &id)
+//	if errors.Is(err, FWP_E_ALREADY_EXISTS) { ... } // the code
+//	if errors.Is(err, ErrAlreadyExists) { ... }     // the sentinel of the codes
+//	var callErr *CallError
+//	if errors.As(err, &callErr) { fmt.Println(callErr.Func, callErr.R1) }
+type CallError struct {
+	Dll  string // such as kernel32.dll
+	Func string // such as CreateSemaphoreW
+	R1   uintptr
+	// The last error of the function which sets it, ERROR_SUCCESS if the function does not set it.
+	LastError syscall.Errno
+	// syscall.Errno, NTStatus or HRESULT, it is syscall.EINVAL if the function fails without the last error.
+	Code error
+}
+
+// The sentinels of the codes of the different kinds, such as ErrNotFound is ERROR_FILE_NOT_FOUND,
+// STATUS_OBJECT_NAME_NOT_FOUND and FWP_E_FILTER_NOT_FOUND.
+var (
+	ErrAccessDenied       = errors.New("access denied")
+	ErrNotFound           = errors.New("not found")
+	ErrAlreadyExists      = errors.New("already exists")
+	ErrInsufficientBuffer = errors.New("insufficient buffer")
+	ErrInvalidParameter   = errors.New("invalid parameter")
+	ErrNotSupported       = errors.New("not supported")
+	ErrTimeout            = errors.New("timeout")
+)
+
+// The codes of the sentinels, NTStatus and HRESULT match the win32 errors of them.
+var sentinelCodes = map[error][]error{
+	ErrAccessDenied: {syscall.Errno(5)},
+	ErrNotFound: {syscall.Errno(2), syscall.Errno(3), syscall.Errno(1168),
+		FWP_E_CALLOUT_NOT_FOUND, FWP_E_CONDITION_NOT_FOUND, FWP_E_FILTER_NOT_FOUND, FWP_E_LAYER_NOT_FOUND,
+		FWP_E_PROVIDER_NOT_FOUND, FWP_E_PROVIDER_CONTEXT_NOT_FOUND, FWP_E_SUBLAYER_NOT_FOUND, FWP_E_NOT_FOUND},
+	ErrAlreadyExists:      {syscall.Errno(80), syscall.Errno(183), syscall.Errno(5010), FWP_E_ALREADY_EXISTS},
+	ErrInsufficientBuffer: {syscall.Errno(24), syscall.Errno(111), syscall.Errno(122), syscall.Errno(234)},
+	ErrInvalidParameter:   {syscall.Errno(87), FWP_E_INVALID_PARAMETER},
+	ErrNotSupported:       {syscall.Errno(50), syscall.Errno(120), E_NOTIMPL},
+	ErrTimeout:            {syscall.Errno(121), syscall.Errno(258), syscall.Errno(1460), FWP_E_TIMEOUT},
+}
+
+func newCallError(dll, fn string, r1 uintptr, lastError syscall.Errno, code error) error {
+	return &CallError{Dll: dll, Func: fn, R1: r1, LastError: lastError, Code: code}
+}
+
+// The function returns FALSE or NULL and sets the last error.
+func newLastCallError(dll, fn string, r1 uintptr, e1 error) error {
+	lastError, _ := e1.(syscall.Errno)
+	var code error = lastError
+	if lastError == ERROR_SUCCESS {
+		code = syscall.EINVAL
+	}
+	return newCallError(dll, fn, r1, lastError, code)
+}
+
+// The return value is the win32 error, such as the functions of iphlpapi.
+func newErrnoCallError(dll, fn string, r1 uintptr) error {
+	return newCallError(dll, fn, r1, ERROR_SUCCESS, syscall.Errno(r1))
+}
+
+// The return value is FWP_E_* or the win32 error.
+func newFwpmCallError(dll, fn string, r1 uintptr) error {
+	return newCallError(dll, fn, r1, ERROR_SUCCESS, decodeFwpmError(uint32(r1)))
+}
+
+// The return value is NTSTATUS.
+func newNTStatusCallError(dll, fn string, r1 uintptr) error {
+	return newCallError(dll, fn, r1, ERROR_SUCCESS, NTStatus(r1))
+}
+
+// The return value is HRESULT.
+func newHRESULTCallError(dll, fn string, r1 uintptr) error {
+	return newCallError(dll, fn, r1, ERROR_SUCCESS, HRESULT(r1))
+}
+
+// The DWORD of the Fwpm* functions, it is FWP_E_* or the win32 error.
+func decodeFwpmError(r1 uint32) error {
+	if HRESULT(r1).IsFailed() {
+		return HRESULT(r1)
+	}
+	return syscall.Errno(r1)
+}
+
+func (e *CallError) Error() string {
+	s := fmt.Sprintf("%v (%v), ", e.Func, e.Dll)
+	if e.Code == syscall.EINVAL && e.LastError == ERROR_SUCCESS {
+		return s + fmt.Sprintf("the function failed without the last error, r1 0x%X", e.R1)
+	}
+	s += formatErrorCode(e.Code)
+	if e.LastError != ERROR_SUCCESS && e.Code != e.LastError {
+		s += ", last error " + win32ErrorString(e.LastError)
+	}
+	return s
+}
+
+func (e *CallError) Unwrap() error {
+	return e.Code
+}
+
+// errors.Is, the sentinels match the codes of them,
+// and the os errors match the win32 errors as windows on linux.
+func (e *CallError) Is(target error) bool {
+	switch target {
+	case os.ErrPermission, os.ErrExist, os.ErrNotExist:
+		if code, ok := e.Code.(syscall.Errno); ok {
+			return NTStatusFromWin32(code).Is(target)
+		}
+		return false
+	}
+	codes, ok := sentinelCodes[target]
+	if ok == false {
+		return false
+	}
+	for _, code := range codes {
+		if errors.Is(e.Code, code) {
+			return true
+		}
+	}
+	return false
+}
+
+func formatErrorCode(code error) string {
+	if e, ok := code.(syscall.Errno); ok {
+		return win32ErrorString(e)
+	}
+	return code.Error()
+}
+
+// Such as ERROR_ACCESS_DENIED (5): Access is denied.
+func win32ErrorString(e syscall.Errno) string {
+	if info, ok := win32ErrorTable[e]; ok {
+		return fmt.Sprintf("%v (%d): %v", info.name, uint32(e), info.message)
+	}
+	return fmt.Sprintf("win32 error %d (0x%X)", uint32(e), uint32(e))
+}
+
+type win32ErrorInfo struct {
+	name    string
+	message string
+}
+
+// The common win32 errors of the package
+// https://docs.microsoft.com/en-us/windows/win32/debug/system-error-codes
+var win32ErrorTable = map[syscall.Errno]win32ErrorInfo{
+	0:    {"ERROR_SUCCESS", "The operation completed successfully."},
+	1:    {"ERROR_INVALID_FUNCTION", "Incorrect function."},
+	2:    {"ERROR_FILE_NOT_FOUND", "The system cannot find the file specified."},
+	3:    {"ERROR_PATH_NOT_FOUND", "The system cannot find the path specified."},
+	4:    {"ERROR_TOO_MANY_OPEN_FILES", "The system cannot open the file."},
+	5:    {"ERROR_ACCESS_DENIED", "Access is denied."},
+	6:    {"ERROR_INVALID_HANDLE", "The handle is invalid."},
+	8:    {"ERROR_NOT_ENOUGH_MEMORY", "Not enough memory resources are available to process this command."},
+	13:   {"ERROR_INVALID_DATA", "The data is invalid."},
+	14:   {"ERROR_OUTOFMEMORY", "Not enough memory resources are available to complete this operation."},
+	18:   {"ERROR_NO_MORE_FILES", "There are no more files."},
+	24:   {"ERROR_BAD_LENGTH", "The program issued a command but the command length is incorrect."},
+	31:   {"ERROR_GEN_FAILURE", "A device attached to the system is not functioning."},
+	32:   {"ERROR_SHARING_VIOLATION", "The process cannot access the file because it is being used by another process."},
+	50:   {"ERROR_NOT_SUPPORTED", "The request is not supported."},
+	80:   {"ERROR_FILE_EXISTS", "The file exists."},
+	87:   {"ERROR_INVALID_PARAMETER", "The parameter is incorrect."},
+	109:  {"ERROR_BROKEN_PIPE", "The pipe has been ended."},
+	111:  {"ERROR_BUFFER_OVERFLOW", "The file name is too long."},
+	120:  {"ERROR_CALL_NOT_IMPLEMENTED", "This function is not supported on this system."},
+	121:  {"ERROR_SEM_TIMEOUT", "The semaphore timeout period has expired."},
+	122:  {"ERROR_INSUFFICIENT_BUFFER", "The data area passed to a system call is too small."},
+	123:  {"ERROR_INVALID_NAME", "The filename, directory name, or volume label syntax is incorrect."},
+	126:  {"ERROR_MOD_NOT_FOUND", "The specified module could not be found."},
+	127:  {"ERROR_PROC_NOT_FOUND", "The specified procedure could not be found."},
+	145:  {"ERROR_DIR_NOT_EMPTY", "The directory is not empty."},
+	161:  {"ERROR_BAD_PATHNAME", "The specified path is invalid."},
+	183:  {"ERROR_ALREADY_EXISTS", "Cannot create a file when that file already exists."},
+	193:  {"ERROR_BAD_EXE_FORMAT", "The file is not a valid Win32 application."},
+	232:  {"ERROR_NO_DATA", "The pipe is being closed."},
+	234:  {"ERROR_MORE_DATA", "More data is available."},
+	258:  {"WAIT_TIMEOUT", "The wait operation timed out."},
+	259:  {"ERROR_NO_MORE_ITEMS", "No more data is available."},
+	288:  {"ERROR_NOT_OWNER", "Attempt to release mutex not owned by caller."},
+	298:  {"ERROR_TOO_MANY_POSTS", "Too many posts were made to a semaphore."},
+	299:  {"ERROR_PARTIAL_COPY", "Only part of a ReadProcessMemory or WriteProcessMemory request was completed."},
+	317:  {"ERROR_MR_MID_NOT_FOUND", "The system cannot find message text for the message number."},
+	995:  {"ERROR_OPERATION_ABORTED", "The I/O operation has been aborted because of either a thread exit or an application request."},
+	997:  {"ERROR_IO_PENDING", "Overlapped I/O operation is in progress."},
+	998:  {"ERROR_NOACCESS", "Invalid access to memory location."},
+	1004: {"ERROR_INVALID_FLAGS", "Invalid flags."},
+	1113: {"ERROR_NO_UNICODE_TRANSLATION", "No mapping for the Unicode character exists in the target multi-byte code page."},
+	1168: {"ERROR_NOT_FOUND", "Element not found."},
+	1300: {"ERROR_NOT_ALL_ASSIGNED", "Not all privileges or groups referenced are assigned to the caller."},
+	1314: {"ERROR_PRIVILEGE_NOT_HELD", "A required privilege is not held by the client."},
+	1460: {"ERROR_TIMEOUT", "This operation returned because the timeout period expired."},
+	5010: {"ERROR_OBJECT_ALREADY_EXISTS", "The object already exists."},
+}
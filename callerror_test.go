@@ -0,0 +1,112 @@
+package gowindows
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"syscall"
+	"testing"
+)
+
+func TestCallErrorError(t *testing.T) {
+	data := []struct {
+		err  error
+		want string
+	}{
+		{newLastCallError("kernel32.dll", "OpenSemaphoreW", 0, syscall.Errno(2)),
+			"OpenSemaphoreW (kernel32.dll), ERROR_FILE_NOT_FOUND (2): The system cannot find the file specified."},
+		{newLastCallError("kernel32.dll", "LocalFree", 0x1234, ERROR_SUCCESS),
+			"LocalFree (kernel32.dll), the function failed without the last error, r1 0x1234"},
+		{newErrnoCallError("iphlpapi.dll", "GetIpForwardTable", 0x2AF9),
+			"GetIpForwardTable (iphlpapi.dll), win32 error 11001 (0x2AF9)"},
+		{newNTStatusCallError("ntdll.dll", "NtQueryInformationProcess", uintptr(STATUS_ACCESS_DENIED)),
+			"NtQueryInformationProcess (ntdll.dll), " + STATUS_ACCESS_DENIED.Error()},
+		{newHRESULTCallError("ole32.dll", "CLSIDFromString", uintptr(CO_E_CLASSSTRING)),
+			"CLSIDFromString (ole32.dll), " + CO_E_CLASSSTRING.Error()},
+		{newFwpmCallError("fwpuclnt.dll", "FwpmFilterAdd0", uintptr(FWP_E_ALREADY_EXISTS)),
+			"FwpmFilterAdd0 (fwpuclnt.dll), " + FWP_E_ALREADY_EXISTS.Error()},
+		{newFwpmCallError("fwpuclnt.dll", "FwpmEngineOpen0", 5),
+			"FwpmEngineOpen0 (fwpuclnt.dll), ERROR_ACCESS_DENIED (5): Access is denied."},
+		{newCallError("kernel32.dll", "CreateFileW", 0, 5, syscall.Errno(2)),
+			"CreateFileW (kernel32.dll), ERROR_FILE_NOT_FOUND (2): The system cannot find the file specified., last error ERROR_ACCESS_DENIED (5): Access is denied."},
+	}
+	for i, v := range data {
+		if s := v.err.Error(); s != v.want {
+			t.Errorf("%v: %v!=%v", i, s, v.want)
+		}
+	}
+}
+
+func TestCallErrorIs(t *testing.T) {
+	data := []struct {
+		err    error
+		target error
+		want   bool
+	}{
+		{newLastCallError("kernel32.dll", "OpenSemaphoreW", 0, syscall.Errno(2)), syscall.Errno(2), true},
+		{newLastCallError("kernel32.dll", "OpenSemaphoreW", 0, syscall.Errno(2)), ErrNotFound, true},
+		{newLastCallError("kernel32.dll", "OpenSemaphoreW", 0, syscall.Errno(2)), os.ErrNotExist, true},
+		{newLastCallError("kernel32.dll", "OpenSemaphoreW", 0, syscall.Errno(2)), ErrAccessDenied, false},
+		{newLastCallError("kernel32.dll", "LocalFree", 0x1234, ERROR_SUCCESS), syscall.EINVAL, true},
+		{newErrnoCallError("advapi32.dll", "SetSecurityInfo", 5), ErrAccessDenied, true},
+		{newErrnoCallError("advapi32.dll", "SetSecurityInfo", 5), os.ErrPermission, true},
+		{newNTStatusCallError("ntdll.dll", "NtQueryInformationProcess", uintptr(STATUS_ACCESS_DENIED)), STATUS_ACCESS_DENIED, true},
+		{newNTStatusCallError("ntdll.dll", "NtQueryInformationProcess", uintptr(STATUS_ACCESS_DENIED)), syscall.Errno(5), true},
+		{newNTStatusCallError("ntdll.dll", "NtQueryInformationProcess", uintptr(STATUS_ACCESS_DENIED)), ErrAccessDenied, true},
+		{newNTStatusCallError("ntdll.dll", "NtQuerySystemInformation", uintptr(STATUS_BUFFER_TOO_SMALL)), ErrInsufficientBuffer, true},
+		{newNTStatusCallError("ntdll.dll", "NtOpenFile", uintptr(STATUS_OBJECT_NAME_NOT_FOUND)), ErrNotFound, true},
+		{newHRESULTCallError("ole32.dll", "CoCreateInstance", uintptr(E_NOTIMPL)), ErrNotSupported, true},
+		{newHRESULTCallError("ole32.dll", "CoCreateInstance", uintptr(E_ACCESSDENIED)), ErrAccessDenied, true},
+		{newFwpmCallError("fwpuclnt.dll", "FwpmFilterAdd0", uintptr(FWP_E_ALREADY_EXISTS)), FWP_E_ALREADY_EXISTS, true},
+		{newFwpmCallError("fwpuclnt.dll", "FwpmFilterAdd0", uintptr(FWP_E_ALREADY_EXISTS)), ErrAlreadyExists, true},
+		{newFwpmCallError("fwpuclnt.dll", "FwpmFilterDeleteById0", uintptr(FWP_E_FILTER_NOT_FOUND)), ErrNotFound, true},
+		{newFwpmCallError("fwpuclnt.dll", "FwpmFilterDeleteById0", uintptr(FWP_E_FILTER_NOT_FOUND)), ErrAlreadyExists, false},
+		{newFwpmCallError("fwpuclnt.dll", "FwpmEngineOpen0", 5), ErrAccessDenied, true},
+		{fmt.Errorf("open filter, %w", newFwpmCallError("fwpuclnt.dll", "FwpmFilterAdd0", uintptr(FWP_E_TIMEOUT))), ErrTimeout, true},
+	}
+	for i, v := range data {
+		if r := errors.Is(v.err, v.target); r != v.want {
+			t.Errorf("%v: errors.Is(%v, %v) %v!=%v", i, v.err, v.target, r, v.want)
+		}
+	}
+}
+
+func TestCallErrorAs(t *testing.T) {
+	err := fmt.Errorf("query, %w", newNTStatusCallError("ntdll.dll", "NtQueryInformationProcess", uintptr(STATUS_ACCESS_DENIED)))
+
+	var callErr *CallError
+	if errors.As(err, &callErr) == false {
+		t.Fatalf("%v is not CallError", err)
+	}
+	if callErr.Dll != "ntdll.dll" || callErr.Func != "NtQueryInformationProcess" {
+		t.Errorf("%v!=ntdll.dll NtQueryInformationProcess", callErr.Func)
+	}
+	if callErr.R1 != uintptr(STATUS_ACCESS_DENIED) || callErr.LastError != ERROR_SUCCESS {
+		t.Errorf("0x%X, %v!=0x%X, 0", callErr.R1, callErr.LastError, uint32(STATUS_ACCESS_DENIED))
+	}
+
+	var status NTStatus
+	if errors.As(err, &status) == false || status != STATUS_ACCESS_DENIED {
+		t.Errorf("%v!=STATUS_ACCESS_DENIED", status.String())
+	}
+	if errors.Unwrap(callErr) != STATUS_ACCESS_DENIED {
+		t.Errorf("%v!=STATUS_ACCESS_DENIED", errors.Unwrap(callErr))
+	}
+}
+
+func TestDecodeFwpmError(t *testing.T) {
+	data := []struct {
+		r1   uint32
+		want error
+	}{
+		{0x80320009, FWP_E_ALREADY_EXISTS},
+		{0x80320003, FWP_E_FILTER_NOT_FOUND},
+		{5, syscall.Errno(5)},
+		{1168, syscall.Errno(1168)},
+	}
+	for i, v := range data {
+		if err := decodeFwpmError(v.r1); err != v.want {
+			t.Errorf("%v: %v!=%v", i, err, v.want)
+		}
+	}
+}
@@ -0,0 +1,375 @@
+package gowindows
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Windows command line
+// The command line of windows is one string, the program splits it to the arguments.
+// EscapeArg and makeCmdLine build it with the MSVCRT rules, SplitCommandLine and SplitCommandLineCRT split it.
+// EscapeArgFor and MakeCmdLineFor escape it for cmd.exe and PowerShell.
+
+// Escape the argument by the MSVCRT rules
+// The argument with spaces is quoted, the quotes and the backslashes before them are escaped by backslashes.
+func EscapeArg(s string) string {
+	if len(s) == 0 {
+		return "\"\""
+	}
+	n := len(s)
+	hasSpace := false
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '"', '\\':
+			n++
+		case ' ', '\t':
+			hasSpace = true
+		}
+	}
+	if hasSpace {
+		n += 2
+	}
+	if n == len(s) {
+		return s
+	}
+
+	qs := make([]byte, n)
+	j := 0
+	if hasSpace {
+		qs[j] = '"'
+		j++
+	}
+	slashes := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		default:
+			slashes = 0
+			qs[j] = s[i]
+		case '\\':
+			slashes++
+			qs[j] = s[i]
+		case '"':
+			for ; slashes > 0; slashes-- {
+				qs[j] = '\\'
+				j++
+			}
+			qs[j] = '\\'
+			j++
+			qs[j] = s[i]
+		}
+		j++
+	}
+	if hasSpace {
+		for ; slashes > 0; slashes-- {
+			qs[j] = '\\'
+			j++
+		}
+		qs[j] = '"'
+		j++
+	}
+	return string(qs[:j])
+}
+
+// makeCmdLine builds a command line out of args by escaping "special"
+// characters and joining the arguments with spaces.
+func makeCmdLine(args []string) string {
+	var s string
+	for _, v := range args {
+		if s != "" {
+			s += " "
+		}
+		s += EscapeArg(v)
+	}
+	return s
+}
+
+// The shell which parses the command line before the program
+type Shell int
+
+const (
+	// No shell, the program splits the command line by the MSVCRT rules, the same as EscapeArg.
+	ShellCRT Shell = iota
+	// The command line of cmd.exe /c, and then the program splits it by the MSVCRT rules.
+	ShellCmd
+	// The same as ShellCmd, with the delayed expansion of !var! (cmd.exe /v:on /c).
+	ShellCmdDelayedExpansion
+	// The string literal of PowerShell.
+	ShellPowerShell
+)
+
+func (s Shell) String() string {
+	switch s {
+	case ShellCRT:
+		return "CRT"
+	case ShellCmd:
+		return "cmd"
+	case ShellCmdDelayedExpansion:
+		return "cmd /v:on"
+	case ShellPowerShell:
+		return "PowerShell"
+	}
+	return fmt.Sprintf("Shell(%d)", int(s))
+}
+
+// Escape the argument for the shell
+//
+// ShellCmd escapes the argument by the MSVCRT rules first, then the metacharacters of cmd.exe
+// ( ) % ! ^ " < > & | are escaped by the carets, so cmd.exe never sees the quotes and the special characters.
+// %var% is not expanded because the variable name ends with the caret, cmd.exe keeps the undefined variable
+// of the command line unchanged, it does not work in the batch files, where % must be doubled.
+// The line feed can not be passed through cmd.exe.
+//
+// With ShellCmdDelayedExpansion, cmd.exe removes the carets again only if the line contains !,
+// so the carets are escaped twice if the argument contains !, otherwise it is the same as ShellCmd.
+// If other parts of the command line contain !, use MakeCmdLineFor to escape the whole line.
+//
+// ShellPowerShell quotes the argument by the single quotes, the single quotes in it are doubled,
+// including the curly quotes which are the single quotes of PowerShell too.
+// The string is passed to the native programs by PowerShell, the quotes in it are escaped only by PowerShell 7.3 and later.
+func EscapeArgFor(shell Shell, arg string) string {
+	switch shell {
+	case ShellCmd:
+		return escapeCmdArg(arg, false)
+	case ShellCmdDelayedExpansion:
+		return escapeCmdArg(arg, strings.Contains(arg, "!"))
+	case ShellPowerShell:
+		return escapePowerShellArg(arg)
+	}
+	return EscapeArg(arg)
+}
+
+// Build the command line for the shell by escaping and joining the arguments with spaces
+// The carets of ShellCmdDelayedExpansion are escaped twice if any argument contains !.
+func MakeCmdLineFor(shell Shell, args []string) string {
+	delayed := false
+	if shell == ShellCmdDelayedExpansion {
+		for _, v := range args {
+			if strings.Contains(v, "!") {
+				delayed = true
+				break
+			}
+		}
+	}
+
+	var b strings.Builder
+	for i, v := range args {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		switch shell {
+		case ShellCmd, ShellCmdDelayedExpansion:
+			b.WriteString(escapeCmdArg(v, delayed))
+		default:
+			b.WriteString(EscapeArgFor(shell, v))
+		}
+	}
+	return b.String()
+}
+
+// delayed: the line contains ! and the delayed expansion is enabled, cmd.exe removes the carets twice.
+func escapeCmdArg(arg string, delayed bool) string {
+	s := EscapeArg(arg)
+	var b strings.Builder
+	b.Grow(len(s) * 2)
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch c {
+		case '(', ')', '%', '"', '<', '>', '&', '|':
+			b.WriteByte('^')
+		case '!':
+			if delayed {
+				b.WriteString("^^")
+			}
+		case '^':
+			if delayed {
+				b.WriteString("^^^")
+			} else {
+				b.WriteByte('^')
+			}
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
+
+func escapePowerShellArg(arg string) string {
+	var b strings.Builder
+	b.Grow(len(arg) + 2)
+	b.WriteByte('\'')
+	for _, r := range arg {
+		if isPowerShellQuote(r) {
+			b.WriteRune(r)
+		}
+		b.WriteRune(r)
+	}
+	b.WriteByte('\'')
+	return b.String()
+}
+
+// ' and the curly single quotes
+func isPowerShellQuote(r rune) bool {
+	return r == '\'' || r == '\u2018' || r == '\u2019' || r == '\u201A' || r == '\u201B'
+}
+
+// Split the command line, the same as CommandLineToArgvW
+// The program name is the first argument, it ends at the next quote if it starts with a quote,
+// otherwise it ends at the first space or tab, the backslashes and the quotes in it are not special.
+// The other arguments are split by the spaces and tabs outside the quotes:
+//
+//	2N backslashes + "   N backslashes, the quote begins or ends the quoted part
+//	2N+1 backslashes + " N backslashes and a literal quote
+//	N backslashes        N backslashes
+//	"" in the quotes     a literal quote, and the quoted part ends
+//
+// Different from CommandLineToArgvW, the empty command line returns nil instead of the path of the current program.
+func SplitCommandLine(cmdLine string) []string {
+	if cmdLine == "" {
+		return nil
+	}
+
+	s := cmdLine
+	i := 0
+	var argv []string
+	if s[0] == '"' {
+		i = 1
+		for i < len(s) && s[i] != '"' {
+			i++
+		}
+		argv = append(argv, s[1:i])
+		if i < len(s) {
+			i++
+		}
+	} else {
+		for i < len(s) && s[i] != ' ' && s[i] != '\t' {
+			i++
+		}
+		argv = append(argv, s[:i])
+	}
+
+	for i < len(s) && (s[i] == ' ' || s[i] == '\t') {
+		i++
+	}
+	if i == len(s) {
+		return argv
+	}
+
+	// The port of the parser of wine, it is tested to be the same as windows.
+	arg := make([]byte, 0, len(s)-i)
+	quotes := 0
+	backslashes := 0
+	for i < len(s) {
+		switch c := s[i]; {
+		case (c == ' ' || c == '\t') && quotes == 0:
+			argv = append(argv, string(arg))
+			arg = arg[:0]
+			for i < len(s) && (s[i] == ' ' || s[i] == '\t') {
+				i++
+			}
+			if i == len(s) {
+				return argv
+			}
+			backslashes = 0
+		case c == '\\':
+			arg = append(arg, c)
+			i++
+			backslashes++
+		case c == '"':
+			arg = arg[:len(arg)-backslashes/2]
+			if backslashes%2 == 0 {
+				quotes++
+			} else {
+				arg[len(arg)-1] = '"'
+			}
+			i++
+			backslashes = 0
+			// The quotes count includes the opening quote and this quote
+			for i < len(s) && s[i] == '"' {
+				quotes++
+				if quotes == 3 {
+					arg = append(arg, '"')
+					quotes = 0
+				}
+				i++
+			}
+			if quotes == 2 {
+				quotes = 0
+			}
+		default:
+			arg = append(arg, c)
+			i++
+			backslashes = 0
+		}
+	}
+	return append(argv, string(arg))
+}
+
+// Split the command line by the MSVCRT rules, the same as argv of the C programs (the universal CRT)
+// The quotes of the program name begin or end the quoted part, it ends at the first space or tab outside the quotes.
+// The other arguments are the same as SplitCommandLine, except that "" in the quotes is a literal quote
+// and the quoted part continues.
+func SplitCommandLineCRT(cmdLine string) []string {
+	if cmdLine == "" {
+		return nil
+	}
+
+	s := cmdLine
+	i := 0
+	inQuotes := false
+	arg := make([]byte, 0, len(s))
+	for ; i < len(s); i++ {
+		c := s[i]
+		if c == '"' {
+			inQuotes = inQuotes == false
+			continue
+		}
+		if inQuotes == false && (c == ' ' || c == '\t') {
+			break
+		}
+		arg = append(arg, c)
+	}
+	argv := []string{string(arg)}
+
+	for {
+		for i < len(s) && (s[i] == ' ' || s[i] == '\t') {
+			i++
+		}
+		if i == len(s) {
+			return argv
+		}
+
+		arg = arg[:0]
+		for {
+			backslashes := 0
+			for i < len(s) && s[i] == '\\' {
+				i++
+				backslashes++
+			}
+
+			copyChar := true
+			if i < len(s) && s[i] == '"' {
+				if backslashes%2 == 0 {
+					if inQuotes && i+1 < len(s) && s[i+1] == '"' {
+						// "" in the quotes
+						i++
+					} else {
+						copyChar = false
+						inQuotes = inQuotes == false
+					}
+				}
+				backslashes /= 2
+			}
+			for ; backslashes > 0; backslashes-- {
+				arg = append(arg, '\\')
+			}
+
+			if i == len(s) || (inQuotes == false && (s[i] == ' ' || s[i] == '\t')) {
+				break
+			}
+			if copyChar {
+				arg = append(arg, s[i])
+			}
+			i++
+		}
+		argv = append(argv, string(arg))
+	}
+}
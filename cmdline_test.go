@@ -0,0 +1,225 @@
+package gowindows
+
+import (
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// The command lines and the arguments of windows
+var commandLineCorpus = []struct {
+	cmdLine string
+	argv    []string
+	crt     []string
+}{
+	{`test.exe "abc" d e`, []string{`test.exe`, `abc`, `d`, `e`}, nil},
+	{`test.exe a\\\b d"e f"g h`, []string{`test.exe`, `a\\\b`, `de fg`, `h`}, nil},
+	{`test.exe a\\\"b c d`, []string{`test.exe`, `a\"b`, `c`, `d`}, nil},
+	{`test.exe a\\\\"b c" d e`, []string{`test.exe`, `a\\b c`, `d`, `e`}, nil},
+	{`test.exe a"b"" c d`, []string{`test.exe`, `ab"`, `c`, `d`}, []string{`test.exe`, `ab" c d`}},
+	{`test.exe "a""b c"`, []string{`test.exe`, `a"b`, `c`}, []string{`test.exe`, `a"b c`}},
+	{`test.exe a""b """" "`, []string{`test.exe`, `ab`, `" `}, []string{`test.exe`, `ab`, `"`, ``}},
+	{`test.exe "" a  ` + "\t", []string{`test.exe`, ``, `a`}, nil},
+	{`test.exe \\server\share\ "c:\dir\\" x`, []string{`test.exe`, `\\server\share\`, `c:\dir\`, `x`}, nil},
+	{`"C:\Program Files\a.exe" b`, []string{`C:\Program Files\a.exe`, `b`}, nil},
+	{`"C:\a"b c`, []string{`C:\a`, `b`, `c`}, []string{`C:\ab`, `c`}},
+	{`C:\a"b c"d e`, []string{`C:\a"b`, `cd e`}, []string{`C:\ab cd`, `e`}},
+	{`"C:\a b\\" c`, []string{`C:\a b\\`, `c`}, nil},
+	{`"unterminated a b`, []string{`unterminated a b`}, nil},
+	{` a b`, []string{``, `a`, `b`}, nil},
+	{`a`, []string{`a`}, nil},
+	{``, nil, nil},
+}
+
+func TestSplitCommandLine(t *testing.T) {
+	for _, v := range commandLineCorpus {
+		if argv := SplitCommandLine(v.cmdLine); reflect.DeepEqual(argv, v.argv) == false {
+			t.Errorf("%v: %q!=%q", v.cmdLine, argv, v.argv)
+		}
+
+		crt := v.crt
+		if crt == nil {
+			crt = v.argv
+		}
+		if argv := SplitCommandLineCRT(v.cmdLine); reflect.DeepEqual(argv, crt) == false {
+			t.Errorf("CRT %v: %q!=%q", v.cmdLine, argv, crt)
+		}
+	}
+}
+
+// Random arguments of the special characters
+func randomArg(r *rand.Rand, chars string) string {
+	b := make([]byte, r.Intn(8))
+	for i := range b {
+		b[i] = chars[r.Intn(len(chars))]
+	}
+	return string(b)
+}
+
+// The command line built by makeCmdLine is split to the same arguments
+func TestSplitCommandLine_escapeArg(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 20000; i++ {
+		// The backslashes and the quotes of the program name are not escaped
+		args := []string{randomArg(r, `ab \:.`) + "x"}
+		for n := r.Intn(5); n > 0; n-- {
+			args = append(args, randomArg(r, "ab \t\\\"'"))
+		}
+
+		cmdLine := makeCmdLine(args)
+		if argv := SplitCommandLine(cmdLine); reflect.DeepEqual(argv, args) == false {
+			t.Fatalf("%v: %q!=%q", cmdLine, argv, args)
+		}
+		if argv := SplitCommandLineCRT(cmdLine); reflect.DeepEqual(argv, args) == false {
+			t.Fatalf("CRT %v: %q!=%q", cmdLine, argv, args)
+		}
+	}
+}
+
+// The reference parser of cmd.exe /c, it returns the command line passed to the program.
+// The undefined variables are kept by %var%, and removed by !var!.
+// ok is false if the line contains the unescaped special characters.
+func parseCmdLine(line string, delayed bool, env map[string]string) (string, bool) {
+	// Phase 1, the percent expansion, the undefined variable is kept and the scan continues at the second %
+	var b []byte
+	for i := 0; i < len(line); i++ {
+		if line[i] == '%' {
+			if j := strings.IndexByte(line[i+1:], '%'); j >= 0 {
+				if v, ok := env[line[i+1:i+1+j]]; ok {
+					b = append(b, v...)
+					i += j + 1
+					continue
+				}
+			}
+		}
+		b = append(b, line[i])
+	}
+
+	// Phase 2, the carets and the quotes
+	s := string(b)
+	b = b[:0]
+	inQuotes := false
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '"':
+			inQuotes = inQuotes == false
+		case inQuotes:
+		case c == '^':
+			i++
+			if i == len(s) {
+				continue
+			}
+			c = s[i]
+		case strings.IndexByte("&|<>()", c) >= 0:
+			return "", false
+		}
+		b = append(b, c)
+	}
+
+	// Phase 5, the delayed expansion, the carets are removed again
+	s = string(b)
+	if delayed == false || strings.Contains(s, "!") == false {
+		return s, true
+	}
+	b = b[:0]
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '^':
+			if i++; i < len(s) {
+				b = append(b, s[i])
+			}
+		case '!':
+			if j := strings.IndexByte(s[i+1:], '!'); j >= 0 {
+				b = append(b, env[s[i+1:i+1+j]]...)
+				i += j + 1
+			}
+		default:
+			b = append(b, s[i])
+		}
+	}
+	return string(b), true
+}
+
+// The reference parser of the single quoted string of PowerShell
+func parsePowerShellString(s string) (string, bool) {
+	r := []rune(s)
+	if len(r) < 2 || isPowerShellQuote(r[0]) == false || isPowerShellQuote(r[len(r)-1]) == false {
+		return "", false
+	}
+	var out []rune
+	for i := 1; i < len(r)-1; i++ {
+		if isPowerShellQuote(r[i]) {
+			// Any single quote is the end, unless it is followed by another single quote
+			if i+1 == len(r)-1 || isPowerShellQuote(r[i+1]) == false {
+				return "", false
+			}
+			i++
+		}
+		out = append(out, r[i])
+	}
+	return string(out), true
+}
+
+func TestEscapeArgFor(t *testing.T) {
+	data := []struct {
+		shell Shell
+		arg   string
+		s     string
+	}{
+		{ShellCRT, `a b`, `"a b"`},
+		{ShellCmd, `a&b`, `a^&b`},
+		{ShellCmd, `"a b"`, `^"\^"a b\^"^"`},
+		{ShellCmd, `%PATH%`, `^%PATH^%`},
+		{ShellCmd, `a!b^`, `a!b^^`},
+		{ShellCmdDelayedExpansion, `a^b`, `a^^b`},
+		{ShellCmdDelayedExpansion, `a!b^`, `a^^!b^^^^`},
+		{ShellPowerShell, ``, `''`},
+		{ShellPowerShell, `it's $a`, `'it''s $a'`},
+		{ShellPowerShell, "a’b", "'a’’b'"},
+	}
+	for _, v := range data {
+		if s := EscapeArgFor(v.shell, v.arg); s != v.s {
+			t.Errorf("%v %v: %v!=%v", v.shell, v.arg, s, v.s)
+		}
+	}
+}
+
+// The escaped arguments are parsed back by the reference parsers
+func TestEscapeArgFor_parse(t *testing.T) {
+	// The variable names ending with the caret are not defined, see EscapeArgFor
+	env := map[string]string{"PATH": "p", "A": "a", "^A": "y"}
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 20000; i++ {
+		args := []string{"test.exe"}
+		for n := r.Intn(4); n > 0; n-- {
+			args = append(args, randomArg(r, "A \t\\\"^%!&|<>()"))
+		}
+
+		for _, shell := range []Shell{ShellCmd, ShellCmdDelayedExpansion} {
+			line := MakeCmdLineFor(shell, args)
+			s, ok := parseCmdLine(line, shell == ShellCmdDelayedExpansion, env)
+			if ok == false {
+				t.Fatalf("%v %v: unescaped", shell, line)
+			}
+			if argv := SplitCommandLineCRT(s); reflect.DeepEqual(argv, args) == false {
+				t.Fatalf("%v %v: %q!=%q", shell, line, argv, args)
+			}
+
+			for _, arg := range args[1:] {
+				line := "test.exe " + EscapeArgFor(shell, arg)
+				s, ok := parseCmdLine(line, shell == ShellCmdDelayedExpansion, env)
+				if argv := SplitCommandLineCRT(s); ok == false || reflect.DeepEqual(argv, []string{"test.exe", arg}) == false {
+					t.Fatalf("%v %v: %q!=%q", shell, line, argv, arg)
+				}
+			}
+		}
+
+		arg := randomArg(r, "a '\"$`") + string([]rune{'‘', '’', '‚', '‛'}[r.Intn(4)])
+		s, ok := parsePowerShellString(EscapeArgFor(ShellPowerShell, arg))
+		if ok == false || s != arg {
+			t.Fatalf("%q: %q!=%q", EscapeArgFor(ShellPowerShell, arg), s, arg)
+		}
+	}
+}
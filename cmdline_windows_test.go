@@ -0,0 +1,44 @@
+package gowindows
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+func commandLineToArgv(t *testing.T, cmdLine string) []string {
+	var argc int32
+	argv, err := windows.CommandLineToArgv(windows.StringToUTF16Ptr(cmdLine), &argc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer windows.LocalFree(windows.Handle(uintptr(unsafe.Pointer(argv))))
+
+	args := make([]string, argc)
+	for i := range args {
+		args[i] = windows.UTF16ToString(argv[i][:])
+	}
+	return args
+}
+
+func TestSplitCommandLine_windows(t *testing.T) {
+	for _, v := range commandLineCorpus {
+		if v.cmdLine == "" {
+			continue
+		}
+		if argv := commandLineToArgv(t, v.cmdLine); reflect.DeepEqual(argv, v.argv) == false {
+			t.Errorf("%v: %q!=%q", v.cmdLine, argv, v.argv)
+		}
+	}
+
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 5000; i++ {
+		cmdLine := randomArg(r, "a\"") + " " + randomArg(r, "a \t\\\"") + randomArg(r, "a \t\\\"")
+		if argv, want := SplitCommandLine(cmdLine), commandLineToArgv(t, cmdLine); reflect.DeepEqual(argv, want) == false {
+			t.Fatalf("%v: %q!=%q", cmdLine, argv, want)
+		}
+	}
+}
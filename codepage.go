@@ -0,0 +1,370 @@
+package gowindows
+
+import (
+	"syscall"
+	"unicode"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+type CodePage uint32
+
+const (
+	//  Code Page Default Values.
+	//
+	CP_ACP        CodePage = 0  // default to ANSI code page
+	CP_OEMCP      CodePage = 1  // default to OEM  code page
+	CP_MACCP      CodePage = 2  // default to MAC  code page
+	CP_THREAD_ACP CodePage = 3  // current thread's ANSI code page
+	CP_SYMBOL     CodePage = 42 // SYMBOL translations
+
+	CP_UTF7 CodePage = 65000 // UTF-7 translation
+	CP_UTF8 CodePage = 65001 // UTF-8 translation
+
+	// The code pages supported by CodePageConverter
+	CP_IBM437       CodePage = 437   // OEM United States
+	CP_IBM866       CodePage = 866   // OEM Russian; Cyrillic
+	CP_SHIFT_JIS    CodePage = 932   // ANSI/OEM Japanese; Japanese (Shift-JIS)
+	CP_GBK          CodePage = 936   // ANSI/OEM Simplified Chinese (PRC, Singapore); Chinese Simplified (GB2312)
+	CP_BIG5         CodePage = 950   // ANSI/OEM Traditional Chinese (Taiwan; Hong Kong SAR, PRC); Chinese Traditional (Big5)
+	CP_WINDOWS_1252 CodePage = 1252  // ANSI Latin 1; Western European (Windows)
+	CP_MACINTOSH    CodePage = 10000 // MAC Roman; Western European (Mac)
+)
+
+type WideChar2MultiByteFlags DWord
+
+const (
+	WC_COMPOSITECHECK WideChar2MultiByteFlags = 0x00000200 // convert composite to precomposed
+	WC_DISCARDNS      WideChar2MultiByteFlags = 0x00000010 // discard non-spacing chars
+	WC_SEPCHARS       WideChar2MultiByteFlags = 0x00000020 // generate separate chars
+	WC_DEFAULTCHAR    WideChar2MultiByteFlags = 0x00000040 // replace w/ default char
+	//#if (WINVER >= 0x0600)
+	// 	Windows Vista and later: If an invalid input character is encountered, it fails (by returning 0 and setting the last error code to ERROR_NO_UNICODE_TRANSLATION). You can retrieve the last error code by calling GetLastError. If this flag is not set, the function replaces the illegal sequence (coded according to the specified code page) with U + FFFD, and succeeds by returning the length of the converted string. Please note that this flag only applies when the code page is specified as CP_UTF8 or 54936. It cannot be used with other code page values.
+	WC_ERR_INVALID_CHARS WideChar2MultiByteFlags = 0x00000080 // error for invalid chars
+	//#endif
+
+	//#if(WINVER >= 0x0500)
+	// Convert Unicode characters that cannot be directly converted into corresponding multibyte characters into the default characters specified by lpDefaultChar. In other words, if you convert Unicode to multibyte characters and then convert back, you may not necessarily get the same Unicode character, because the default character may be used during this period. This option can be used alone or with other options.
+	// For strings that require authentication, such as files, resources, and user names, applications should always use the WC_NO_BEST_FIT_CHARS flag. This flag prevents the function from mapping characters to characters that look similar but have very different semantics. In some cases, semantic changes may be extreme. For example, the "∞" (infinity) symbol is mapped to 8 (8) in some code pages.
+	WC_NO_BEST_FIT_CHARS WideChar2MultiByteFlags = 0x00000400 // do not use best fit chars
+	//#endif /* WINVER >= 0x0500 */
+)
+
+type MultiByteToWideCharFlags DWord
+
+const (
+	//
+	//  MBCS and Unicode Translation Flags.
+	//
+
+	// Default; do not use with MB_COMPOSITE. Always use pre-combined characters, that is, characters with a single character value as basic characters or non-spacing character combinations. For example, in the character è, e is a basic character, and accented characters are non-sliding characters. If a single Unicode code point is defined for a character, the application should use it instead of separate basic and non-spacing characters. For example, Ä is represented by a single Unicode code point LATIN CAPITAL LETTER A WITH DIAERESIS (U + 00C4).
+	MB_PRECOMPOSED MultiByteToWideCharFlags = 0x00000001 // use precomposed chars
+
+	// Always use decomposed characters, that is, characters that have different code point values for the base character and one or more non-spacing characters. For example, Ä is represented by A +¨: LATIN CAPITAL LETTER A (U + 0041) + COMBINING DIAERESIS (U + 0308). Please note that this flag cannot be used with MB_PRECOMPOSED.
+	MB_COMPOSITE MultiByteToWideCharFlags = 0x00000002 // use composite chars
+
+	// Use glyph characters instead of control characters.
+	MB_USEGLYPHCHARS MultiByteToWideCharFlags = 0x00000004 // use glyph chars, not ctrl chars
+
+	// If an invalid input character is encountered, it fails.
+	//Starting from Windows Vista, if the application does not set this flag, the function will not delete the illegal code point, but use U + FFFD to replace the illegal sequence (according to the specified code page encoding).
+	//
+	//Windows 2000 with SP4 and higher, Windows XP: If this flag is not set, the function will silently delete illegal code points. The call to GetLastError returns ERROR_NO_UNICODE_TRANSLATION.
+	MB_ERR_INVALID_CHARS MultiByteToWideCharFlags = 0x00000008 // error for invalid chars
+)
+
+// The errors of WideChar2MultiByte and MultiByte2WideChar, errors.Is matches them on windows and linux.
+const (
+	ERROR_INVALID_PARAMETER      syscall.Errno = 87
+	ERROR_INVALID_FLAGS          syscall.Errno = 1004
+	ERROR_NO_UNICODE_TRANSLATION syscall.Errno = 1113
+)
+
+// Implemented by WideChar2MultiByte, that is the windows api on windows and CodePageConverter on linux.
+// The main purpose is actually to convert go string to windows local encoding
+func Str2MultiByte(codePage CodePage, dwFlags WideChar2MultiByteFlags, str string, lpDefaultChar *byte, pfUsedDefaultChar *bool) ([]byte, error) {
+	utf16Data := utf16.Encode([]rune(str))
+
+	return WideChar2MultiByte(codePage, dwFlags, utf16Data, lpDefaultChar, pfUsedDefaultChar)
+}
+
+// Implemented by MultiByte2WideChar, that is the windows api on windows and CodePageConverter on linux.
+// The main purpose is actually to convert Windows local encoding to go string
+func MultiByte2Str(codePage CodePage, dwFlags MultiByteToWideCharFlags, str []byte) (string, error) {
+	utf16Data, err := MultiByte2WideChar(codePage, dwFlags, str)
+	if err != nil {
+		return "", err
+	}
+
+	return string(utf16.Decode(utf16Data)), nil
+}
+
+// Convert between UTF-16 and the code page in go
+// The flags, the default char and the used default char are the same as WideCharToMultiByte and MultiByteToWideChar,
+// so it works on linux. The best fit characters are the same as windows with codepage_bestfit_table.go generated by
+// mkbestfit.go from the best fit tables of windows. Without it only a subset is built in, the other characters that
+// windows maps to a similar character are converted to the default char, use WC_NO_BEST_FIT_CHARS for the same result.
+// The supported code pages are CP_UTF8, CP_UTF7, CP_SYMBOL, CP_IBM437, CP_IBM866, CP_SHIFT_JIS, CP_GBK, CP_BIG5,
+// CP_WINDOWS_1252 and CP_MACINTOSH, CP_ACP, CP_THREAD_ACP, CP_OEMCP and CP_MACCP are resolved to the code page of the system.
+type CodePageConverter struct {
+	codePage CodePage
+	// nil for CP_UTF8 and CP_UTF7
+	table *codePageTable
+}
+
+// ERROR_INVALID_PARAMETER is returned if the code page is not supported.
+func NewCodePageConverter(codePage CodePage) (*CodePageConverter, error) {
+	codePage = resolveCodePage(codePage)
+	if codePage == CP_UTF8 || codePage == CP_UTF7 {
+		return &CodePageConverter{codePage: codePage}, nil
+	}
+
+	table, err := getCodePageTable(codePage)
+	if err != nil {
+		return nil, err
+	}
+	return &CodePageConverter{codePage: codePage, table: table}, nil
+}
+
+// The resolved code page
+func (c *CodePageConverter) CodePage() CodePage {
+	return c.codePage
+}
+
+// Whether the byte is the lead byte of a double byte character, the same as IsDBCSLeadByteEx.
+func (c *CodePageConverter) IsLeadByte(b byte) bool {
+	return c.table != nil && c.table.lead[b]
+}
+
+// Check the flags and the parameters of WideChar2MultiByte
+// For CP_UTF8 dwFlags must be 0 or WC_ERR_INVALID_CHARS, for CP_UTF7 and CP_SYMBOL dwFlags must be 0,
+// and the default char can not be used for CP_UTF8 and CP_UTF7.
+func (c *CodePageConverter) checkEncode(dwFlags WideChar2MultiByteFlags, useDefaultChar bool) error {
+	if c.table == nil && useDefaultChar {
+		return ERROR_INVALID_PARAMETER
+	}
+
+	allowed := WC_COMPOSITECHECK | WC_DISCARDNS | WC_SEPCHARS | WC_DEFAULTCHAR | WC_NO_BEST_FIT_CHARS
+	switch {
+	case c.codePage == CP_UTF8:
+		allowed = WC_ERR_INVALID_CHARS
+	case c.table == nil || c.table.noFlags:
+		allowed = 0
+	}
+	if dwFlags&^allowed != 0 {
+		return ERROR_INVALID_FLAGS
+	}
+	return nil
+}
+
+// Check the flags of MultiByte2WideChar
+// For CP_UTF8 dwFlags must be 0 or MB_ERR_INVALID_CHARS, for CP_UTF7 and CP_SYMBOL dwFlags must be 0.
+func (c *CodePageConverter) checkDecode(dwFlags MultiByteToWideCharFlags) error {
+	allowed := MB_PRECOMPOSED | MB_COMPOSITE | MB_USEGLYPHCHARS | MB_ERR_INVALID_CHARS
+	switch {
+	case c.codePage == CP_UTF8:
+		allowed = MB_ERR_INVALID_CHARS
+	case c.table == nil || c.table.noFlags:
+		allowed = 0
+	}
+	if dwFlags&^allowed != 0 || dwFlags&(MB_PRECOMPOSED|MB_COMPOSITE) == MB_PRECOMPOSED|MB_COMPOSITE {
+		return ERROR_INVALID_FLAGS
+	}
+	return nil
+}
+
+// wchar(UTF-16LE) converts to char, the same as WideCharToMultiByte except the best fit characters (see CodePageConverter)
+// lpDefaultChar is the char of the characters that can not be represented, the default is '?'.
+// pfUsedDefaultChar reports whether lpDefaultChar is used.
+func (c *CodePageConverter) WideChar2MultiByte(dwFlags WideChar2MultiByteFlags, wchar []uint16, lpDefaultChar *byte, pfUsedDefaultChar *bool) ([]byte, error) {
+	if err := c.checkEncode(dwFlags, lpDefaultChar != nil || pfUsedDefaultChar != nil); err != nil {
+		return nil, err
+	}
+	if len(wchar) == 0 {
+		return nil, nil
+	}
+
+	switch c.codePage {
+	case CP_UTF8:
+		return encodeUTF8(wchar, dwFlags&WC_ERR_INVALID_CHARS != 0)
+	case CP_UTF7:
+		e := utf7Encoder{}
+		out := make([]byte, 0, len(wchar)*3)
+		for _, u := range wchar {
+			out = e.encode(out, u)
+		}
+		return e.flush(out), nil
+	}
+
+	t := c.table
+	defaultChar := t.defaultChar
+	if lpDefaultChar != nil {
+		defaultChar = *lpDefaultChar
+	}
+	if dwFlags&WC_COMPOSITECHECK != 0 {
+		wchar = utf16.Encode([]rune(norm.NFC.String(string(utf16.Decode(wchar)))))
+	}
+
+	usedDefaultChar := false
+	out := make([]byte, 0, len(wchar))
+	for _, u := range wchar {
+		// The nonspacing characters that are not composed
+		if dwFlags&WC_COMPOSITECHECK != 0 && unicode.Is(unicode.Mn, rune(u)) {
+			if dwFlags&WC_DISCARDNS != 0 {
+				continue
+			}
+			if dwFlags&WC_DEFAULTCHAR != 0 {
+				out = append(out, defaultChar)
+				usedDefaultChar = true
+				continue
+			}
+		}
+
+		var ok bool
+		// The surrogate pair is two characters too
+		if out, ok = t.encodeChar(out, u, dwFlags, defaultChar); ok == false {
+			usedDefaultChar = true
+		}
+	}
+
+	if pfUsedDefaultChar != nil {
+		*pfUsedDefaultChar = usedDefaultChar
+	}
+	return out, nil
+}
+
+// convert char to wchar(UTF-16LE), the same as MultiByteToWideChar
+// The invalid sequence is converted to the default unicode char of the code page (U+FFFD for CP_UTF8),
+// or ERROR_NO_UNICODE_TRANSLATION is returned with MB_ERR_INVALID_CHARS.
+func (c *CodePageConverter) MultiByte2WideChar(dwFlags MultiByteToWideCharFlags, str []byte) ([]uint16, error) {
+	if err := c.checkDecode(dwFlags); err != nil {
+		return nil, err
+	}
+	if len(str) == 0 {
+		return nil, nil
+	}
+
+	switch c.codePage {
+	case CP_UTF8:
+		return decodeUTF8(str, dwFlags&MB_ERR_INVALID_CHARS != 0)
+	case CP_UTF7:
+		d := utf7Decoder{}
+		out := make([]uint16, 0, len(str))
+		for _, b := range str {
+			out = d.decode(out, b)
+		}
+		return out, nil
+	}
+
+	out := make([]uint16, 0, len(str))
+	for i := 0; i < len(str); {
+		u, size, ok := c.table.decodeChar(str[i:], dwFlags, true)
+		if ok == false && dwFlags&MB_ERR_INVALID_CHARS != 0 {
+			return nil, ERROR_NO_UNICODE_TRANSLATION
+		}
+		out = append(out, u)
+		i += size
+	}
+
+	if dwFlags&MB_COMPOSITE != 0 {
+		out = utf16.Encode([]rune(norm.NFD.String(string(utf16.Decode(out)))))
+	}
+	return out, nil
+}
+
+// The same as Str2MultiByte
+func (c *CodePageConverter) Str2MultiByte(dwFlags WideChar2MultiByteFlags, str string, lpDefaultChar *byte, pfUsedDefaultChar *bool) ([]byte, error) {
+	return c.WideChar2MultiByte(dwFlags, utf16.Encode([]rune(str)), lpDefaultChar, pfUsedDefaultChar)
+}
+
+// The same as MultiByte2Str
+func (c *CodePageConverter) MultiByte2Str(dwFlags MultiByteToWideCharFlags, str []byte) (string, error) {
+	utf16Data, err := c.MultiByte2WideChar(dwFlags, str)
+	if err != nil {
+		return "", err
+	}
+	return string(utf16.Decode(utf16Data)), nil
+}
+
+// The unpaired surrogate is converted to U+FFFD
+func encodeUTF8(wchar []uint16, errInvalidChars bool) ([]byte, error) {
+	if len(wchar) == 0 {
+		return nil, nil
+	}
+
+	out := make([]byte, 0, len(wchar)*3)
+	for i := 0; i < len(wchar); i++ {
+		r := rune(wchar[i])
+		switch {
+		case utf16.IsSurrogate(r) == false:
+		case r < 0xDC00 && i+1 < len(wchar) && wchar[i+1] >= 0xDC00 && wchar[i+1] <= 0xDFFF:
+			r = utf16.DecodeRune(r, rune(wchar[i+1]))
+			i++
+		default:
+			if errInvalidChars {
+				return nil, ERROR_NO_UNICODE_TRANSLATION
+			}
+			r = utf8.RuneError
+		}
+		out = append(out, string(r)...)
+	}
+	return out, nil
+}
+
+// Each maximal subpart of the invalid sequence is converted to U+FFFD, as recommended by the unicode standard.
+func decodeUTF8(str []byte, errInvalidChars bool) ([]uint16, error) {
+	if len(str) == 0 {
+		return nil, nil
+	}
+
+	out := make([]uint16, 0, len(str))
+	for i := 0; i < len(str); {
+		r, size := utf8.DecodeRune(str[i:])
+		if r == utf8.RuneError && size <= 1 {
+			if errInvalidChars {
+				return nil, ERROR_NO_UNICODE_TRANSLATION
+			}
+			size = utf8InvalidLength(str[i:])
+		}
+		if r >= 0x10000 {
+			r1, r2 := utf16.EncodeRune(r)
+			out = append(out, uint16(r1), uint16(r2))
+		} else {
+			out = append(out, uint16(r))
+		}
+		i += size
+	}
+	return out, nil
+}
+
+// The length of the maximal subpart of the invalid sequence
+func utf8InvalidLength(p []byte) int {
+	need, lo, hi := 0, byte(0x80), byte(0xBF)
+	switch b := p[0]; {
+	case b >= 0xC2 && b <= 0xDF:
+		need = 1
+	case b == 0xE0:
+		need, lo = 2, 0xA0
+	case b == 0xED:
+		need, hi = 2, 0x9F
+	case b >= 0xE1 && b <= 0xEF:
+		need = 2
+	case b == 0xF0:
+		need, lo = 3, 0x90
+	case b == 0xF4:
+		need, hi = 3, 0x8F
+	case b >= 0xF1 && b <= 0xF3:
+		need = 3
+	}
+
+	n := 1
+	for ; n <= need && n < len(p); n++ {
+		if p[n] < lo || p[n] > hi {
+			break
+		}
+		lo, hi = 0x80, 0xBF
+	}
+	return n
+}
@@ -0,0 +1,40 @@
+package gowindows
+
+// The code pages of CP_ACP (CP_THREAD_ACP), CP_OEMCP and CP_MACCP on linux
+var (
+	ANSICodePage = CP_WINDOWS_1252
+	OEMCodePage  = CP_IBM437
+	MacCodePage  = CP_MACINTOSH
+)
+
+func resolveCodePage(codePage CodePage) CodePage {
+	switch codePage {
+	case CP_ACP, CP_THREAD_ACP:
+		return ANSICodePage
+	case CP_OEMCP:
+		return OEMCodePage
+	case CP_MACCP:
+		return MacCodePage
+	}
+	return codePage
+}
+
+// wchar(UTF-16LE) converts to char by CodePageConverter
+// If the input does not contain \0, the output will not.
+func WideChar2MultiByte(codePage CodePage, dwFlags WideChar2MultiByteFlags, wchar []uint16, lpDefaultChar *byte, pfUsedDefaultChar *bool) ([]byte, error) {
+	c, err := NewCodePageConverter(codePage)
+	if err != nil {
+		return nil, err
+	}
+	return c.WideChar2MultiByte(dwFlags, wchar, lpDefaultChar, pfUsedDefaultChar)
+}
+
+// convert char to wchar(UTF-16LE) by CodePageConverter
+// If the input does not contain \0, the output will not.
+func MultiByte2WideChar(codePage CodePage, dwFlags MultiByteToWideCharFlags, str []byte) ([]uint16, error) {
+	c, err := NewCodePageConverter(codePage)
+	if err != nil {
+		return nil, err
+	}
+	return c.MultiByte2WideChar(dwFlags, str)
+}
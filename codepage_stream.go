@@ -0,0 +1,303 @@
+package gowindows
+
+import (
+	"io"
+	"os/exec"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"golang.org/x/text/transform"
+)
+
+// Streaming decoder from the code page to UTF-8, it is a transform.Transformer.
+// The double byte characters and the UTF-8 sequences split across the calls are decoded as a whole,
+// the result is the same as MultiByte2Str of the whole input.
+type CodePageDecoder struct {
+	c     *CodePageConverter
+	flags MultiByteToWideCharFlags
+	utf7  utf7Decoder
+	// The high surrogate decoded from UTF-7
+	high uint16
+}
+
+// MB_COMPOSITE is not supported.
+func (c *CodePageConverter) NewDecoder(dwFlags MultiByteToWideCharFlags) (*CodePageDecoder, error) {
+	if err := c.checkDecode(dwFlags); err != nil {
+		return nil, err
+	}
+	if dwFlags&MB_COMPOSITE != 0 {
+		return nil, ERROR_INVALID_FLAGS
+	}
+	return &CodePageDecoder{c: c, flags: dwFlags}, nil
+}
+
+func (d *CodePageDecoder) Reset() {
+	d.utf7 = utf7Decoder{}
+	d.high = 0
+}
+
+// ERROR_NO_UNICODE_TRANSLATION is returned for the invalid sequence with MB_ERR_INVALID_CHARS.
+func (d *CodePageDecoder) Transform(dst, src []byte, atEOF bool) (nDst, nSrc int, err error) {
+	var buf [2]rune
+	for nSrc < len(src) {
+		runes := buf[:0]
+		size := 1
+		utf7, high := d.utf7, d.high
+
+		switch d.c.codePage {
+		case CP_UTF8:
+			if utf8.FullRune(src[nSrc:]) == false && atEOF == false {
+				return nDst, nSrc, transform.ErrShortSrc
+			}
+			var r rune
+			r, size = utf8.DecodeRune(src[nSrc:])
+			if r == utf8.RuneError && size <= 1 {
+				if d.flags&MB_ERR_INVALID_CHARS != 0 {
+					return nDst, nSrc, ERROR_NO_UNICODE_TRANSLATION
+				}
+				size = utf8InvalidLength(src[nSrc:])
+			}
+			runes = append(runes, r)
+		case CP_UTF7:
+			var units [1]uint16
+			for _, u := range utf7.decode(units[:0], src[nSrc]) {
+				runes, high = appendUTF16(runes, high, u)
+			}
+		default:
+			u, n, ok := d.c.table.decodeChar(src[nSrc:], d.flags, atEOF)
+			if n == 0 {
+				return nDst, nSrc, transform.ErrShortSrc
+			}
+			if ok == false && d.flags&MB_ERR_INVALID_CHARS != 0 {
+				return nDst, nSrc, ERROR_NO_UNICODE_TRANSLATION
+			}
+			runes, size = append(runes, rune(u)), n
+		}
+
+		if nDst, err = appendRunes(dst, nDst, runes); err != nil {
+			return nDst, nSrc, err
+		}
+		nSrc += size
+		d.utf7, d.high = utf7, high
+	}
+
+	// The unpaired high surrogate at the end
+	if atEOF && d.high != 0 {
+		if nDst, err = appendRunes(dst, nDst, []rune{utf8.RuneError}); err != nil {
+			return nDst, nSrc, err
+		}
+		d.high = 0
+	}
+	return nDst, nSrc, nil
+}
+
+// Combine the UTF-16 code unit with the pending high surrogate, the unpaired surrogate is U+FFFD.
+func appendUTF16(runes []rune, high uint16, u uint16) ([]rune, uint16) {
+	if high != 0 {
+		if u >= 0xDC00 && u <= 0xDFFF {
+			return append(runes, utf16.DecodeRune(rune(high), rune(u))), 0
+		}
+		runes = append(runes, utf8.RuneError)
+	}
+	if u >= 0xD800 && u < 0xDC00 {
+		return runes, u
+	}
+	return append(runes, rune(u)), 0
+}
+
+// Encode all the runes to dst[nDst:] or nothing
+func appendRunes(dst []byte, nDst int, runes []rune) (int, error) {
+	n := 0
+	for _, r := range runes {
+		n += utf8.RuneLen(r)
+	}
+	if nDst+n > len(dst) {
+		return nDst, transform.ErrShortDst
+	}
+	for _, r := range runes {
+		nDst += utf8.EncodeRune(dst[nDst:], r)
+	}
+	return nDst, nil
+}
+
+// Streaming encoder from UTF-8 to the code page, it is a transform.Transformer.
+// The characters split across the calls are encoded as a whole, the invalid UTF-8 is the same as the unpaired surrogate,
+// so the result is the same as Str2MultiByte of the whole input.
+type CodePageEncoder struct {
+	c               *CodePageConverter
+	flags           WideChar2MultiByteFlags
+	defaultChar     byte
+	usedDefaultChar bool
+	utf7            utf7Encoder
+}
+
+// lpDefaultChar is the same as WideChar2MultiByte, WC_COMPOSITECHECK is not supported.
+func (c *CodePageConverter) NewEncoder(dwFlags WideChar2MultiByteFlags, lpDefaultChar *byte) (*CodePageEncoder, error) {
+	if err := c.checkEncode(dwFlags, lpDefaultChar != nil); err != nil {
+		return nil, err
+	}
+	if dwFlags&WC_COMPOSITECHECK != 0 {
+		return nil, ERROR_INVALID_FLAGS
+	}
+
+	e := &CodePageEncoder{c: c, flags: dwFlags}
+	if lpDefaultChar != nil {
+		e.defaultChar = *lpDefaultChar
+	} else if c.table != nil {
+		e.defaultChar = c.table.defaultChar
+	}
+	return e, nil
+}
+
+// Whether the default char is used since the encoder is created or reset
+func (e *CodePageEncoder) UsedDefaultChar() bool {
+	return e.usedDefaultChar
+}
+
+func (e *CodePageEncoder) Reset() {
+	e.utf7 = utf7Encoder{}
+	e.usedDefaultChar = false
+}
+
+// ERROR_NO_UNICODE_TRANSLATION is returned for the invalid UTF-8 with WC_ERR_INVALID_CHARS.
+func (e *CodePageEncoder) Transform(dst, src []byte, atEOF bool) (nDst, nSrc int, err error) {
+	var buf [16]byte
+	for nSrc < len(src) {
+		if utf8.FullRune(src[nSrc:]) == false && atEOF == false {
+			return nDst, nSrc, transform.ErrShortSrc
+		}
+		r, size := utf8.DecodeRune(src[nSrc:])
+		invalid := r == utf8.RuneError && size <= 1
+		if invalid {
+			size = utf8InvalidLength(src[nSrc:])
+		}
+
+		out := buf[:0]
+		utf7 := e.utf7
+		usedDefaultChar := false
+		switch {
+		case e.c.codePage == CP_UTF8:
+			if invalid && e.flags&WC_ERR_INVALID_CHARS != 0 {
+				return nDst, nSrc, ERROR_NO_UNICODE_TRANSLATION
+			}
+			out = append(out, string(r)...)
+		case e.c.codePage == CP_UTF7:
+			for _, u := range utf16.Encode([]rune{r}) {
+				out = utf7.encode(out, u)
+			}
+		case invalid:
+			out = append(out, e.defaultChar)
+			usedDefaultChar = true
+		default:
+			// The characters out of the BMP are two default chars
+			for _, u := range utf16.Encode([]rune{r}) {
+				var ok bool
+				if out, ok = e.c.table.encodeChar(out, u, e.flags, e.defaultChar); ok == false {
+					usedDefaultChar = true
+				}
+			}
+		}
+
+		if nDst+len(out) > len(dst) {
+			return nDst, nSrc, transform.ErrShortDst
+		}
+		nDst += copy(dst[nDst:], out)
+		nSrc += size
+		e.utf7 = utf7
+		e.usedDefaultChar = e.usedDefaultChar || usedDefaultChar
+	}
+
+	// End the base64 of UTF-7
+	if atEOF && e.utf7.base64 {
+		utf7 := e.utf7
+		out := utf7.flush(buf[:0])
+		if nDst+len(out) > len(dst) {
+			return nDst, nSrc, transform.ErrShortDst
+		}
+		nDst += copy(dst[nDst:], out)
+		e.utf7 = utf7
+	}
+	return nDst, nSrc, nil
+}
+
+// Read the code page from r as UTF-8
+func NewCodePageReader(r io.Reader, codePage CodePage) (io.Reader, error) {
+	c, err := NewCodePageConverter(codePage)
+	if err != nil {
+		return nil, err
+	}
+	d, err := c.NewDecoder(0)
+	if err != nil {
+		return nil, err
+	}
+	return transform.NewReader(r, d), nil
+}
+
+// Write UTF-8 to w in the code page
+// Close writes the pending bytes, it does not close w.
+func NewCodePageWriter(w io.Writer, codePage CodePage) (io.WriteCloser, error) {
+	c, err := NewCodePageConverter(codePage)
+	if err != nil {
+		return nil, err
+	}
+	e, err := c.NewEncoder(0, nil)
+	if err != nil {
+		return nil, err
+	}
+	return transform.NewWriter(w, e), nil
+}
+
+// Whether a == b, false instead of the panic if the dynamic type is not comparable, the same as os/exec.
+func interfaceEqual(a, b interface{}) bool {
+	defer func() {
+		recover()
+	}()
+	return a == b
+}
+
+// Decode the stdout and stderr of the command from the code page to UTF-8
+// The console programs of windows usually write CP_OEMCP, the ANSI programs write CP_ACP.
+// cmd.Stdout and cmd.Stderr are wrapped by the decoders, it must be called before cmd.Start.
+// The returned function writes the incomplete character at the end of the output, it should be called after cmd.Wait.
+func DecodeCommandOutput(cmd *exec.Cmd, codePage CodePage) (func() error, error) {
+	c, err := NewCodePageConverter(codePage)
+	if err != nil {
+		return nil, err
+	}
+
+	var closers []io.Closer
+	wrap := func(w io.Writer) (io.Writer, error) {
+		d, err := c.NewDecoder(0)
+		if err != nil {
+			return nil, err
+		}
+		tw := transform.NewWriter(w, d)
+		closers = append(closers, tw)
+		return tw, nil
+	}
+
+	// The same writer is wrapped once, so the output is still written by one pipe.
+	same := cmd.Stdout != nil && interfaceEqual(cmd.Stdout, cmd.Stderr)
+	if cmd.Stdout != nil {
+		if cmd.Stdout, err = wrap(cmd.Stdout); err != nil {
+			return nil, err
+		}
+	}
+	if same {
+		cmd.Stderr = cmd.Stdout
+	} else if cmd.Stderr != nil {
+		if cmd.Stderr, err = wrap(cmd.Stderr); err != nil {
+			return nil, err
+		}
+	}
+
+	return func() error {
+		var result error
+		for _, c := range closers {
+			if err := c.Close(); err != nil && result == nil {
+				result = err
+			}
+		}
+		return result
+	}, nil
+}
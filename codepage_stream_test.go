@@ -0,0 +1,214 @@
+package gowindows
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"reflect"
+	"testing"
+	"testing/iotest"
+	"time"
+	"unicode/utf16"
+
+	"golang.org/x/text/transform"
+)
+
+// The input is read one byte at a time
+func TestCodePageDecoder(t *testing.T) {
+	for i, v := range codePageDecodeCorpus {
+		if v.flags&MB_COMPOSITE != 0 || v.err == ERROR_INVALID_FLAGS {
+			continue
+		}
+		c, err := NewCodePageConverter(v.codePage)
+		if err != nil {
+			t.Fatal(err)
+		}
+		d, err := c.NewDecoder(v.flags)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		out, err := ioutil.ReadAll(transform.NewReader(iotest.OneByteReader(bytes.NewReader(v.str)), d))
+		if err != v.err {
+			t.Errorf("%v: %v!=%v", i, err, v.err)
+			continue
+		}
+		if err == nil && string(out) != v.out {
+			t.Errorf("%v: %+q!=%+q", i, out, v.out)
+		}
+	}
+}
+
+// The input is written one byte at a time
+func TestCodePageEncoder(t *testing.T) {
+	for i, v := range codePageEncodeCorpus {
+		// The unpaired surrogates can not be UTF-8
+		str := string(utf16.Decode(v.wchar))
+		if v.flags&WC_COMPOSITECHECK != 0 || v.err != nil || reflect.DeepEqual(utf16.Encode([]rune(str)), v.wchar) == false {
+			continue
+		}
+		c, err := NewCodePageConverter(v.codePage)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var defaultChar *byte
+		if v.defaultChar != 0 {
+			defaultChar = &v.defaultChar
+		}
+		e, err := c.NewEncoder(v.flags, defaultChar)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		buf := bytes.Buffer{}
+		w := transform.NewWriter(&buf, e)
+		for _, b := range []byte(str) {
+			if _, err := w.Write([]byte{b}); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+		if bytes.Equal(buf.Bytes(), v.out) == false {
+			t.Errorf("%v: % x!=% x", i, buf.Bytes(), v.out)
+		}
+		if e.UsedDefaultChar() != v.usedDefault {
+			t.Errorf("%v: used default %v!=%v", i, e.UsedDefaultChar(), v.usedDefault)
+		}
+	}
+}
+
+func TestCodePageReaderWriter(t *testing.T) {
+	data := "a+b 中文 日本語 😀 Привет -x- +-"
+	for _, cp := range []CodePage{CP_UTF8, CP_UTF7, CP_GBK, CP_SHIFT_JIS, CP_BIG5, CP_IBM866} {
+		c, err := NewCodePageConverter(cp)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want, err := c.Str2MultiByte(0, data, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		buf := bytes.Buffer{}
+		w, err := NewCodePageWriter(&buf, cp)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := 0; i < len(data); i += 3 {
+			end := i + 3
+			if end > len(data) {
+				end = len(data)
+			}
+			w.Write([]byte(data[i:end]))
+		}
+		w.Close()
+		if bytes.Equal(buf.Bytes(), want) == false {
+			t.Errorf("%v: % x!=% x", cp, buf.Bytes(), want)
+		}
+
+		wantStr, err := c.MultiByte2Str(0, want)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r, err := NewCodePageReader(iotest.OneByteReader(bytes.NewReader(want)), cp)
+		if err != nil {
+			t.Fatal(err)
+		}
+		out, err := ioutil.ReadAll(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(out) != wantStr {
+			t.Errorf("%v: %v!=%v", cp, string(out), wantStr)
+		}
+		if cp == CP_UTF8 || cp == CP_UTF7 {
+			if string(out) != data {
+				t.Errorf("%v: %v!=%v", cp, string(out), data)
+			}
+		}
+	}
+
+	// The lead byte at the end
+	r, _ := NewCodePageReader(bytes.NewReader([]byte{'a', 0x81}), CP_GBK)
+	if out, err := ioutil.ReadAll(r); err != nil || string(out) != "a?" {
+		t.Errorf("%+q %v", out, err)
+	}
+}
+
+// Write the GBK output, the double bytes are split across the writes.
+func TestDecodeCommandOutputHelperProcess(t *testing.T) {
+	if os.Getenv("GOWINDOWS_CODEPAGE_HELPER") == "" {
+		t.Skip("helper process")
+	}
+
+	c, _ := NewCodePageConverter(CP_GBK)
+	stdout, _ := c.Str2MultiByte(0, "中文输出", nil, nil)
+	stderr, _ := c.Str2MultiByte(0, "错误", nil, nil)
+	for i := range stdout {
+		os.Stdout.Write(stdout[i : i+1])
+		time.Sleep(time.Millisecond)
+	}
+	os.Stderr.Write(stderr)
+	os.Exit(0)
+}
+
+func TestDecodeCommandOutput(t *testing.T) {
+	if os.Getenv("GOWINDOWS_CODEPAGE_HELPER") != "" {
+		t.Skip("helper process")
+	}
+
+	stdout := bytes.Buffer{}
+	stderr := bytes.Buffer{}
+	cmd := exec.Command(os.Args[0], "-test.run=^TestDecodeCommandOutputHelperProcess$", "-test.count=1")
+	cmd.Env = append(os.Environ(), "GOWINDOWS_CODEPAGE_HELPER=1")
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	flush, err := DecodeCommandOutput(cmd, CP_GBK)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cmd.Run(); err != nil {
+		t.Fatal(err)
+	}
+	if err := flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	if stdout.String() != "中文输出" {
+		t.Errorf("%v!=中文输出", stdout.String())
+	}
+	if stderr.String() != "错误" {
+		t.Errorf("%v!=错误", stderr.String())
+	}
+}
+
+type testWriterFunc func(p []byte) (int, error)
+
+func (f testWriterFunc) Write(p []byte) (int, error) {
+	return f(p)
+}
+
+// The writers of a type that is not comparable
+func TestDecodeCommandOutput_notComparable(t *testing.T) {
+	out := bytes.Buffer{}
+	w := testWriterFunc(out.Write)
+	cmd := exec.Command(os.Args[0])
+	cmd.Stdout = w
+	cmd.Stderr = w
+	flush, err := DecodeCommandOutput(cmd, CP_GBK)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cmd.Stdout.Write([]byte{0xD6, 0xD0})
+	cmd.Stderr.Write([]byte{0xCE, 0xC4})
+	if err := flush(); err != nil {
+		t.Fatal(err)
+	}
+	if out.String() != "中文" {
+		t.Errorf("%v!=中文", out.String())
+	}
+}
@@ -0,0 +1,405 @@
+package gowindows
+
+import (
+	"sync"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/japanese"
+	"golang.org/x/text/encoding/simplifiedchinese"
+	"golang.org/x/text/encoding/traditionalchinese"
+	"golang.org/x/text/unicode/norm"
+)
+
+// The conversion table of the code page
+// The tables are built from the WHATWG tables of golang.org/x/text, with the differences of the windows tables
+// (c_*.nls) applied: the end user defined characters (EUDC) are mapped to the private use area,
+// the bytes undefined by WHATWG are mapped as windows does.
+// All the characters of the tables are in the BMP, so a UTF-16 code unit is a character.
+type codePageTable struct {
+	// CPINFO.DefaultChar
+	defaultChar byte
+	// CPINFOEX.UnicodeDefaultChar, the result of the invalid sequences
+	unicodeDefault uint16
+	lead           [256]bool
+	trail          [256]bool
+	// The bytes that are not lead bytes
+	single [256]uint16
+	double map[uint16]uint16
+	// The character to the byte, or the double byte if it is greater than 0xFF
+	encode map[uint16]uint16
+	// The one way mappings, they are not used with WC_NO_BEST_FIT_CHARS.
+	bestFit map[uint16]uint16
+	// MB_USEGLYPHCHARS is supported
+	glyph bool
+	// The flags must be 0
+	noFlags bool
+}
+
+var codePageTables = struct {
+	sync.Mutex
+	m map[CodePage]*codePageTable
+}{m: make(map[CodePage]*codePageTable)}
+
+// The table is built on the first use
+func getCodePageTable(codePage CodePage) (*codePageTable, error) {
+	codePageTables.Lock()
+	defer codePageTables.Unlock()
+
+	if t, ok := codePageTables.m[codePage]; ok {
+		return t, nil
+	}
+
+	var t *codePageTable
+	switch codePage {
+	case CP_WINDOWS_1252:
+		// 0x81, 0x8D, 0x8F, 0x90 and 0x9D are the C1 control characters
+		t = newSingleByteTable(charmap.Windows1252)
+	case CP_IBM437:
+		t = newSingleByteTable(charmap.CodePage437)
+		t.glyph = true
+	case CP_IBM866:
+		t = newSingleByteTable(charmap.CodePage866)
+		t.glyph = true
+	case CP_MACINTOSH:
+		t = newSingleByteTable(charmap.Macintosh)
+	case CP_SYMBOL:
+		t = newSymbolTable()
+	case CP_SHIFT_JIS:
+		t = newShiftJISTable()
+	case CP_GBK:
+		t = newGBKTable()
+	case CP_BIG5:
+		t = newBig5Table()
+	default:
+		return nil, ERROR_INVALID_PARAMETER
+	}
+
+	t.addBestFit(codePage)
+	codePageTables.m[codePage] = t
+	return t, nil
+}
+
+func newCodePageTable(unicodeDefault uint16) *codePageTable {
+	return &codePageTable{
+		defaultChar:    '?',
+		unicodeDefault: unicodeDefault,
+		double:         make(map[uint16]uint16),
+		encode:         make(map[uint16]uint16),
+		bestFit:        make(map[uint16]uint16),
+	}
+}
+
+func newSingleByteTable(m *charmap.Charmap) *codePageTable {
+	t := newCodePageTable('?')
+	for i := 0; i < 256; i++ {
+		r := m.DecodeByte(byte(i))
+		if r == utf8.RuneError {
+			r = rune(i)
+		}
+		t.setSingle(byte(i), uint16(r))
+	}
+	return t
+}
+
+// The single bytes 0x00-0x7F are ASCII, the others that are not lead bytes are set by the code page.
+func newDoubleByteTable(unicodeDefault uint16, leads, trails [][2]byte) *codePageTable {
+	t := newCodePageTable(unicodeDefault)
+	for _, r := range leads {
+		for b := int(r[0]); b <= int(r[1]); b++ {
+			t.lead[b] = true
+		}
+	}
+	for _, r := range trails {
+		for b := int(r[0]); b <= int(r[1]); b++ {
+			t.trail[b] = true
+		}
+	}
+	for i := 0; i < 0x80; i++ {
+		t.setSingle(byte(i), uint16(i))
+	}
+	return t
+}
+
+// The control characters are not changed, 0x20-0xFF are mapped to U+F020-U+F0FF.
+func newSymbolTable() *codePageTable {
+	t := newCodePageTable('?')
+	t.noFlags = true
+	for i := 0; i < 256; i++ {
+		if i < 0x20 {
+			t.setSingle(byte(i), uint16(i))
+		} else {
+			t.setSingle(byte(i), uint16(0xF000+i))
+		}
+	}
+	return t
+}
+
+// Decode the character at the start of p
+// size is 0 if p is a lead byte and atEOF is false, ok is false for the invalid sequence.
+// The lead byte without a valid trail byte is invalid, the next byte is decoded again.
+func (t *codePageTable) decodeChar(p []byte, flags MultiByteToWideCharFlags, atEOF bool) (u uint16, size int, ok bool) {
+	b := p[0]
+	if t.lead[b] == false {
+		u = t.single[b]
+		if flags&MB_USEGLYPHCHARS != 0 && t.glyph {
+			u = glyphChar(b, u)
+		}
+		return u, 1, true
+	}
+
+	switch {
+	case len(p) < 2 && atEOF == false:
+		return 0, 0, false
+	case len(p) < 2 || t.trail[p[1]] == false:
+		return t.unicodeDefault, 1, false
+	}
+	if u, ok = t.double[uint16(b)<<8|uint16(p[1])]; ok == false {
+		u = t.unicodeDefault
+	}
+	return u, 2, ok
+}
+
+// Append the encoded character to out, ok is false if defaultChar is used.
+func (t *codePageTable) encodeChar(out []byte, u uint16, flags WideChar2MultiByteFlags, defaultChar byte) ([]byte, bool) {
+	v, ok := t.encode[u]
+	if ok == false && flags&WC_NO_BEST_FIT_CHARS == 0 {
+		v, ok = t.bestFit[u]
+	}
+	switch {
+	case ok == false:
+		return append(out, defaultChar), false
+	case v > 0xFF:
+		return append(out, byte(v>>8), byte(v)), true
+	}
+	return append(out, byte(v)), true
+}
+
+func (t *codePageTable) setSingle(b byte, u uint16) {
+	t.single[b] = u
+	if _, ok := t.encode[u]; ok == false {
+		t.encode[u] = uint16(b)
+	}
+}
+
+// The first double byte of the character is used to encode it, unless preferLast.
+func (t *codePageTable) setDouble(d uint16, u uint16, preferLast bool) {
+	t.double[d] = u
+	if _, ok := t.encode[u]; ok == false || preferLast {
+		t.encode[u] = d
+	}
+}
+
+// Add the double bytes decoded by e, skip returns true for the double bytes that are not defined by windows.
+func (t *codePageTable) addDecoded(e encoding.Encoding, leads []byte, skip func(d uint16, u uint16) bool, preferLast func(u uint16) bool) {
+	decoder := e.NewDecoder()
+	for _, l := range leads {
+		for tr := 0; tr < 256; tr++ {
+			if t.trail[tr] == false {
+				continue
+			}
+			d := uint16(l)<<8 | uint16(tr)
+			out, err := decoder.Bytes([]byte{l, byte(tr)})
+			if err != nil {
+				continue
+			}
+			r, size := utf8.DecodeRune(out)
+			if r == utf8.RuneError || r > 0xFFFF || size != len(out) {
+				continue
+			}
+			if skip != nil && skip(d, uint16(r)) {
+				continue
+			}
+			t.setDouble(d, uint16(r), preferLast != nil && preferLast(uint16(r)))
+		}
+	}
+}
+
+// Map the double bytes in order to the private use area from start
+// The leads are from firstLead to lastLead, the trails of each lead are the valid ones from firstTrail to lastTrail.
+func (t *codePageTable) addPrivateUse(start uint16, firstLead, lastLead, firstTrail, lastTrail byte) {
+	u := start
+	for l := int(firstLead); l <= int(lastLead); l++ {
+		for tr := int(firstTrail); tr <= int(lastTrail); tr++ {
+			if t.trail[tr] {
+				t.setDouble(uint16(l)<<8|uint16(tr), u, false)
+				u++
+			}
+		}
+	}
+}
+
+func byteRange(first, last byte) []byte {
+	bytes := make([]byte, 0, int(last)-int(first)+1)
+	for b := int(first); b <= int(last); b++ {
+		bytes = append(bytes, byte(b))
+	}
+	return bytes
+}
+
+// Code page 932 (Windows-31J), the unicode default char is U+30FB KATAKANA MIDDLE DOT.
+func newShiftJISTable() *codePageTable {
+	t := newDoubleByteTable(0x30FB, [][2]byte{{0x81, 0x9F}, {0xE0, 0xFC}}, [][2]byte{{0x40, 0x7E}, {0x80, 0xFC}})
+	t.setSingle(0x80, 0x80)
+	for b := 0xA1; b <= 0xDF; b++ {
+		t.setSingle(byte(b), uint16(0xFF61+b-0xA1))
+	}
+	t.setSingle(0xA0, 0xF8F0)
+	t.setSingle(0xFD, 0xF8F1)
+	t.setSingle(0xFE, 0xF8F2)
+	t.setSingle(0xFF, 0xF8F3)
+
+	// The NEC selected IBM extensions (0xED, 0xEE) are only decoded, the IBM extensions (0xFA-0xFC) are encoded.
+	leads := append(byteRange(0x81, 0x9F), byteRange(0xE0, 0xEC)...)
+	leads = append(leads, byteRange(0xEF, 0xFC)...)
+	leads = append(leads, 0xED, 0xEE)
+	t.addDecoded(japanese.ShiftJIS, leads, func(d uint16, u uint16) bool {
+		return d >= 0xF040 && d <= 0xF9FC
+	}, nil)
+	t.addPrivateUse(0xE000, 0xF0, 0xF9, 0x40, 0xFC)
+	return t
+}
+
+// Code page 936, GBK with the euro sign 0x80
+func newGBKTable() *codePageTable {
+	t := newDoubleByteTable('?', [][2]byte{{0x81, 0xFE}}, [][2]byte{{0x40, 0x7E}, {0x80, 0xFE}})
+	t.setSingle(0x80, 0x20AC)
+	t.setSingle(0xFF, 0xF8F5)
+
+	// 0xA2E3 is the euro sign of GB18030, it is not defined by 936.
+	t.addDecoded(simplifiedchinese.GBK, byteRange(0x81, 0xFE), func(d uint16, u uint16) bool {
+		return u == 0x20AC
+	}, nil)
+	t.addPrivateUse(0xE000, 0xAA, 0xAF, 0xA1, 0xFE)
+	t.addPrivateUse(0xE234, 0xF8, 0xFE, 0xA1, 0xFE)
+	t.addPrivateUse(0xE4C6, 0xA1, 0xA7, 0x40, 0xA0)
+	return t
+}
+
+// Code page 950, Big5 with the ETEN extensions and the euro sign 0xA3E1
+// The HKSCS characters of WHATWG Big5 are not defined, 0xC6A1-0xC8FE is EUDC.
+func newBig5Table() *codePageTable {
+	t := newDoubleByteTable('?', [][2]byte{{0x81, 0xFE}}, [][2]byte{{0x40, 0x7E}, {0xA1, 0xFE}})
+	t.setSingle(0x80, 0x80)
+	t.setSingle(0xFF, 0xF8F8)
+
+	t.addDecoded(traditionalchinese.Big5, byteRange(0xA1, 0xF9), func(d uint16, u uint16) bool {
+		return (d >= 0xC6A1 && d <= 0xC8FE) || (d >= 0xA3C0 && d <= 0xA3FE && d != 0xA3E1)
+	}, func(u uint16) bool {
+		// The duplicate characters encoded to the last double byte
+		switch u {
+		case 0x2550, 0x255E, 0x2561, 0x256A, 0x5341, 0x5345:
+			return true
+		}
+		return false
+	})
+	t.addPrivateUse(0xE000, 0xFA, 0xFE, 0x40, 0xFE)
+	t.addPrivateUse(0xE311, 0x8E, 0xA0, 0x40, 0xFE)
+	t.addPrivateUse(0xEEB8, 0x81, 0x8D, 0x40, 0xFE)
+	t.addPrivateUse(0xF6B1, 0xC6, 0xC6, 0xA1, 0xFE)
+	t.addPrivateUse(0xF6B1+94, 0xC7, 0xC8, 0x40, 0xFE)
+	return t
+}
+
+// The one way mappings of the best fit tables of windows (bestfit*.txt), from the character to the byte or the double byte.
+// They are set by codepage_bestfit_table.go, generated by "go run mkbestfit.go" from the tables of
+// https://www.unicode.org/Public/MAPPINGS/VENDORS/MICSFT/WindowsBestFit/
+var codePageBestFitTables map[CodePage]map[uint16]uint16
+
+// The best fit characters used without the generated tables, the first one that can be encoded is used.
+// It is a subset of the best fit table of windows, the common punctuations and symbols.
+var codePageBestFit = map[uint16][]uint16{
+	0x0110: {0x00D0, 'D'}, // LATIN CAPITAL LETTER D WITH STROKE
+	0x0111: {'d'},
+	0x0126: {'H'},
+	0x0127: {'h'},
+	0x0131: {'i'}, // LATIN SMALL LETTER DOTLESS I
+	0x0141: {'L'},
+	0x0142: {'l'},
+	0x0166: {'T'},
+	0x0167: {'t'},
+	0x00AB: {'<'},
+	0x00BB: {'>'},
+	0x2010: {'-'},
+	0x2011: {'-'},
+	0x2013: {'-'},
+	0x2014: {'-'},
+	0x2018: {'\''},
+	0x2019: {'\''},
+	0x201C: {'"'},
+	0x201D: {'"'},
+	0x2039: {'<'},
+	0x203A: {'>'},
+	0x2044: {'/'}, // FRACTION SLASH
+	0x2212: {'-'}, // MINUS SIGN
+	0x2215: {'/'}, // DIVISION SLASH
+	0x2216: {'\\'},
+	0x221E: {'8'}, // INFINITY
+}
+
+// The best fit of windows, from the generated tables if they exist.
+// Otherwise the single byte code pages map the fullwidth ASCII to ASCII, the latin letters to the letters
+// without the marks and codePageBestFit; the double byte code pages only have the best fit of the yen sign
+// and the overline of 932.
+func (t *codePageTable) addBestFit(codePage CodePage) {
+	if table, ok := codePageBestFitTables[codePage]; ok {
+		for u, v := range table {
+			if _, ok := t.encode[u]; ok == false {
+				t.bestFit[u] = v
+			}
+		}
+		return
+	}
+
+	add := func(u uint16, candidates ...uint16) {
+		if _, ok := t.encode[u]; ok {
+			return
+		}
+		for _, c := range candidates {
+			if v, ok := t.encode[c]; ok {
+				t.bestFit[u] = v
+				return
+			}
+		}
+	}
+
+	switch codePage {
+	case CP_SHIFT_JIS:
+		add(0x00A5, '\\')
+		add(0x203E, '~')
+		return
+	case CP_GBK, CP_BIG5, CP_SYMBOL:
+		return
+	}
+
+	for u := uint16(0xFF01); u <= 0xFF5E; u++ {
+		add(u, u-0xFEE0)
+	}
+	for u := uint16(0x00C0); u <= 0x024F; u++ {
+		if base := []rune(norm.NFD.String(string(rune(u))))[0]; base < utf8.RuneSelf && base != rune(u) {
+			add(u, uint16(base))
+		}
+	}
+	for u, candidates := range codePageBestFit {
+		add(u, candidates...)
+	}
+}
+
+// The glyph characters of the control characters for MB_USEGLYPHCHARS
+var codePageGlyphs = [32]uint16{
+	0x0000, 0x263A, 0x263B, 0x2665, 0x2666, 0x2663, 0x2660, 0x2022,
+	0x25D8, 0x25CB, 0x25D9, 0x2642, 0x2640, 0x266A, 0x266B, 0x263C,
+	0x25BA, 0x25C4, 0x2195, 0x203C, 0x00B6, 0x00A7, 0x25AC, 0x21A8,
+	0x2191, 0x2193, 0x2192, 0x2190, 0x221F, 0x2194, 0x25B2, 0x25BC,
+}
+
+func glyphChar(b byte, u uint16) uint16 {
+	switch {
+	case b < 0x20:
+		return codePageGlyphs[b]
+	case b == 0x7F:
+		return 0x2302
+	}
+	return u
+}
@@ -0,0 +1,175 @@
+package gowindows
+
+import (
+	"bytes"
+	"testing"
+	"unicode/utf16"
+)
+
+// The corpus of WideChar2MultiByte, the output is the same as windows.
+var codePageEncodeCorpus = []struct {
+	codePage    CodePage
+	flags       WideChar2MultiByteFlags
+	wchar       []uint16
+	defaultChar byte
+	out         []byte
+	usedDefault bool
+	err         error
+}{
+	{CP_WINDOWS_1252, 0, utf16.Encode([]rune("café €")), 0, []byte{'c', 'a', 'f', 0xE9, ' ', 0x80}, false, nil},
+	{CP_WINDOWS_1252, 0, utf16.Encode([]rune("∞Āı＂")), 0, []byte("8Ai\""), false, nil},
+	{CP_WINDOWS_1252, WC_NO_BEST_FIT_CHARS, utf16.Encode([]rune("∞Āı＂")), 0, []byte("????"), true, nil},
+	{CP_WINDOWS_1252, 0, utf16.Encode([]rune("a中b")), '*', []byte("a*b"), true, nil},
+	{CP_WINDOWS_1252, 0, utf16.Encode([]rune("😀")), 0, []byte("??"), true, nil},
+	{CP_WINDOWS_1252, 0, []uint16{0xD800, 'a'}, 0, []byte("?a"), true, nil},
+	{CP_WINDOWS_1252, WC_COMPOSITECHECK, []uint16{'e', 0x0301}, 0, []byte{0xE9}, false, nil},
+	{CP_WINDOWS_1252, WC_ERR_INVALID_CHARS, utf16.Encode([]rune("a")), 0, nil, false, ERROR_INVALID_FLAGS},
+	{CP_IBM437, 0, utf16.Encode([]rune("é╬√")), 0, []byte{0x82, 0xCE, 0xFB}, false, nil},
+	{CP_IBM437, WC_NO_BEST_FIT_CHARS, utf16.Encode([]rune("€À")), 0, []byte("??"), true, nil},
+	{CP_IBM437, 0, utf16.Encode([]rune("À“”")), 0, []byte("A\"\""), false, nil},
+	{CP_IBM866, 0, utf16.Encode([]rune("Привет №")), 0, []byte{0x8F, 0xE0, 0xA8, 0xA2, 0xA5, 0xE2, ' ', 0xFC}, false, nil},
+	{CP_GBK, 0, utf16.Encode([]rune("中文€")), 0, []byte{0xD6, 0xD0, 0xCE, 0xC4, 0x80}, false, nil},
+	{CP_GBK, 0, utf16.Encode([]rune("\uE000aก")), 0, []byte{0xAA, 0xA1, 'a', '?'}, true, nil},
+	{CP_SHIFT_JIS, 0, utf16.Encode([]rune("日本語ｱⅰ￢")), 0, []byte{0x93, 0xFA, 0x96, 0x7B, 0x8C, 0xEA, 0xB1, 0xFA, 0x40, 0x81, 0xCA}, false, nil},
+	{CP_SHIFT_JIS, 0, utf16.Encode([]rune("¥")), 0, []byte{0x5C}, false, nil},
+	{CP_SHIFT_JIS, WC_NO_BEST_FIT_CHARS, utf16.Encode([]rune("¥")), 0, []byte{'?'}, true, nil},
+	{CP_BIG5, 0, utf16.Encode([]rune("中文十€")), 0, []byte{0xA4, 0xA4, 0xA4, 0xE5, 0xA4, 0x51, 0xA3, 0xE1}, false, nil},
+	{CP_UTF8, 0, utf16.Encode([]rune("中文😀")), 0, []byte("中文😀"), false, nil},
+	{CP_UTF8, 0, []uint16{'a', 0xDC00}, 0, []byte("a�"), false, nil},
+	{CP_UTF8, WC_ERR_INVALID_CHARS, []uint16{'a', 0xDC00}, 0, nil, false, ERROR_NO_UNICODE_TRANSLATION},
+	{CP_UTF8, 0, utf16.Encode([]rune("a")), '?', nil, false, ERROR_INVALID_PARAMETER},
+	{CP_UTF8, WC_NO_BEST_FIT_CHARS, utf16.Encode([]rune("a")), 0, nil, false, ERROR_INVALID_FLAGS},
+}
+
+// The corpus of MultiByte2WideChar, the output is the same as windows.
+var codePageDecodeCorpus = []struct {
+	codePage CodePage
+	flags    MultiByteToWideCharFlags
+	str      []byte
+	out      string
+	err      error
+}{
+	{CP_WINDOWS_1252, 0, []byte{'c', 0xE9, 0x80, 0x81, 0x9F}, "cé€\u0081Ÿ", nil},
+	{CP_WINDOWS_1252, MB_ERR_INVALID_CHARS, []byte{0x8D, 0x90}, "\u008D\u0090", nil},
+	{CP_WINDOWS_1252, MB_COMPOSITE, []byte{0xE9}, "é", nil},
+	{CP_WINDOWS_1252, MB_PRECOMPOSED | MB_COMPOSITE, []byte{'a'}, "", ERROR_INVALID_FLAGS},
+	{CP_IBM437, 0, []byte{0x01, 0x82, 0xFB, 0x7F}, "\x01é√\x7F", nil},
+	{CP_IBM437, MB_USEGLYPHCHARS, []byte{0x01, 0x82, 0xFB, 0x7F}, "☺é√⌂", nil},
+	{CP_IBM866, 0, []byte{0x8F, 0xE0, 0xA8, 0xA2, 0xA5, 0xE2, 0xFF}, "Привет\u00A0", nil},
+	{CP_GBK, 0, []byte{0xD6, 0xD0, 0xCE, 0xC4, 0x80, 0xFF}, "中文€\uF8F5", nil},
+	{CP_GBK, 0, []byte{0xAA, 0xA1, 0xFE, 0xFE, 0xA1, 0x40}, "\uE000\uE4C5\uE4C6", nil},
+	{CP_GBK, 0, []byte{0x81, ' ', 0x81}, "? ?", nil},
+	{CP_GBK, MB_ERR_INVALID_CHARS, []byte{'a', 0x81}, "", ERROR_NO_UNICODE_TRANSLATION},
+	{CP_SHIFT_JIS, 0, []byte{0x93, 0xFA, 0x96, 0x7B, 0x8C, 0xEA, 0xB1, 0x5C, 0xA0}, "日本語ｱ\\\uF8F0", nil},
+	{CP_SHIFT_JIS, 0, []byte{0xED, 0x40, 0xFA, 0x40, 0xF0, 0x40, 0xF9, 0xFC}, "纊ⅰ\uE000\uE757", nil},
+	{CP_SHIFT_JIS, 0, []byte{0x85, 0x40}, "・", nil},
+	{CP_SHIFT_JIS, MB_ERR_INVALID_CHARS, []byte{0x85, 0x40}, "", ERROR_NO_UNICODE_TRANSLATION},
+	{CP_BIG5, 0, []byte{0xA4, 0xA4, 0xA4, 0xE5, 0xA2, 0xCC, 0xA3, 0xE1, 0xF9, 0xD6}, "中文十€碁", nil},
+	{CP_BIG5, 0, []byte{0xFA, 0x40, 0x8E, 0x40, 0x81, 0x40, 0xC6, 0xA1, 0xC8, 0xFE}, "\uE000\uE311\uEEB8\uF6B1\uF848", nil},
+	{CP_BIG5, 0, []byte{0xA3, 0xC0, 0xA1}, "??", nil},
+	{CP_UTF8, 0, []byte("中文😀"), "中文😀", nil},
+	{CP_UTF8, 0, []byte{'a', 0xE4, 0xB8, 'b', 0xED, 0xA0, 0x80, 0xFF}, "a�b����", nil},
+	{CP_UTF8, MB_ERR_INVALID_CHARS, []byte{'a', 0xE4, 0xB8}, "", ERROR_NO_UNICODE_TRANSLATION},
+	{CP_UTF8, MB_PRECOMPOSED, []byte{'a'}, "", ERROR_INVALID_FLAGS},
+	{CP_UTF7, 0, []byte("A+ImIDkQ. +- Hi Mom -+Jjo--!"), "A≢Α. + Hi Mom -☺-!", nil},
+	{CP_UTF7, MB_ERR_INVALID_CHARS, []byte("a"), "", ERROR_INVALID_FLAGS},
+	{CP_SYMBOL, 0, []byte{0x01, 0x41, 0xFF}, "\x01\uF041\uF0FF", nil},
+}
+
+func TestCodePageConverter_WideChar2MultiByte(t *testing.T) {
+	for i, v := range codePageEncodeCorpus {
+		c, err := NewCodePageConverter(v.codePage)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var defaultChar *byte
+		var usedDefault *bool
+		if v.defaultChar != 0 {
+			defaultChar = &v.defaultChar
+		}
+		if v.codePage != CP_UTF8 {
+			usedDefault = new(bool)
+		}
+
+		out, err := c.WideChar2MultiByte(v.flags, v.wchar, defaultChar, usedDefault)
+		if err != v.err {
+			t.Errorf("%v: %v!=%v", i, err, v.err)
+			continue
+		}
+		if bytes.Equal(out, v.out) == false {
+			t.Errorf("%v: % x!=% x", i, out, v.out)
+		}
+		if usedDefault != nil && err == nil && *usedDefault != v.usedDefault {
+			t.Errorf("%v: used default %v!=%v", i, *usedDefault, v.usedDefault)
+		}
+	}
+}
+
+func TestCodePageConverter_MultiByte2WideChar(t *testing.T) {
+	for i, v := range codePageDecodeCorpus {
+		c, err := NewCodePageConverter(v.codePage)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		out, err := c.MultiByte2Str(v.flags, v.str)
+		if err != v.err {
+			t.Errorf("%v: %v!=%v", i, err, v.err)
+			continue
+		}
+		if out != v.out {
+			t.Errorf("%v: %+q!=%+q", i, out, v.out)
+		}
+	}
+}
+
+// Every character of the code page is converted back to the same bytes
+func TestCodePageConverter_roundTrip(t *testing.T) {
+	for _, cp := range []CodePage{CP_WINDOWS_1252, CP_IBM437, CP_IBM866, CP_GBK, CP_SHIFT_JIS, CP_BIG5} {
+		c, err := NewCodePageConverter(cp)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for d, u := range c.table.double {
+			if v := c.table.encode[u]; v != d {
+				// Only decoded, such as the NEC selected IBM extensions of 932
+				if dd := c.table.double[v]; dd != u {
+					t.Errorf("%v: 0x%x -> U+%04X -> 0x%x", cp, d, u, v)
+				}
+			}
+		}
+
+		for u, v := range c.table.bestFit {
+			if _, ok := c.table.encode[u]; ok {
+				t.Errorf("%v: U+%04X is best fit to 0x%x, but it can be encoded", cp, u, v)
+			}
+		}
+	}
+
+	if _, err := NewCodePageConverter(1250); err != ERROR_INVALID_PARAMETER {
+		t.Errorf("%v!=%v", err, ERROR_INVALID_PARAMETER)
+	}
+}
+
+func TestUtf8InvalidLength(t *testing.T) {
+	data := []struct {
+		p []byte
+		n int
+	}{
+		{[]byte{0x80}, 1},
+		{[]byte{0xC2}, 1},
+		{[]byte{0xE0, 0x80}, 1},
+		{[]byte{0xE0, 0xA0}, 2},
+		{[]byte{0xED, 0xA0, 0x80}, 1},
+		{[]byte{0xF0, 0x90, 0x80, 'a'}, 3},
+		{[]byte{0xF4, 0x90}, 1},
+		{[]byte{0xF5, 0x80}, 1},
+	}
+	for _, v := range data {
+		if n := utf8InvalidLength(v.p); n != v.n {
+			t.Errorf("% x: %v!=%v", v.p, n, v.n)
+		}
+	}
+}
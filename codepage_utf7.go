@@ -0,0 +1,116 @@
+package gowindows
+
+// UTF-7 (RFC 2152) of CP_UTF7
+// The characters except the directly encoded characters (the set D and the white spaces) are encoded by the modified base64,
+// '+' is encoded as "+-", and the base64 is always ended by '-'.
+const utf7Base64 = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789+/"
+
+func utf7Direct(u uint16) bool {
+	switch {
+	case u >= 'A' && u <= 'Z', u >= 'a' && u <= 'z', u >= '0' && u <= '9':
+		return true
+	}
+	switch u {
+	case '\'', '(', ')', ',', '-', '.', '/', ':', '?', ' ', '\t', '\r', '\n':
+		return true
+	}
+	return false
+}
+
+// The value of the base64 char, -1 if it is not
+func utf7Value(b byte) int {
+	switch {
+	case b >= 'A' && b <= 'Z':
+		return int(b - 'A')
+	case b >= 'a' && b <= 'z':
+		return int(b-'a') + 26
+	case b >= '0' && b <= '9':
+		return int(b-'0') + 52
+	case b == '+':
+		return 62
+	case b == '/':
+		return 63
+	}
+	return -1
+}
+
+type utf7Encoder struct {
+	base64 bool
+	bits   uint32
+	n      uint
+}
+
+func (e *utf7Encoder) encode(out []byte, u uint16) []byte {
+	if utf7Direct(u) {
+		out = e.flush(out)
+		return append(out, byte(u))
+	}
+	if u == '+' && e.base64 == false {
+		return append(out, '+', '-')
+	}
+
+	if e.base64 == false {
+		out = append(out, '+')
+		e.base64 = true
+	}
+	e.bits = e.bits<<16 | uint32(u)
+	e.n += 16
+	for e.n >= 6 {
+		e.n -= 6
+		out = append(out, utf7Base64[(e.bits>>e.n)&0x3F])
+	}
+	return out
+}
+
+// End the base64
+func (e *utf7Encoder) flush(out []byte) []byte {
+	if e.base64 == false {
+		return out
+	}
+	if e.n > 0 {
+		out = append(out, utf7Base64[(e.bits<<(6-e.n))&0x3F])
+	}
+	*e = utf7Encoder{}
+	return append(out, '-')
+}
+
+type utf7Decoder struct {
+	base64 bool
+	// The last byte is the '+' starting the base64
+	plus bool
+	bits uint32
+	n    uint
+}
+
+// The bytes that are not ASCII are decoded to U+FFFD, the remaining bits of the base64 are discarded.
+func (d *utf7Decoder) decode(out []uint16, b byte) []uint16 {
+	if d.base64 {
+		if v := utf7Value(b); v >= 0 {
+			d.plus = false
+			d.bits = d.bits<<6 | uint32(v)
+			d.n += 6
+			if d.n >= 16 {
+				d.n -= 16
+				out = append(out, uint16(d.bits>>d.n))
+			}
+			return out
+		}
+
+		plus := d.plus
+		*d = utf7Decoder{}
+		if b == '-' {
+			if plus {
+				out = append(out, '+')
+			}
+			return out
+		}
+	} else if b == '+' {
+		d.base64, d.plus = true, true
+		return out
+	}
+
+	if b >= 0x80 {
+		return append(out, 0xFFFD)
+	}
+	return append(out, uint16(b))
+}
@@ -0,0 +1,23 @@
+package gowindows
+
+import (
+	"strconv"
+
+	"golang.org/x/sys/windows"
+)
+
+func resolveCodePage(codePage CodePage) CodePage {
+	switch codePage {
+	case CP_ACP, CP_THREAD_ACP:
+		return CodePage(windows.GetACP())
+	case CP_OEMCP:
+		return CodePage(GetOEMCP())
+	case CP_MACCP:
+		if s, err := GetLocaleInfo(LOCALE_SYSTEM_DEFAULT, LOCALE_IDEFAULTMACCODEPAGE); err == nil {
+			if v, err := strconv.Atoi(s); err == nil {
+				return CodePage(v)
+			}
+		}
+	}
+	return codePage
+}
@@ -0,0 +1,109 @@
+package gowindows
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+// The corpus is the output of the windows api
+func TestCodePageConverter_windows(t *testing.T) {
+	for i, v := range codePageEncodeCorpus {
+		var defaultChar *byte
+		var usedDefault *bool
+		if v.defaultChar != 0 {
+			defaultChar = &v.defaultChar
+		}
+		if v.codePage != CP_UTF8 {
+			usedDefault = new(bool)
+		}
+
+		out, err := WideChar2MultiByte(v.codePage, v.flags, v.wchar, defaultChar, usedDefault)
+		if errors.Is(err, v.err) == false {
+			t.Errorf("%v: %v!=%v", i, err, v.err)
+			continue
+		}
+		if bytes.Equal(out, v.out) == false {
+			t.Errorf("%v: % x!=% x", i, out, v.out)
+		}
+		if usedDefault != nil && err == nil && *usedDefault != v.usedDefault {
+			t.Errorf("%v: used default %v!=%v", i, *usedDefault, v.usedDefault)
+		}
+	}
+
+	for i, v := range codePageDecodeCorpus {
+		out, err := MultiByte2Str(v.codePage, v.flags, v.str)
+		if errors.Is(err, v.err) == false {
+			t.Errorf("%v: %v!=%v", i, err, v.err)
+			continue
+		}
+		if out != v.out {
+			t.Errorf("%v: %+q!=%+q", i, out, v.out)
+		}
+	}
+}
+
+// Every byte of the single byte code pages, and every character without best fit
+func TestCodePageConverter_windowsSingleByte(t *testing.T) {
+	for _, cp := range []CodePage{CP_WINDOWS_1252, CP_IBM437, CP_IBM866} {
+		c, err := NewCodePageConverter(cp)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for b := 0; b < 256; b++ {
+			for _, flags := range []MultiByteToWideCharFlags{0, MB_USEGLYPHCHARS} {
+				want, err := MultiByte2WideChar(cp, flags, []byte{byte(b)})
+				if err != nil {
+					t.Fatal(err)
+				}
+				out, _ := c.MultiByte2WideChar(flags, []byte{byte(b)})
+				if len(out) != 1 || out[0] != want[0] {
+					t.Errorf("%v 0x%x %v: %x!=%x", cp, b, flags, out, want)
+				}
+			}
+		}
+
+		for u := 1; u < 0xD800; u++ {
+			want, err := WideChar2MultiByte(cp, WC_NO_BEST_FIT_CHARS, []uint16{uint16(u)}, nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			out, _ := c.WideChar2MultiByte(WC_NO_BEST_FIT_CHARS, []uint16{uint16(u)}, nil, nil)
+			if bytes.Equal(out, want) == false {
+				t.Errorf("%v U+%04X: % x!=% x", cp, u, out, want)
+			}
+		}
+	}
+}
+
+// Every character with the default flags, that is with the best fit of windows
+func TestCodePageConverter_windowsBestFit(t *testing.T) {
+	for _, cp := range []CodePage{CP_WINDOWS_1252, CP_IBM437, CP_IBM866, CP_SHIFT_JIS, CP_GBK, CP_BIG5, CP_SYMBOL} {
+		c, err := NewCodePageConverter(cp)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		diff := 0
+		for u := 1; u < 0x10000; u++ {
+			if u >= 0xD800 && u < 0xE000 {
+				continue
+			}
+			var wantUsed, used bool
+			want, err := WideChar2MultiByte(cp, 0, []uint16{uint16(u)}, nil, &wantUsed)
+			if err != nil {
+				t.Fatal(err)
+			}
+			out, _ := c.WideChar2MultiByte(0, []uint16{uint16(u)}, nil, &used)
+			if bytes.Equal(out, want) == false || used != wantUsed {
+				if diff++; diff <= 20 {
+					t.Errorf("%v U+%04X: % x %v!=% x %v", cp, u, out, used, want, wantUsed)
+				}
+			}
+		}
+		if diff > 20 {
+			t.Errorf("%v: %v characters are different", cp, diff)
+		}
+	}
+}
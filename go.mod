@@ -2,4 +2,7 @@ module github.com/r10v/gowindows
 
 go 1.13
 
-require golang.org/x/sys v0.0.0-20200625212154-ddb9806d33ae // indirect
+require (
+	golang.org/x/sys v0.0.0-20200625212154-ddb9806d33ae
+	golang.org/x/text v0.3.3
+)
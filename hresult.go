@@ -0,0 +1,175 @@
+package gowindows
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+)
+
+// The severity of HRESULT
+const (
+	SEVERITY_SUCCESS = 0
+	SEVERITY_ERROR   = 1
+)
+
+// The facility of HRESULT
+// https://docs.microsoft.com/en-us/openspecs/windows_protocols/ms-erref/0642cb2f-2075-4469-918c-4441e69c548a
+const (
+	FACILITY_NULL     = 0
+	FACILITY_RPC      = 1
+	FACILITY_DISPATCH = 2
+	FACILITY_STORAGE  = 3
+	FACILITY_ITF      = 4
+	FACILITY_WIN32    = 7
+	FACILITY_WINDOWS  = 8
+	FACILITY_SECURITY = 9
+	FACILITY_CONTROL  = 10
+	FACILITY_CERT     = 11
+	FACILITY_INTERNET = 12
+	FACILITY_COMPLUS  = 17
+	FACILITY_FWP      = 50
+
+	// The N bit, the HRESULT is a NTSTATUS.
+	FACILITY_NT_BIT = 0x10000000
+)
+
+var hresultFacilityNames = map[uint32]string{
+	FACILITY_NULL:     "FACILITY_NULL",
+	FACILITY_RPC:      "FACILITY_RPC",
+	FACILITY_DISPATCH: "FACILITY_DISPATCH",
+	FACILITY_STORAGE:  "FACILITY_STORAGE",
+	FACILITY_ITF:      "FACILITY_ITF",
+	FACILITY_WIN32:    "FACILITY_WIN32",
+	FACILITY_WINDOWS:  "FACILITY_WINDOWS",
+	FACILITY_SECURITY: "FACILITY_SECURITY",
+	FACILITY_CONTROL:  "FACILITY_CONTROL",
+	FACILITY_CERT:     "FACILITY_CERT",
+	FACILITY_INTERNET: "FACILITY_INTERNET",
+	FACILITY_COMPLUS:  "FACILITY_COMPLUS",
+	FACILITY_FWP:      "FACILITY_FWP",
+}
+
+// HRESULT_FROM_WIN32
+func HRESULTFromWin32(e syscall.Errno) HRESULT {
+	if int32(e) <= 0 {
+		return HRESULT(e)
+	}
+	return HRESULT(e&0xFFFF) | FACILITY_WIN32<<16 | SEVERITY_ERROR<<31
+}
+
+// HRESULT_FROM_NT, the N bit is set.
+func HRESULTFromNT(s NTStatus) HRESULT {
+	return HRESULT(s) | FACILITY_NT_BIT
+}
+
+// FAILED
+func (h HRESULT) IsFailed() bool {
+	return h.IsSucceeded() == false
+}
+
+// SEVERITY_*
+func (h HRESULT) Severity() uint32 {
+	return uint32(h) >> 31
+}
+
+// FACILITY_*, it is the 11 bits of the facility, the R, C, N and X bits are not included.
+func (h HRESULT) Facility() uint32 {
+	return uint32(h) >> 16 & 0x7FF
+}
+
+func (h HRESULT) Code() uint32 {
+	return uint32(h) & 0xFFFF
+}
+
+// The HRESULT is a NTSTATUS of HRESULTFromNT
+func (h HRESULT) IsNTStatus() bool {
+	return h&FACILITY_NT_BIT != 0
+}
+
+// The NTSTATUS of HRESULTFromNT, false if the N bit is not set.
+func (h HRESULT) NTStatus() (NTStatus, bool) {
+	if h.IsNTStatus() == false {
+		return 0, false
+	}
+	return NTStatus(h &^ FACILITY_NT_BIT), true
+}
+
+// The win32 error of HRESULTFromWin32, false if it is not the error of FACILITY_WIN32.
+func (h HRESULT) Win32Error() (syscall.Errno, bool) {
+	if h == S_OK {
+		return ERROR_SUCCESS, true
+	}
+	if h.IsFailed() == false || h.IsNTStatus() || h.Facility() != FACILITY_WIN32 {
+		return 0, false
+	}
+	return syscall.Errno(h.Code()), true
+}
+
+// The name of the facility, such as FACILITY_WIN32, or the number if it is unknown.
+func (h HRESULT) FacilityName() string {
+	if name, ok := hresultFacilityNames[h.Facility()]; ok {
+		return name
+	}
+	return fmt.Sprintf("0x%X", h.Facility())
+}
+
+// The name of the HRESULT, such as E_ACCESSDENIED, HRESULT_FROM_WIN32(32), HRESULT_FROM_NT(STATUS_ACCESS_DENIED),
+// or HRESULT(0x80041234) if it is unknown.
+func (h HRESULT) String() string {
+	if info, ok := hresultTable[h]; ok {
+		return info.name
+	}
+	if s, ok := h.NTStatus(); ok {
+		return fmt.Sprintf("HRESULT_FROM_NT(%v)", s.String())
+	}
+	if e, ok := h.Win32Error(); ok {
+		return fmt.Sprintf("HRESULT_FROM_WIN32(%d)", e)
+	}
+	return fmt.Sprintf("HRESULT(0x%08X)", uint32(h))
+}
+
+// The message of the HRESULT, or empty if it is unknown.
+func (h HRESULT) Message() string {
+	if info, ok := hresultTable[h]; ok {
+		return info.message
+	}
+	if s, ok := h.NTStatus(); ok {
+		return s.Message()
+	}
+	return ""
+}
+
+func (h HRESULT) Error() string {
+	if message := h.Message(); message != "" {
+		return fmt.Sprintf("%v (0x%08X): %v", h.String(), uint32(h), message)
+	}
+	if h.IsNTStatus() || h.Facility() == FACILITY_WIN32 {
+		return fmt.Sprintf("%v (0x%08X)", h.String(), uint32(h))
+	}
+	return fmt.Sprintf("HRESULT 0x%08X (facility %v, code 0x%X)", uint32(h), h.FacilityName(), h.Code())
+}
+
+// errors.Is, the HRESULT is the win32 error of HRESULTFromWin32 and the NTSTATUS of HRESULTFromNT.
+func (h HRESULT) Is(target error) bool {
+	if h.IsSucceeded() {
+		return false
+	}
+	if s, ok := h.NTStatus(); ok {
+		if t, ok := target.(NTStatus); ok {
+			return s == t
+		}
+		return s.Is(target)
+	}
+	e, ok := h.Win32Error()
+	if ok == false {
+		return false
+	}
+	switch target {
+	case os.ErrPermission, os.ErrExist, os.ErrNotExist:
+		return NTStatusFromWin32(e).Is(target)
+	}
+	if t, ok := target.(syscall.Errno); ok {
+		return e == t
+	}
+	return false
+}
@@ -0,0 +1,260 @@
+package gowindows
+
+// The common HRESULT of COM, WinRT and FWP (the errors of the Fwpm* functions).
+// https://docs.microsoft.com/en-us/windows/win32/seccrypto/common-hresult-values
+// https://docs.microsoft.com/en-us/windows/win32/fwp/wfp-error-codes
+const (
+	// COM
+	S_OK                      HRESULT = 0x00000000
+	S_FALSE                   HRESULT = 0x00000001
+	E_NOTIMPL                 HRESULT = 0x80004001
+	E_NOINTERFACE             HRESULT = 0x80004002
+	E_POINTER                 HRESULT = 0x80004003
+	E_ABORT                   HRESULT = 0x80004004
+	E_FAIL                    HRESULT = 0x80004005
+	E_UNEXPECTED              HRESULT = 0x8000FFFF
+	E_ACCESSDENIED            HRESULT = 0x80070005
+	E_HANDLE                  HRESULT = 0x80070006
+	E_OUTOFMEMORY             HRESULT = 0x8007000E
+	E_INVALIDARG              HRESULT = 0x80070057
+	E_NOT_SUFFICIENT_BUFFER   HRESULT = 0x8007007A
+	E_NOT_SET                 HRESULT = 0x80070490
+	E_NOT_VALID_STATE         HRESULT = 0x8007139F
+	E_PENDING                 HRESULT = 0x8000000A
+	RPC_E_CALL_REJECTED       HRESULT = 0x80010001
+	RPC_E_SERVERFAULT         HRESULT = 0x80010105
+	RPC_E_CHANGED_MODE        HRESULT = 0x80010106
+	RPC_E_DISCONNECTED        HRESULT = 0x80010108
+	RPC_E_WRONG_THREAD        HRESULT = 0x8001010E
+	DISP_E_MEMBERNOTFOUND     HRESULT = 0x80020003
+	DISP_E_TYPEMISMATCH       HRESULT = 0x80020005
+	DISP_E_UNKNOWNNAME        HRESULT = 0x80020006
+	DISP_E_EXCEPTION          HRESULT = 0x80020009
+	DISP_E_BADPARAMCOUNT      HRESULT = 0x8002000E
+	CLASS_E_NOAGGREGATION     HRESULT = 0x80040110
+	CLASS_E_CLASSNOTAVAILABLE HRESULT = 0x80040111
+	REGDB_E_READREGDB         HRESULT = 0x80040150
+	REGDB_E_CLASSNOTREG       HRESULT = 0x80040154
+	CO_E_NOTINITIALIZED       HRESULT = 0x800401F0
+	CO_E_ALREADYINITIALIZED   HRESULT = 0x800401F1
+	CO_E_CLASSSTRING          HRESULT = 0x800401F3
+	CO_E_IIDSTRING            HRESULT = 0x800401F4
+
+	// WinRT
+	E_BOUNDS                             HRESULT = 0x8000000B
+	E_CHANGED_STATE                      HRESULT = 0x8000000C
+	E_ILLEGAL_STATE_CHANGE               HRESULT = 0x8000000D
+	E_ILLEGAL_METHOD_CALL                HRESULT = 0x8000000E
+	RO_E_METADATA_NAME_NOT_FOUND         HRESULT = 0x8000000F
+	RO_E_METADATA_NAME_IS_NAMESPACE      HRESULT = 0x80000010
+	RO_E_METADATA_INVALID_TYPE_FORMAT    HRESULT = 0x80000011
+	RO_E_INVALID_METADATA_FILE           HRESULT = 0x80000012
+	RO_E_CLOSED                          HRESULT = 0x80000013
+	RO_E_EXCLUSIVE_WRITE                 HRESULT = 0x80000014
+	RO_E_CHANGE_NOTIFICATION_IN_PROGRESS HRESULT = 0x80000015
+	RO_E_ERROR_STRING_NOT_FOUND          HRESULT = 0x80000016
+	E_STRING_NOT_NULL_TERMINATED         HRESULT = 0x80000017
+	E_ILLEGAL_DELEGATE_ASSIGNMENT        HRESULT = 0x80000018
+	E_ASYNC_OPERATION_NOT_STARTED        HRESULT = 0x80000019
+	E_APPLICATION_EXITING                HRESULT = 0x8000001A
+	E_APPLICATION_VIEW_EXITING           HRESULT = 0x8000001B
+
+	// FWP
+	FWP_E_CALLOUT_NOT_FOUND                     HRESULT = 0x80320001
+	FWP_E_CONDITION_NOT_FOUND                   HRESULT = 0x80320002
+	FWP_E_FILTER_NOT_FOUND                      HRESULT = 0x80320003
+	FWP_E_LAYER_NOT_FOUND                       HRESULT = 0x80320004
+	FWP_E_PROVIDER_NOT_FOUND                    HRESULT = 0x80320005
+	FWP_E_PROVIDER_CONTEXT_NOT_FOUND            HRESULT = 0x80320006
+	FWP_E_SUBLAYER_NOT_FOUND                    HRESULT = 0x80320007
+	FWP_E_NOT_FOUND                             HRESULT = 0x80320008
+	FWP_E_ALREADY_EXISTS                        HRESULT = 0x80320009
+	FWP_E_IN_USE                                HRESULT = 0x8032000A
+	FWP_E_DYNAMIC_SESSION_IN_PROGRESS           HRESULT = 0x8032000B
+	FWP_E_WRONG_SESSION                         HRESULT = 0x8032000C
+	FWP_E_NO_TXN_IN_PROGRESS                    HRESULT = 0x8032000D
+	FWP_E_TXN_IN_PROGRESS                       HRESULT = 0x8032000E
+	FWP_E_TXN_ABORTED                           HRESULT = 0x8032000F
+	FWP_E_SESSION_ABORTED                       HRESULT = 0x80320010
+	FWP_E_INCOMPATIBLE_TXN                      HRESULT = 0x80320011
+	FWP_E_TIMEOUT                               HRESULT = 0x80320012
+	FWP_E_NET_EVENTS_DISABLED                   HRESULT = 0x80320013
+	FWP_E_INCOMPATIBLE_LAYER                    HRESULT = 0x80320014
+	FWP_E_KM_CLIENTS_ONLY                       HRESULT = 0x80320015
+	FWP_E_LIFETIME_MISMATCH                     HRESULT = 0x80320016
+	FWP_E_BUILTIN_OBJECT                        HRESULT = 0x80320017
+	FWP_E_TOO_MANY_CALLOUTS                     HRESULT = 0x80320018
+	FWP_E_NOTIFICATION_DROPPED                  HRESULT = 0x80320019
+	FWP_E_TRAFFIC_MISMATCH                      HRESULT = 0x8032001A
+	FWP_E_INCOMPATIBLE_SA_STATE                 HRESULT = 0x8032001B
+	FWP_E_NULL_POINTER                          HRESULT = 0x8032001C
+	FWP_E_INVALID_ENUMERATOR                    HRESULT = 0x8032001D
+	FWP_E_INVALID_FLAGS                         HRESULT = 0x8032001E
+	FWP_E_INVALID_NET_MASK                      HRESULT = 0x8032001F
+	FWP_E_INVALID_RANGE                         HRESULT = 0x80320020
+	FWP_E_INVALID_INTERVAL                      HRESULT = 0x80320021
+	FWP_E_ZERO_LENGTH_ARRAY                     HRESULT = 0x80320022
+	FWP_E_NULL_DISPLAY_NAME                     HRESULT = 0x80320023
+	FWP_E_INVALID_ACTION_TYPE                   HRESULT = 0x80320024
+	FWP_E_INVALID_WEIGHT                        HRESULT = 0x80320025
+	FWP_E_MATCH_TYPE_MISMATCH                   HRESULT = 0x80320026
+	FWP_E_TYPE_MISMATCH                         HRESULT = 0x80320027
+	FWP_E_OUT_OF_BOUNDS                         HRESULT = 0x80320028
+	FWP_E_RESERVED                              HRESULT = 0x80320029
+	FWP_E_DUPLICATE_CONDITION                   HRESULT = 0x8032002A
+	FWP_E_DUPLICATE_KEYMOD                      HRESULT = 0x8032002B
+	FWP_E_ACTION_INCOMPATIBLE_WITH_LAYER        HRESULT = 0x8032002C
+	FWP_E_ACTION_INCOMPATIBLE_WITH_SUBLAYER     HRESULT = 0x8032002D
+	FWP_E_CONTEXT_INCOMPATIBLE_WITH_LAYER       HRESULT = 0x8032002E
+	FWP_E_CONTEXT_INCOMPATIBLE_WITH_CALLOUT     HRESULT = 0x8032002F
+	FWP_E_INCOMPATIBLE_AUTH_METHOD              HRESULT = 0x80320030
+	FWP_E_INCOMPATIBLE_DH_GROUP                 HRESULT = 0x80320031
+	FWP_E_EM_NOT_SUPPORTED                      HRESULT = 0x80320032
+	FWP_E_NEVER_MATCH                           HRESULT = 0x80320033
+	FWP_E_PROVIDER_CONTEXT_MISMATCH             HRESULT = 0x80320034
+	FWP_E_INVALID_PARAMETER                     HRESULT = 0x80320035
+	FWP_E_TOO_MANY_SUBLAYERS                    HRESULT = 0x80320036
+	FWP_E_CALLOUT_NOTIFICATION_FAILED           HRESULT = 0x80320037
+	FWP_E_INVALID_AUTH_TRANSFORM                HRESULT = 0x80320038
+	FWP_E_INVALID_CIPHER_TRANSFORM              HRESULT = 0x80320039
+	FWP_E_INCOMPATIBLE_CIPHER_TRANSFORM         HRESULT = 0x8032003A
+	FWP_E_INVALID_TRANSFORM_COMBINATION         HRESULT = 0x8032003B
+	FWP_E_DUPLICATE_AUTH_METHOD                 HRESULT = 0x8032003C
+	FWP_E_INVALID_TUNNEL_ENDPOINT               HRESULT = 0x8032003D
+	FWP_E_L2_DRIVER_NOT_READY                   HRESULT = 0x8032003E
+	FWP_E_KEY_DICTATOR_ALREADY_REGISTERED       HRESULT = 0x8032003F
+	FWP_E_KEY_DICTATION_INVALID_KEYING_MATERIAL HRESULT = 0x80320040
+	FWP_E_CONNECTIONS_DISABLED                  HRESULT = 0x80320041
+	FWP_E_INVALID_DNS_NAME                      HRESULT = 0x80320042
+	FWP_E_STILL_ON                              HRESULT = 0x80320043
+	FWP_E_IKEEXT_NOT_RUNNING                    HRESULT = 0x80320044
+	FWP_E_DROP_NOICMP                           HRESULT = 0x80320104
+)
+
+type hresultInfo struct {
+	name    string
+	message string
+}
+
+var hresultTable = map[HRESULT]hresultInfo{
+	S_OK:                                        {"S_OK", "The operation completed successfully."},
+	S_FALSE:                                     {"S_FALSE", "The operation completed successfully, the result is false."},
+	E_NOTIMPL:                                   {"E_NOTIMPL", "Not implemented."},
+	E_NOINTERFACE:                               {"E_NOINTERFACE", "No such interface supported."},
+	E_POINTER:                                   {"E_POINTER", "Invalid pointer."},
+	E_ABORT:                                     {"E_ABORT", "Operation aborted."},
+	E_FAIL:                                      {"E_FAIL", "Unspecified error."},
+	E_UNEXPECTED:                                {"E_UNEXPECTED", "Catastrophic failure."},
+	E_ACCESSDENIED:                              {"E_ACCESSDENIED", "General access denied error."},
+	E_HANDLE:                                    {"E_HANDLE", "Invalid handle."},
+	E_OUTOFMEMORY:                               {"E_OUTOFMEMORY", "Ran out of memory."},
+	E_INVALIDARG:                                {"E_INVALIDARG", "One or more arguments are invalid."},
+	E_NOT_SUFFICIENT_BUFFER:                     {"E_NOT_SUFFICIENT_BUFFER", "The data area passed to a system call is too small."},
+	E_NOT_SET:                                   {"E_NOT_SET", "Element not found."},
+	E_NOT_VALID_STATE:                           {"E_NOT_VALID_STATE", "The group or resource is not in the correct state to perform the requested operation."},
+	E_PENDING:                                   {"E_PENDING", "The data necessary to complete this operation is not yet available."},
+	RPC_E_CALL_REJECTED:                         {"RPC_E_CALL_REJECTED", "Call was rejected by callee."},
+	RPC_E_SERVERFAULT:                           {"RPC_E_SERVERFAULT", "The server threw an exception."},
+	RPC_E_CHANGED_MODE:                          {"RPC_E_CHANGED_MODE", "Cannot change thread mode after it is set."},
+	RPC_E_DISCONNECTED:                          {"RPC_E_DISCONNECTED", "The object invoked has disconnected from its clients."},
+	RPC_E_WRONG_THREAD:                          {"RPC_E_WRONG_THREAD", "The application called an interface that was marshalled for a different thread."},
+	DISP_E_MEMBERNOTFOUND:                       {"DISP_E_MEMBERNOTFOUND", "Member not found."},
+	DISP_E_TYPEMISMATCH:                         {"DISP_E_TYPEMISMATCH", "Type mismatch."},
+	DISP_E_UNKNOWNNAME:                          {"DISP_E_UNKNOWNNAME", "Unknown name."},
+	DISP_E_EXCEPTION:                            {"DISP_E_EXCEPTION", "Exception occurred."},
+	DISP_E_BADPARAMCOUNT:                        {"DISP_E_BADPARAMCOUNT", "Invalid number of parameters."},
+	CLASS_E_NOAGGREGATION:                       {"CLASS_E_NOAGGREGATION", "Class does not support aggregation (or class object is remote)."},
+	CLASS_E_CLASSNOTAVAILABLE:                   {"CLASS_E_CLASSNOTAVAILABLE", "ClassFactory cannot supply requested class."},
+	REGDB_E_READREGDB:                           {"REGDB_E_READREGDB", "Could not read key from registry."},
+	REGDB_E_CLASSNOTREG:                         {"REGDB_E_CLASSNOTREG", "Class not registered."},
+	CO_E_NOTINITIALIZED:                         {"CO_E_NOTINITIALIZED", "CoInitialize has not been called."},
+	CO_E_ALREADYINITIALIZED:                     {"CO_E_ALREADYINITIALIZED", "CoInitialize has already been called."},
+	CO_E_CLASSSTRING:                            {"CO_E_CLASSSTRING", "Invalid class string."},
+	CO_E_IIDSTRING:                              {"CO_E_IIDSTRING", "Invalid interface string."},
+	E_BOUNDS:                                    {"E_BOUNDS", "The operation attempted to access data outside the valid range."},
+	E_CHANGED_STATE:                             {"E_CHANGED_STATE", "A concurrent or interleaved operation changed the state of the object, invalidating this operation."},
+	E_ILLEGAL_STATE_CHANGE:                      {"E_ILLEGAL_STATE_CHANGE", "An illegal state change was requested."},
+	E_ILLEGAL_METHOD_CALL:                       {"E_ILLEGAL_METHOD_CALL", "A method was called at an unexpected time."},
+	RO_E_METADATA_NAME_NOT_FOUND:                {"RO_E_METADATA_NAME_NOT_FOUND", "Typename or Namespace was not found in metadata file."},
+	RO_E_METADATA_NAME_IS_NAMESPACE:             {"RO_E_METADATA_NAME_IS_NAMESPACE", "Name is an existing namespace rather than a typename."},
+	RO_E_METADATA_INVALID_TYPE_FORMAT:           {"RO_E_METADATA_INVALID_TYPE_FORMAT", "Typename has an invalid format."},
+	RO_E_INVALID_METADATA_FILE:                  {"RO_E_INVALID_METADATA_FILE", "Metadata file is invalid or corrupted."},
+	RO_E_CLOSED:                                 {"RO_E_CLOSED", "The object has been closed."},
+	RO_E_EXCLUSIVE_WRITE:                        {"RO_E_EXCLUSIVE_WRITE", "Only one thread may access the object during a write operation."},
+	RO_E_CHANGE_NOTIFICATION_IN_PROGRESS:        {"RO_E_CHANGE_NOTIFICATION_IN_PROGRESS", "Operation is prohibited during change notification."},
+	RO_E_ERROR_STRING_NOT_FOUND:                 {"RO_E_ERROR_STRING_NOT_FOUND", "The text associated with this error code could not be found."},
+	E_STRING_NOT_NULL_TERMINATED:                {"E_STRING_NOT_NULL_TERMINATED", "String not null terminated."},
+	E_ILLEGAL_DELEGATE_ASSIGNMENT:               {"E_ILLEGAL_DELEGATE_ASSIGNMENT", "A delegate was assigned when not allowed."},
+	E_ASYNC_OPERATION_NOT_STARTED:               {"E_ASYNC_OPERATION_NOT_STARTED", "An async operation was not properly started."},
+	E_APPLICATION_EXITING:                       {"E_APPLICATION_EXITING", "The application is exiting and cannot service this request."},
+	E_APPLICATION_VIEW_EXITING:                  {"E_APPLICATION_VIEW_EXITING", "The application view is exiting and cannot service this request."},
+	FWP_E_CALLOUT_NOT_FOUND:                     {"FWP_E_CALLOUT_NOT_FOUND", "The callout does not exist."},
+	FWP_E_CONDITION_NOT_FOUND:                   {"FWP_E_CONDITION_NOT_FOUND", "The filter condition does not exist."},
+	FWP_E_FILTER_NOT_FOUND:                      {"FWP_E_FILTER_NOT_FOUND", "The filter does not exist."},
+	FWP_E_LAYER_NOT_FOUND:                       {"FWP_E_LAYER_NOT_FOUND", "The layer does not exist."},
+	FWP_E_PROVIDER_NOT_FOUND:                    {"FWP_E_PROVIDER_NOT_FOUND", "The provider does not exist."},
+	FWP_E_PROVIDER_CONTEXT_NOT_FOUND:            {"FWP_E_PROVIDER_CONTEXT_NOT_FOUND", "The provider context does not exist."},
+	FWP_E_SUBLAYER_NOT_FOUND:                    {"FWP_E_SUBLAYER_NOT_FOUND", "The sublayer does not exist."},
+	FWP_E_NOT_FOUND:                             {"FWP_E_NOT_FOUND", "The object does not exist."},
+	FWP_E_ALREADY_EXISTS:                        {"FWP_E_ALREADY_EXISTS", "An object with that GUID or LUID already exists."},
+	FWP_E_IN_USE:                                {"FWP_E_IN_USE", "The object is referenced by other objects so cannot be deleted."},
+	FWP_E_DYNAMIC_SESSION_IN_PROGRESS:           {"FWP_E_DYNAMIC_SESSION_IN_PROGRESS", "The call is not allowed from within a dynamic session."},
+	FWP_E_WRONG_SESSION:                         {"FWP_E_WRONG_SESSION", "The call was made from the wrong session so cannot be completed."},
+	FWP_E_NO_TXN_IN_PROGRESS:                    {"FWP_E_NO_TXN_IN_PROGRESS", "The call must be made from within an explicit transaction."},
+	FWP_E_TXN_IN_PROGRESS:                       {"FWP_E_TXN_IN_PROGRESS", "The call is not allowed from within an explicit transaction."},
+	FWP_E_TXN_ABORTED:                           {"FWP_E_TXN_ABORTED", "The explicit transaction has been forcibly cancelled."},
+	FWP_E_SESSION_ABORTED:                       {"FWP_E_SESSION_ABORTED", "The session has been cancelled."},
+	FWP_E_INCOMPATIBLE_TXN:                      {"FWP_E_INCOMPATIBLE_TXN", "The call is not allowed from within a read-only transaction."},
+	FWP_E_TIMEOUT:                               {"FWP_E_TIMEOUT", "The call timed out while waiting to acquire the transaction lock."},
+	FWP_E_NET_EVENTS_DISABLED:                   {"FWP_E_NET_EVENTS_DISABLED", "Collection of network diagnostic events is disabled."},
+	FWP_E_INCOMPATIBLE_LAYER:                    {"FWP_E_INCOMPATIBLE_LAYER", "The operation is not supported by the specified layer."},
+	FWP_E_KM_CLIENTS_ONLY:                       {"FWP_E_KM_CLIENTS_ONLY", "The call is allowed for kernel-mode callers only."},
+	FWP_E_LIFETIME_MISMATCH:                     {"FWP_E_LIFETIME_MISMATCH", "The call tried to associate two objects with incompatible lifetimes."},
+	FWP_E_BUILTIN_OBJECT:                        {"FWP_E_BUILTIN_OBJECT", "The object is built in so cannot be deleted."},
+	FWP_E_TOO_MANY_CALLOUTS:                     {"FWP_E_TOO_MANY_CALLOUTS", "The maximum number of callouts has been reached."},
+	FWP_E_NOTIFICATION_DROPPED:                  {"FWP_E_NOTIFICATION_DROPPED", "A notification could not be delivered because a message queue is at its maximum capacity."},
+	FWP_E_TRAFFIC_MISMATCH:                      {"FWP_E_TRAFFIC_MISMATCH", "The traffic parameters do not match those for the security association context."},
+	FWP_E_INCOMPATIBLE_SA_STATE:                 {"FWP_E_INCOMPATIBLE_SA_STATE", "The call is not allowed for the current security association state."},
+	FWP_E_NULL_POINTER:                          {"FWP_E_NULL_POINTER", "A required pointer is null."},
+	FWP_E_INVALID_ENUMERATOR:                    {"FWP_E_INVALID_ENUMERATOR", "An enumerator is not valid."},
+	FWP_E_INVALID_FLAGS:                         {"FWP_E_INVALID_FLAGS", "The flags field contains an invalid value."},
+	FWP_E_INVALID_NET_MASK:                      {"FWP_E_INVALID_NET_MASK", "A network mask is not valid."},
+	FWP_E_INVALID_RANGE:                         {"FWP_E_INVALID_RANGE", "An FWP_RANGE is not valid."},
+	FWP_E_INVALID_INTERVAL:                      {"FWP_E_INVALID_INTERVAL", "The time interval is not valid."},
+	FWP_E_ZERO_LENGTH_ARRAY:                     {"FWP_E_ZERO_LENGTH_ARRAY", "An array that must contain at least one element is zero length."},
+	FWP_E_NULL_DISPLAY_NAME:                     {"FWP_E_NULL_DISPLAY_NAME", "The displayData.name field cannot be null."},
+	FWP_E_INVALID_ACTION_TYPE:                   {"FWP_E_INVALID_ACTION_TYPE", "The action type is not one of the allowed action types for a filter."},
+	FWP_E_INVALID_WEIGHT:                        {"FWP_E_INVALID_WEIGHT", "The filter weight is not valid."},
+	FWP_E_MATCH_TYPE_MISMATCH:                   {"FWP_E_MATCH_TYPE_MISMATCH", "A filter condition contains a match type that is not compatible with the operands."},
+	FWP_E_TYPE_MISMATCH:                         {"FWP_E_TYPE_MISMATCH", "An FWP_VALUE or FWPM_CONDITION_VALUE is of the wrong type."},
+	FWP_E_OUT_OF_BOUNDS:                         {"FWP_E_OUT_OF_BOUNDS", "An integer value is outside the allowed range."},
+	FWP_E_RESERVED:                              {"FWP_E_RESERVED", "A reserved field is non-zero."},
+	FWP_E_DUPLICATE_CONDITION:                   {"FWP_E_DUPLICATE_CONDITION", "A filter cannot contain multiple conditions operating on a single field."},
+	FWP_E_DUPLICATE_KEYMOD:                      {"FWP_E_DUPLICATE_KEYMOD", "A policy cannot contain the same keying module more than once."},
+	FWP_E_ACTION_INCOMPATIBLE_WITH_LAYER:        {"FWP_E_ACTION_INCOMPATIBLE_WITH_LAYER", "The action type is not compatible with the layer."},
+	FWP_E_ACTION_INCOMPATIBLE_WITH_SUBLAYER:     {"FWP_E_ACTION_INCOMPATIBLE_WITH_SUBLAYER", "The action type is not compatible with the sublayer."},
+	FWP_E_CONTEXT_INCOMPATIBLE_WITH_LAYER:       {"FWP_E_CONTEXT_INCOMPATIBLE_WITH_LAYER", "The raw context or the provider context is not compatible with the layer."},
+	FWP_E_CONTEXT_INCOMPATIBLE_WITH_CALLOUT:     {"FWP_E_CONTEXT_INCOMPATIBLE_WITH_CALLOUT", "The raw context or the provider context is not compatible with the callout."},
+	FWP_E_INCOMPATIBLE_AUTH_METHOD:              {"FWP_E_INCOMPATIBLE_AUTH_METHOD", "The authentication method is not compatible with the policy type."},
+	FWP_E_INCOMPATIBLE_DH_GROUP:                 {"FWP_E_INCOMPATIBLE_DH_GROUP", "The Diffie-Hellman group is not compatible with the policy type."},
+	FWP_E_EM_NOT_SUPPORTED:                      {"FWP_E_EM_NOT_SUPPORTED", "An IKE policy cannot contain an Extended Mode policy."},
+	FWP_E_NEVER_MATCH:                           {"FWP_E_NEVER_MATCH", "The enumeration template or subscription will never match any objects."},
+	FWP_E_PROVIDER_CONTEXT_MISMATCH:             {"FWP_E_PROVIDER_CONTEXT_MISMATCH", "The provider context is of the wrong type."},
+	FWP_E_INVALID_PARAMETER:                     {"FWP_E_INVALID_PARAMETER", "The parameter is incorrect."},
+	FWP_E_TOO_MANY_SUBLAYERS:                    {"FWP_E_TOO_MANY_SUBLAYERS", "The maximum number of sublayers has been reached."},
+	FWP_E_CALLOUT_NOTIFICATION_FAILED:           {"FWP_E_CALLOUT_NOTIFICATION_FAILED", "The notification function for a callout returned an error."},
+	FWP_E_INVALID_AUTH_TRANSFORM:                {"FWP_E_INVALID_AUTH_TRANSFORM", "The IPsec authentication transform is not valid."},
+	FWP_E_INVALID_CIPHER_TRANSFORM:              {"FWP_E_INVALID_CIPHER_TRANSFORM", "The IPsec cipher transform is not valid."},
+	FWP_E_INCOMPATIBLE_CIPHER_TRANSFORM:         {"FWP_E_INCOMPATIBLE_CIPHER_TRANSFORM", "The IPsec cipher transform is not compatible with the policy."},
+	FWP_E_INVALID_TRANSFORM_COMBINATION:         {"FWP_E_INVALID_TRANSFORM_COMBINATION", "The combination of IPsec transform types is not valid."},
+	FWP_E_DUPLICATE_AUTH_METHOD:                 {"FWP_E_DUPLICATE_AUTH_METHOD", "A policy cannot contain the same auth method more than once."},
+	FWP_E_INVALID_TUNNEL_ENDPOINT:               {"FWP_E_INVALID_TUNNEL_ENDPOINT", "A tunnel endpoint configuration is invalid."},
+	FWP_E_L2_DRIVER_NOT_READY:                   {"FWP_E_L2_DRIVER_NOT_READY", "The WFP MAC Layers are not ready."},
+	FWP_E_KEY_DICTATOR_ALREADY_REGISTERED:       {"FWP_E_KEY_DICTATOR_ALREADY_REGISTERED", "A key manager capable of key dictation is already registered."},
+	FWP_E_KEY_DICTATION_INVALID_KEYING_MATERIAL: {"FWP_E_KEY_DICTATION_INVALID_KEYING_MATERIAL", "A key manager dictated invalid keys."},
+	FWP_E_CONNECTIONS_DISABLED:                  {"FWP_E_CONNECTIONS_DISABLED", "The BFE IPsec Connection Tracking is disabled."},
+	FWP_E_INVALID_DNS_NAME:                      {"FWP_E_INVALID_DNS_NAME", "The DNS name is invalid."},
+	FWP_E_STILL_ON:                              {"FWP_E_STILL_ON", "The engine option is still enabled due to other configuration settings."},
+	FWP_E_IKEEXT_NOT_RUNNING:                    {"FWP_E_IKEEXT_NOT_RUNNING", "The IKEEXT service is not running."},
+	FWP_E_DROP_NOICMP:                           {"FWP_E_DROP_NOICMP", "The packet should be dropped, no ICMP should be sent."},
+}
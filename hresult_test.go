@@ -0,0 +1,130 @@
+package gowindows
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"syscall"
+	"testing"
+)
+
+func TestHRESULTDecode(t *testing.T) {
+	data := []struct {
+		hr       HRESULT
+		severity uint32
+		facility uint32
+		code     uint32
+	}{
+		{S_OK, SEVERITY_SUCCESS, FACILITY_NULL, 0},
+		{S_FALSE, SEVERITY_SUCCESS, FACILITY_NULL, 1},
+		{E_FAIL, SEVERITY_ERROR, FACILITY_NULL, 0x4005},
+		{E_ACCESSDENIED, SEVERITY_ERROR, FACILITY_WIN32, 5},
+		{REGDB_E_CLASSNOTREG, SEVERITY_ERROR, FACILITY_ITF, 0x154},
+		{RPC_E_CHANGED_MODE, SEVERITY_ERROR, FACILITY_RPC, 0x106},
+		{FWP_E_ALREADY_EXISTS, SEVERITY_ERROR, FACILITY_FWP, 9},
+		{HRESULTFromNT(STATUS_ACCESS_DENIED), SEVERITY_ERROR, 0, 0x22},
+	}
+	for _, v := range data {
+		if v.hr.Severity() != v.severity || v.hr.Facility() != v.facility || v.hr.Code() != v.code {
+			t.Errorf("%v: %v,%v,0x%X!=%v,%v,0x%X", v.hr.String(), v.hr.Severity(), v.hr.Facility(), v.hr.Code(),
+				v.severity, v.facility, v.code)
+		}
+		if v.hr.IsFailed() != (v.severity == SEVERITY_ERROR) {
+			t.Errorf("%v: IsFailed %v", v.hr.String(), v.hr.IsFailed())
+		}
+	}
+}
+
+func TestHRESULTFrom(t *testing.T) {
+	if hr := HRESULTFromWin32(5); hr != E_ACCESSDENIED {
+		t.Errorf("0x%08X!=0x%08X", uint32(hr), uint32(E_ACCESSDENIED))
+	}
+	if hr := HRESULTFromWin32(ERROR_SUCCESS); hr != S_OK {
+		t.Errorf("0x%08X!=S_OK", uint32(hr))
+	}
+	if e, ok := HRESULTFromWin32(32).Win32Error(); ok == false || e != 32 {
+		t.Errorf("%d,%v!=32,true", e, ok)
+	}
+	if _, ok := E_FAIL.Win32Error(); ok {
+		t.Errorf("E_FAIL is not the win32 error")
+	}
+
+	hr := HRESULTFromNT(STATUS_INFO_LENGTH_MISMATCH)
+	if uint32(hr) != 0xD0000004 {
+		t.Errorf("0x%08X!=0xD0000004", uint32(hr))
+	}
+	if s, ok := hr.NTStatus(); ok == false || s != STATUS_INFO_LENGTH_MISMATCH {
+		t.Errorf("%v,%v!=STATUS_INFO_LENGTH_MISMATCH,true", s, ok)
+	}
+	if _, ok := E_ACCESSDENIED.NTStatus(); ok {
+		t.Errorf("E_ACCESSDENIED is not the NTSTATUS")
+	}
+}
+
+func TestHRESULTString(t *testing.T) {
+	data := []struct {
+		hr   HRESULT
+		want string
+	}{
+		{S_OK, "S_OK"},
+		{E_ACCESSDENIED, "E_ACCESSDENIED"},
+		{CO_E_CLASSSTRING, "CO_E_CLASSSTRING"},
+		{RO_E_CLOSED, "RO_E_CLOSED"},
+		{FWP_E_FILTER_NOT_FOUND, "FWP_E_FILTER_NOT_FOUND"},
+		{HRESULTFromWin32(32), "HRESULT_FROM_WIN32(32)"},
+		{HRESULTFromNT(STATUS_ACCESS_DENIED), "HRESULT_FROM_NT(STATUS_ACCESS_DENIED)"},
+		{0x80041234, "HRESULT(0x80041234)"},
+	}
+	for _, v := range data {
+		if s := v.hr.String(); s != v.want {
+			t.Errorf("%v!=%v", s, v.want)
+		}
+	}
+
+	if s := FWP_E_ALREADY_EXISTS.Error(); s != "FWP_E_ALREADY_EXISTS (0x80320009): "+FWP_E_ALREADY_EXISTS.Message() {
+		t.Errorf("%v", s)
+	}
+	if s := HRESULTFromNT(STATUS_ACCESS_DENIED).Error(); strings.HasSuffix(s, STATUS_ACCESS_DENIED.Message()) == false {
+		t.Errorf("%v", s)
+	}
+	if s := HRESULTFromWin32(32).Error(); s != "HRESULT_FROM_WIN32(32) (0x80070020)" {
+		t.Errorf("%v", s)
+	}
+	if s := HRESULT(0x8032FFFF).Error(); s != "HRESULT 0x8032FFFF (facility FACILITY_FWP, code 0xFFFF)" {
+		t.Errorf("%v", s)
+	}
+	if s := HRESULT(0x87FF0001).Error(); s != "HRESULT 0x87FF0001 (facility 0x7FF, code 0x1)" {
+		t.Errorf("%v", s)
+	}
+}
+
+func TestHRESULTIs(t *testing.T) {
+	err := fmt.Errorf("FwpmFilterAdd0, %w", FWP_E_ALREADY_EXISTS)
+	data := []struct {
+		err    error
+		target error
+		want   bool
+	}{
+		{err, FWP_E_ALREADY_EXISTS, true},
+		{err, FWP_E_NOT_FOUND, false},
+		{E_ACCESSDENIED, syscall.Errno(5), true},
+		{E_ACCESSDENIED, os.ErrPermission, true},
+		{HRESULTFromWin32(2), os.ErrNotExist, true},
+		{E_FAIL, syscall.Errno(0x4005), false},
+		{HRESULTFromNT(STATUS_ACCESS_DENIED), STATUS_ACCESS_DENIED, true},
+		{HRESULTFromNT(STATUS_ACCESS_DENIED), syscall.Errno(5), true},
+		{HRESULTFromNT(STATUS_ACCESS_DENIED), STATUS_INVALID_HANDLE, false},
+		{S_OK, ERROR_SUCCESS, false},
+	}
+	for i, v := range data {
+		if r := errors.Is(v.err, v.target); r != v.want {
+			t.Errorf("%v: errors.Is(%v, %v) %v!=%v", i, v.err, v.target, r, v.want)
+		}
+	}
+
+	var hr HRESULT
+	if errors.As(err, &hr) == false || hr != FWP_E_ALREADY_EXISTS {
+		t.Errorf("%v!=FWP_E_ALREADY_EXISTS", hr.String())
+	}
+}
@@ -73,53 +73,14 @@ func TestAdapterAddresses(t *testing.T) {
 	}
 }
 
+// The structures of the package are asserted by layout.txt, these are the types of golang.org/x/sys and the aliases.
 func TestStruct(t *testing.T) {
 	if ptrSize == 8 {
 		t.Log("64")
 
-		if unsafe.Sizeof(IpAdapterAddresses{}) != 448 {
-			t.Errorf("IpAdapterAddresses %v!=448", unsafe.Sizeof(IpAdapterAddresses{}))
-		}
-
-		ipAdapterAddresses := IpAdapterAddresses{}
-
-		if o := unsafe.Offsetof(ipAdapterAddresses.Length); o != 0 {
-			t.Errorf("%v !=0", o)
-		}
-		if o := unsafe.Offsetof(ipAdapterAddresses.ipv6IfIndex); o != 108 {
-			t.Errorf("%v !=108", o)
-		}
-		if o := unsafe.Offsetof(ipAdapterAddresses.luid); o != 224 {
-			t.Errorf("%v !=224", o)
-		}
-		if o := unsafe.Offsetof(ipAdapterAddresses.dhcpv4Server); o != 232 {
-			t.Errorf("%v !=232", o)
-		}
-		if o := unsafe.Offsetof(ipAdapterAddresses.compartmentId); o != 248 {
-			t.Errorf("%v !=248", o)
-		}
-		if o := unsafe.Offsetof(ipAdapterAddresses.networkGuid); o != 252 {
-			t.Errorf("%v !=252", o)
-		}
-		if o := unsafe.Offsetof(ipAdapterAddresses.connectionType); o != 268 {
-			t.Errorf("%v !=268", o)
-		}
-		if o := unsafe.Offsetof(ipAdapterAddresses.dhcpv6Server); o != 280 {
-			t.Errorf("%v !=280", o)
-		}
-		if o := unsafe.Offsetof(ipAdapterAddresses.firstDnsSuffix); o != 440 {
-			t.Errorf("%v !=440", o)
-		}
-
 		if unsafe.Sizeof(IfLuid(0)) != 8 {
 			t.Errorf("IfLuid %v!=8", unsafe.Sizeof(IfLuid(0)))
 		}
-		if unsafe.Sizeof(IpAdapterWinsServerAddress{}) != 32 {
-			t.Errorf("IpAdapterWinsServerAddress %v!=32", unsafe.Sizeof(IpAdapterWinsServerAddress{}))
-		}
-		if unsafe.Sizeof(IpAdapterGatewayAddress{}) != 32 {
-			t.Errorf("IpAdapterGatewayAddress %v!=32", unsafe.Sizeof(IpAdapterGatewayAddress{}))
-		}
 		if unsafe.Sizeof(windows.IpAdapterUnicastAddress{}) != 64 {
 			t.Errorf("windows.IpAdapterUnicastAddress %v!=64", unsafe.Sizeof(windows.IpAdapterUnicastAddress{}))
 		}
@@ -138,107 +99,15 @@ func TestStruct(t *testing.T) {
 		if unsafe.Sizeof(windows.SocketAddress{}) != 16 {
 			t.Errorf("windows.SocketAddress %v!=16", unsafe.Sizeof(windows.SocketAddress{}))
 		}
-		if unsafe.Sizeof(CompartmentId(0)) != 4 {
-			t.Errorf("CompartmentId %v!=4", unsafe.Sizeof(CompartmentId(0)))
-		}
-		if unsafe.Sizeof(NetworkGuid{}) != 16 {
-			t.Errorf("NetworkGuid %v!=16", unsafe.Sizeof(NetworkGuid{}))
-		}
-		if unsafe.Sizeof(ConnectionType(0)) != 4 {
-			t.Errorf("ConnectionType %v!=4", unsafe.Sizeof(ConnectionType(0)))
-		}
-		if unsafe.Sizeof(TunnelType(0)) != 4 {
-			t.Errorf("TunnelType %v!=8", unsafe.Sizeof(TunnelType(0)))
-		}
-		if unsafe.Sizeof(IpAdapterDnsSuffix{}) != 520 {
-			t.Errorf("IpAdapterDnsSuffix %v!=516", unsafe.Sizeof(IpAdapterDnsSuffix{}))
-		}
-
-		if unsafe.Sizeof(MibIpAddrTable{}) != 28 {
-			t.Errorf("MibIpAddrTable %v!=28", unsafe.Sizeof(MibIpAddrTable{}))
-		}
-		mibIpAddrTable := MibIpAddrTable{}
-		if o := unsafe.Offsetof(mibIpAddrTable.Table); o != 4 {
-			t.Errorf("%v !=4", o)
-		}
-
-		if unsafe.Sizeof(MibIpAddrRowW2k{}) != 24 {
-			t.Errorf("MibIpAddrRowW2k %v!=24", unsafe.Sizeof(MibIpAddrRowW2k{}))
-		}
-		mibIpAddrRowW2k := MibIpAddrRowW2k{}
-		if o := unsafe.Offsetof(mibIpAddrRowW2k.Mask); o != 8 {
-			t.Errorf("%v !=8", o)
-		}
-		if o := unsafe.Offsetof(mibIpAddrRowW2k.Unused2); o != 22 {
-			t.Errorf("%v !=22", o)
-		}
-
 	} else {
 		t.Log("32")
 
-		if unsafe.Sizeof(IpAdapterAddresses{}) != 376 {
-			t.Errorf("IpAdapterAddresses %v!=376", unsafe.Sizeof(IpAdapterAddresses{}))
-		}
-
-		ipAdapterAddresses := IpAdapterAddresses{}
-
-		if o := unsafe.Offsetof(ipAdapterAddresses.Length); o != 0 {
-			t.Errorf("%v !=0", o)
-		}
-		if o := unsafe.Offsetof(ipAdapterAddresses.ipv6IfIndex); o != 72 {
-			t.Errorf("%v !=72", o)
-		}
-		if o := unsafe.Offsetof(ipAdapterAddresses.luid); o != 176 {
-			t.Errorf("%v !=176", o)
-		}
-		if o := unsafe.Offsetof(ipAdapterAddresses.dhcpv4Server); o != 184 {
-			t.Errorf("%v !=184", o)
-		}
-		if o := unsafe.Offsetof(ipAdapterAddresses.compartmentId); o != 192 {
-			t.Errorf("%v !=192", o)
-		}
-		if o := unsafe.Offsetof(ipAdapterAddresses.networkGuid); o != 196 {
-			t.Errorf("%v !=196", o)
-		}
-		if o := unsafe.Offsetof(ipAdapterAddresses.connectionType); o != 212 {
-			t.Errorf("%v !=212", o)
-		}
-		if o := unsafe.Offsetof(ipAdapterAddresses.dhcpv6Server); o != 220 {
-			t.Errorf("%v !=220", o)
-		}
-		if o := unsafe.Offsetof(ipAdapterAddresses.firstDnsSuffix); o != 368 {
-			t.Errorf("%v !=368", o)
-		}
-
 		if unsafe.Sizeof(IfLuid(0)) != 8 {
 			t.Errorf("IfLuid %v!=8", unsafe.Sizeof(IfLuid(0)))
 		}
-
 		if unsafe.Sizeof(windows.SocketAddress{}) != 8 {
 			t.Errorf("windows.SocketAddress %v!=8", unsafe.Sizeof(windows.SocketAddress{}))
 		}
-
-		if s := unsafe.Sizeof(IpAdapterWinsServerAddress{}); s != 24 {
-			t.Errorf("IpAdapterWinsServerAddress %v!=24", s)
-		}
-
-		ipAdapterWinsServerAddress := IpAdapterWinsServerAddress{}
-		if o := unsafe.Offsetof(ipAdapterWinsServerAddress.Length); o != 0 {
-			t.Errorf("%v !=0", o)
-		}
-		if o := unsafe.Offsetof(ipAdapterWinsServerAddress.Reserved); o != 4 {
-			t.Errorf("%v !=4", o)
-		}
-		if o := unsafe.Offsetof(ipAdapterWinsServerAddress.Next); o != 8 {
-			t.Errorf("%v !=8", o)
-		}
-		if o := unsafe.Offsetof(ipAdapterWinsServerAddress.Address); o != 12 {
-			t.Errorf("%v !=12", o)
-		}
-
-		if unsafe.Sizeof(IpAdapterGatewayAddress{}) != 24 {
-			t.Errorf("IpAdapterGatewayAddress %v!=24", unsafe.Sizeof(IpAdapterGatewayAddress{}))
-		}
 		if unsafe.Sizeof(windows.IpAdapterUnicastAddress{}) != 48 {
 			t.Errorf("windows.IpAdapterUnicastAddress %v!=48", unsafe.Sizeof(windows.IpAdapterUnicastAddress{}))
 		}
@@ -254,40 +123,19 @@ func TestStruct(t *testing.T) {
 		if unsafe.Sizeof(windows.IpAdapterPrefix{}) != 24 {
 			t.Errorf("windows.IpAdapterPrefix %v!=24", unsafe.Sizeof(windows.IpAdapterPrefix{}))
 		}
-		if unsafe.Sizeof(CompartmentId(0)) != 4 {
-			t.Errorf("CompartmentId %v!=4", unsafe.Sizeof(CompartmentId(0)))
-		}
-		if unsafe.Sizeof(NetworkGuid{}) != 16 {
-			t.Errorf("NetworkGuid %v!=16", unsafe.Sizeof(NetworkGuid{}))
-		}
-		if unsafe.Sizeof(ConnectionType(0)) != 4 {
-			t.Errorf("ConnectionType %v!=4", unsafe.Sizeof(ConnectionType(0)))
-		}
-		if unsafe.Sizeof(TunnelType(0)) != 4 {
-			t.Errorf("TunnelType %v!=8", unsafe.Sizeof(TunnelType(0)))
-		}
-		if unsafe.Sizeof(IpAdapterDnsSuffix{}) != 516 {
-			t.Errorf("IpAdapterDnsSuffix %v!=516", unsafe.Sizeof(IpAdapterDnsSuffix{}))
-		}
-
-		if unsafe.Sizeof(MibIpAddrTable{}) != 28 {
-			t.Errorf("MibIpAddrTable %v!=28", unsafe.Sizeof(MibIpAddrTable{}))
-		}
-		mibIpAddrTable := MibIpAddrTable{}
-		if o := unsafe.Offsetof(mibIpAddrTable.Table); o != 4 {
-			t.Errorf("%v !=4", o)
-		}
+	}
 
-		if unsafe.Sizeof(MibIpAddrRowW2k{}) != 24 {
-			t.Errorf("MibIpAddrRowW2k %v!=24", unsafe.Sizeof(MibIpAddrRowW2k{}))
-		}
-		mibIpAddrRowW2k := MibIpAddrRowW2k{}
-		if o := unsafe.Offsetof(mibIpAddrRowW2k.Mask); o != 8 {
-			t.Errorf("%v !=8", o)
-		}
-		if o := unsafe.Offsetof(mibIpAddrRowW2k.Unused2); o != 22 {
-			t.Errorf("%v !=22", o)
-		}
+	if unsafe.Sizeof(CompartmentId(0)) != 4 {
+		t.Errorf("CompartmentId %v!=4", unsafe.Sizeof(CompartmentId(0)))
+	}
+	if unsafe.Sizeof(NetworkGuid{}) != 16 {
+		t.Errorf("NetworkGuid %v!=16", unsafe.Sizeof(NetworkGuid{}))
+	}
+	if unsafe.Sizeof(ConnectionType(0)) != 4 {
+		t.Errorf("ConnectionType %v!=4", unsafe.Sizeof(ConnectionType(0)))
+	}
+	if unsafe.Sizeof(TunnelType(0)) != 4 {
+		t.Errorf("TunnelType %v!=4", unsafe.Sizeof(TunnelType(0)))
 	}
 }
 
@@ -0,0 +1,321 @@
+package gowindows
+
+import (
+	"fmt"
+	"time"
+)
+
+// https://docs.microsoft.com/en-us/windows/win32/api/winnt/ns-winnt-jobobject_basic_limit_information
+const (
+	JOB_OBJECT_LIMIT_WORKINGSET                 = 0x00000001
+	JOB_OBJECT_LIMIT_PROCESS_TIME               = 0x00000002
+	JOB_OBJECT_LIMIT_JOB_TIME                   = 0x00000004
+	JOB_OBJECT_LIMIT_ACTIVE_PROCESS             = 0x00000008
+	JOB_OBJECT_LIMIT_AFFINITY                   = 0x00000010
+	JOB_OBJECT_LIMIT_PRIORITY_CLASS             = 0x00000020
+	JOB_OBJECT_LIMIT_PRESERVE_JOB_TIME          = 0x00000040
+	JOB_OBJECT_LIMIT_SCHEDULING_CLASS           = 0x00000080
+	JOB_OBJECT_LIMIT_PROCESS_MEMORY             = 0x00000100
+	JOB_OBJECT_LIMIT_JOB_MEMORY                 = 0x00000200
+	JOB_OBJECT_LIMIT_DIE_ON_UNHANDLED_EXCEPTION = 0x00000400
+	JOB_OBJECT_LIMIT_BREAKAWAY_OK               = 0x00000800
+	JOB_OBJECT_LIMIT_SILENT_BREAKAWAY_OK        = 0x00001000
+	JOB_OBJECT_LIMIT_KILL_ON_JOB_CLOSE          = 0x00002000
+	JOB_OBJECT_LIMIT_SUBSET_AFFINITY            = 0x00004000
+)
+
+// https://docs.microsoft.com/en-us/windows/win32/api/winnt/ns-winnt-jobobject_cpu_rate_control_information
+const (
+	JOB_OBJECT_CPU_RATE_CONTROL_ENABLE       = 0x1
+	JOB_OBJECT_CPU_RATE_CONTROL_WEIGHT_BASED = 0x2
+	JOB_OBJECT_CPU_RATE_CONTROL_HARD_CAP     = 0x4
+	JOB_OBJECT_CPU_RATE_CONTROL_NOTIFY       = 0x8
+	JOB_OBJECT_CPU_RATE_CONTROL_MIN_MAX_RATE = 0x10
+)
+
+// JOBOBJECTINFOCLASS
+const (
+	JobObjectBasicAccountingInformation         = 1
+	JobObjectBasicLimitInformation              = 2
+	JobObjectBasicProcessIdList                 = 3
+	JobObjectAssociateCompletionPortInformation = 7
+	JobObjectBasicAndIoAccountingInformation    = 8
+	JobObjectExtendedLimitInformation           = 9
+	JobObjectCpuRateControlInformation          = 15
+)
+
+// The message of the completion port associated with the job
+// https://docs.microsoft.com/en-us/windows/win32/api/winnt/ns-winnt-jobobject_associate_completion_port
+type JobEventType uint32
+
+const (
+	JOB_OBJECT_MSG_END_OF_JOB_TIME       JobEventType = 1
+	JOB_OBJECT_MSG_END_OF_PROCESS_TIME   JobEventType = 2
+	JOB_OBJECT_MSG_ACTIVE_PROCESS_LIMIT  JobEventType = 3
+	JOB_OBJECT_MSG_ACTIVE_PROCESS_ZERO   JobEventType = 4
+	JOB_OBJECT_MSG_NEW_PROCESS           JobEventType = 6
+	JOB_OBJECT_MSG_EXIT_PROCESS          JobEventType = 7
+	JOB_OBJECT_MSG_ABNORMAL_EXIT_PROCESS JobEventType = 8
+	JOB_OBJECT_MSG_PROCESS_MEMORY_LIMIT  JobEventType = 9
+	JOB_OBJECT_MSG_JOB_MEMORY_LIMIT      JobEventType = 10
+	JOB_OBJECT_MSG_NOTIFICATION_LIMIT    JobEventType = 11
+	JOB_OBJECT_MSG_JOB_CYCLE_TIME_LIMIT  JobEventType = 12
+	JOB_OBJECT_MSG_SILO_TERMINATED       JobEventType = 13
+)
+
+var jobEventTypeNames = map[JobEventType]string{
+	JOB_OBJECT_MSG_END_OF_JOB_TIME:       "END_OF_JOB_TIME",
+	JOB_OBJECT_MSG_END_OF_PROCESS_TIME:   "END_OF_PROCESS_TIME",
+	JOB_OBJECT_MSG_ACTIVE_PROCESS_LIMIT:  "ACTIVE_PROCESS_LIMIT",
+	JOB_OBJECT_MSG_ACTIVE_PROCESS_ZERO:   "ACTIVE_PROCESS_ZERO",
+	JOB_OBJECT_MSG_NEW_PROCESS:           "NEW_PROCESS",
+	JOB_OBJECT_MSG_EXIT_PROCESS:          "EXIT_PROCESS",
+	JOB_OBJECT_MSG_ABNORMAL_EXIT_PROCESS: "ABNORMAL_EXIT_PROCESS",
+	JOB_OBJECT_MSG_PROCESS_MEMORY_LIMIT:  "PROCESS_MEMORY_LIMIT",
+	JOB_OBJECT_MSG_JOB_MEMORY_LIMIT:      "JOB_MEMORY_LIMIT",
+	JOB_OBJECT_MSG_NOTIFICATION_LIMIT:    "NOTIFICATION_LIMIT",
+	JOB_OBJECT_MSG_JOB_CYCLE_TIME_LIMIT:  "JOB_CYCLE_TIME_LIMIT",
+	JOB_OBJECT_MSG_SILO_TERMINATED:       "SILO_TERMINATED",
+}
+
+func (t JobEventType) String() string {
+	if name, ok := jobEventTypeNames[t]; ok {
+		return name
+	}
+	return fmt.Sprintf("JobEventType(%d)", uint32(t))
+}
+
+// Whether the message carries the process id
+func (t JobEventType) hasProcessId() bool {
+	switch t {
+	case JOB_OBJECT_MSG_END_OF_PROCESS_TIME, JOB_OBJECT_MSG_NEW_PROCESS, JOB_OBJECT_MSG_EXIT_PROCESS,
+		JOB_OBJECT_MSG_ABNORMAL_EXIT_PROCESS, JOB_OBJECT_MSG_PROCESS_MEMORY_LIMIT:
+		return true
+	}
+	return false
+}
+
+// The notification of the job
+// ProcessId is 0 if the message is about the whole job, such as JOB_OBJECT_MSG_ACTIVE_PROCESS_ZERO.
+type JobEvent struct {
+	Type      JobEventType
+	ProcessId uint32
+}
+
+func newJobEvent(message uint32, overlapped uintptr) JobEvent {
+	e := JobEvent{Type: JobEventType(message)}
+	if e.Type.hasProcessId() {
+		e.ProcessId = uint32(overlapped)
+	}
+	return e
+}
+
+// typedef struct _IO_COUNTERS {
+// ULONGLONG ReadOperationCount;
+// ULONGLONG WriteOperationCount;
+// ULONGLONG OtherOperationCount;
+// ULONGLONG ReadTransferCount;
+// ULONGLONG WriteTransferCount;
+// ULONGLONG OtherTransferCount;
+// } IO_COUNTERS;
+type IO_COUNTERS struct {
+	ReadOperationCount  uint64
+	WriteOperationCount uint64
+	OtherOperationCount uint64
+	ReadTransferCount   uint64
+	WriteTransferCount  uint64
+	OtherTransferCount  uint64
+}
+
+// typedef struct _JOBOBJECT_EXTENDED_LIMIT_INFORMATION {
+// JOBOBJECT_BASIC_LIMIT_INFORMATION BasicLimitInformation;
+// IO_COUNTERS                       IoInfo;
+// SIZE_T                            ProcessMemoryLimit;
+// SIZE_T                            JobMemoryLimit;
+// SIZE_T                            PeakProcessMemoryUsed;
+// SIZE_T                            PeakJobMemoryUsed;
+// } JOBOBJECT_EXTENDED_LIMIT_INFORMATION, *PJOBOBJECT_EXTENDED_LIMIT_INFORMATION;
+type JOBOBJECT_EXTENDED_LIMIT_INFORMATION struct {
+	BasicLimitInformation JOBOBJECT_BASIC_LIMIT_INFORMATION
+	IoInfo                IO_COUNTERS
+	ProcessMemoryLimit    uintptr
+	JobMemoryLimit        uintptr
+	PeakProcessMemoryUsed uintptr
+	PeakJobMemoryUsed     uintptr
+}
+
+// typedef struct _JOBOBJECT_CPU_RATE_CONTROL_INFORMATION {
+// DWORD ControlFlags;
+// union {
+// DWORD CpuRate;
+// DWORD Weight;
+// struct {
+// WORD MinRate;
+// WORD MaxRate;
+// } DUMMYSTRUCTNAME;
+// } DUMMYUNIONNAME;
+// } JOBOBJECT_CPU_RATE_CONTROL_INFORMATION, *PJOBOBJECT_CPU_RATE_CONTROL_INFORMATION;
+type JOBOBJECT_CPU_RATE_CONTROL_INFORMATION struct {
+	ControlFlags uint32
+	CpuRate      uint32 // The cycles of 10000, or the Weight, or MinRate and MaxRate
+}
+
+// typedef struct _JOBOBJECT_BASIC_ACCOUNTING_INFORMATION {
+// LARGE_INTEGER TotalUserTime;
+// LARGE_INTEGER TotalKernelTime;
+// LARGE_INTEGER ThisPeriodTotalUserTime;
+// LARGE_INTEGER ThisPeriodTotalKernelTime;
+// DWORD         TotalPageFaultCount;
+// DWORD         TotalProcesses;
+// DWORD         ActiveProcesses;
+// DWORD         TotalTerminatedProcesses;
+// } JOBOBJECT_BASIC_ACCOUNTING_INFORMATION, *PJOBOBJECT_BASIC_ACCOUNTING_INFORMATION;
+type JOBOBJECT_BASIC_ACCOUNTING_INFORMATION struct {
+	TotalUserTime             int64 // 100 nanoseconds
+	TotalKernelTime           int64
+	ThisPeriodTotalUserTime   int64
+	ThisPeriodTotalKernelTime int64
+	TotalPageFaultCount       uint32
+	TotalProcesses            uint32
+	ActiveProcesses           uint32
+	TotalTerminatedProcesses  uint32
+}
+
+// typedef struct JOBOBJECT_BASIC_AND_IO_ACCOUNTING_INFORMATION {
+// JOBOBJECT_BASIC_ACCOUNTING_INFORMATION BasicInfo;
+// IO_COUNTERS                            IoInfo;
+// } JOBOBJECT_BASIC_AND_IO_ACCOUNTING_INFORMATION, *PJOBOBJECT_BASIC_AND_IO_ACCOUNTING_INFORMATION;
+type JOBOBJECT_BASIC_AND_IO_ACCOUNTING_INFORMATION struct {
+	BasicInfo JOBOBJECT_BASIC_ACCOUNTING_INFORMATION
+	IoInfo    IO_COUNTERS
+}
+
+// typedef struct _JOBOBJECT_ASSOCIATE_COMPLETION_PORT {
+// PVOID  CompletionKey;
+// HANDLE CompletionPort;
+// } JOBOBJECT_ASSOCIATE_COMPLETION_PORT, *PJOBOBJECT_ASSOCIATE_COMPLETION_PORT;
+type JOBOBJECT_ASSOCIATE_COMPLETION_PORT struct {
+	CompletionKey  uintptr
+	CompletionPort Handle
+}
+
+// The limits of the job, the zero values are no limit.
+type JobLimits struct {
+	// Terminate all the processes when the last handle of the job is closed, including the crash of the owner.
+	KillOnClose bool
+	// The committed memory of each process and the whole job in bytes
+	ProcessMemory uint64
+	JobMemory     uint64
+	// The number of the active processes, new processes fail to start beyond it.
+	ActiveProcesses uint32
+	// The CPU rate in percent of all the processors (0.01 to 100), it is a hard cap.
+	CPURate float64
+	// The processes created with CREATE_BREAKAWAY_FROM_JOB are not in the job.
+	Breakaway bool
+	// The child processes are never in the job.
+	SilentBreakaway bool
+	// The processes terminate on the unhandled exceptions without the error dialog.
+	DieOnUnhandledException bool
+}
+
+// Build JOBOBJECT_EXTENDED_LIMIT_INFORMATION and JOBOBJECT_CPU_RATE_CONTROL_INFORMATION
+func (l *JobLimits) information() (JOBOBJECT_EXTENDED_LIMIT_INFORMATION, JOBOBJECT_CPU_RATE_CONTROL_INFORMATION, error) {
+	info := JOBOBJECT_EXTENDED_LIMIT_INFORMATION{}
+	cpu := JOBOBJECT_CPU_RATE_CONTROL_INFORMATION{}
+	maxSize := uint64(^uintptr(0))
+	if l.ProcessMemory > maxSize || l.JobMemory > maxSize {
+		return info, cpu, fmt.Errorf("the memory limit is out of the address space")
+	}
+	if l.CPURate < 0 || l.CPURate > 100 {
+		return info, cpu, fmt.Errorf("invalid CPU rate %v", l.CPURate)
+	}
+
+	flags := uint32(0)
+	if l.KillOnClose {
+		flags |= JOB_OBJECT_LIMIT_KILL_ON_JOB_CLOSE
+	}
+	if l.ProcessMemory != 0 {
+		flags |= JOB_OBJECT_LIMIT_PROCESS_MEMORY
+		info.ProcessMemoryLimit = uintptr(l.ProcessMemory)
+	}
+	if l.JobMemory != 0 {
+		flags |= JOB_OBJECT_LIMIT_JOB_MEMORY
+		info.JobMemoryLimit = uintptr(l.JobMemory)
+	}
+	if l.ActiveProcesses != 0 {
+		flags |= JOB_OBJECT_LIMIT_ACTIVE_PROCESS
+		info.BasicLimitInformation.ActiveProcessLimit = l.ActiveProcesses
+	}
+	if l.Breakaway {
+		flags |= JOB_OBJECT_LIMIT_BREAKAWAY_OK
+	}
+	if l.SilentBreakaway {
+		flags |= JOB_OBJECT_LIMIT_SILENT_BREAKAWAY_OK
+	}
+	if l.DieOnUnhandledException {
+		flags |= JOB_OBJECT_LIMIT_DIE_ON_UNHANDLED_EXCEPTION
+	}
+	info.BasicLimitInformation.LimitFlags = flags
+
+	if l.CPURate != 0 {
+		cpu.ControlFlags = JOB_OBJECT_CPU_RATE_CONTROL_ENABLE | JOB_OBJECT_CPU_RATE_CONTROL_HARD_CAP
+		cpu.CpuRate = uint32(l.CPURate*100 + 0.5)
+		if cpu.CpuRate == 0 {
+			cpu.CpuRate = 1
+		}
+	}
+	return info, cpu, nil
+}
+
+// The limits of the information, the other limits are ignored.
+func newJobLimits(info *JOBOBJECT_EXTENDED_LIMIT_INFORMATION, cpu *JOBOBJECT_CPU_RATE_CONTROL_INFORMATION) JobLimits {
+	flags := info.BasicLimitInformation.LimitFlags
+	l := JobLimits{
+		KillOnClose:             flags&JOB_OBJECT_LIMIT_KILL_ON_JOB_CLOSE != 0,
+		Breakaway:               flags&JOB_OBJECT_LIMIT_BREAKAWAY_OK != 0,
+		SilentBreakaway:         flags&JOB_OBJECT_LIMIT_SILENT_BREAKAWAY_OK != 0,
+		DieOnUnhandledException: flags&JOB_OBJECT_LIMIT_DIE_ON_UNHANDLED_EXCEPTION != 0,
+	}
+	if flags&JOB_OBJECT_LIMIT_PROCESS_MEMORY != 0 {
+		l.ProcessMemory = uint64(info.ProcessMemoryLimit)
+	}
+	if flags&JOB_OBJECT_LIMIT_JOB_MEMORY != 0 {
+		l.JobMemory = uint64(info.JobMemoryLimit)
+	}
+	if flags&JOB_OBJECT_LIMIT_ACTIVE_PROCESS != 0 {
+		l.ActiveProcesses = info.BasicLimitInformation.ActiveProcessLimit
+	}
+	if cpu != nil && cpu.ControlFlags&JOB_OBJECT_CPU_RATE_CONTROL_ENABLE != 0 &&
+		cpu.ControlFlags&(JOB_OBJECT_CPU_RATE_CONTROL_WEIGHT_BASED|JOB_OBJECT_CPU_RATE_CONTROL_MIN_MAX_RATE) == 0 {
+		l.CPURate = float64(cpu.CpuRate) / 100
+	}
+	return l
+}
+
+// The accounting of the job, including the terminated processes.
+type JobAccounting struct {
+	TotalUserTime            time.Duration
+	TotalKernelTime          time.Duration
+	TotalPageFaultCount      uint32
+	TotalProcesses           uint32
+	ActiveProcesses          uint32
+	TotalTerminatedProcesses uint32
+	// The peak committed memory, from JOBOBJECT_EXTENDED_LIMIT_INFORMATION
+	PeakProcessMemoryUsed uint64
+	PeakJobMemoryUsed     uint64
+	IO                    IO_COUNTERS
+}
+
+func newJobAccounting(info *JOBOBJECT_BASIC_AND_IO_ACCOUNTING_INFORMATION, limit *JOBOBJECT_EXTENDED_LIMIT_INFORMATION) JobAccounting {
+	return JobAccounting{
+		TotalUserTime:            time.Duration(info.BasicInfo.TotalUserTime) * 100,
+		TotalKernelTime:          time.Duration(info.BasicInfo.TotalKernelTime) * 100,
+		TotalPageFaultCount:      info.BasicInfo.TotalPageFaultCount,
+		TotalProcesses:           info.BasicInfo.TotalProcesses,
+		ActiveProcesses:          info.BasicInfo.ActiveProcesses,
+		TotalTerminatedProcesses: info.BasicInfo.TotalTerminatedProcesses,
+		PeakProcessMemoryUsed:    uint64(limit.PeakProcessMemoryUsed),
+		PeakJobMemoryUsed:        uint64(limit.PeakJobMemoryUsed),
+		IO:                       info.IoInfo,
+	}
+}
@@ -0,0 +1,28 @@
+// +build 386 arm mips mipsle
+
+package gowindows
+
+// typedef struct _JOBOBJECT_BASIC_LIMIT_INFORMATION {
+// LARGE_INTEGER PerProcessUserTimeLimit;
+// LARGE_INTEGER PerJobUserTimeLimit;
+// DWORD         LimitFlags;
+// SIZE_T        MinimumWorkingSetSize;
+// SIZE_T        MaximumWorkingSetSize;
+// DWORD         ActiveProcessLimit;
+// ULONG_PTR     Affinity;
+// DWORD         PriorityClass;
+// DWORD         SchedulingClass;
+// } JOBOBJECT_BASIC_LIMIT_INFORMATION, *PJOBOBJECT_BASIC_LIMIT_INFORMATION;
+// The C struct is padded to 0x30 because of LARGE_INTEGER.
+type JOBOBJECT_BASIC_LIMIT_INFORMATION struct {
+	PerProcessUserTimeLimit int64
+	PerJobUserTimeLimit     int64
+	LimitFlags              uint32
+	MinimumWorkingSetSize   uintptr
+	MaximumWorkingSetSize   uintptr
+	ActiveProcessLimit      uint32
+	Affinity                uintptr
+	PriorityClass           uint32
+	SchedulingClass         uint32
+	_                       uint32
+}
@@ -0,0 +1,81 @@
+package gowindows
+
+import (
+	"testing"
+	"time"
+)
+
+func TestJobLimits(t *testing.T) {
+	limits := JobLimits{
+		KillOnClose:     true,
+		ProcessMemory:   64 << 20,
+		JobMemory:       256 << 20,
+		ActiveProcesses: 3,
+		CPURate:         12.5,
+		Breakaway:       true,
+	}
+	info, cpu, err := limits.information()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	flags := uint32(JOB_OBJECT_LIMIT_KILL_ON_JOB_CLOSE | JOB_OBJECT_LIMIT_PROCESS_MEMORY | JOB_OBJECT_LIMIT_JOB_MEMORY |
+		JOB_OBJECT_LIMIT_ACTIVE_PROCESS | JOB_OBJECT_LIMIT_BREAKAWAY_OK)
+	if info.BasicLimitInformation.LimitFlags != flags {
+		t.Errorf("0x%x!=0x%x", info.BasicLimitInformation.LimitFlags, flags)
+	}
+	if info.ProcessMemoryLimit != 64<<20 || info.JobMemoryLimit != 256<<20 || info.BasicLimitInformation.ActiveProcessLimit != 3 {
+		t.Errorf("%+v", info)
+	}
+	if cpu.ControlFlags != JOB_OBJECT_CPU_RATE_CONTROL_ENABLE|JOB_OBJECT_CPU_RATE_CONTROL_HARD_CAP || cpu.CpuRate != 1250 {
+		t.Errorf("%+v", cpu)
+	}
+	if l := newJobLimits(&info, &cpu); l != limits {
+		t.Errorf("%+v!=%+v", l, limits)
+	}
+
+	// The memory limit without the flag is ignored
+	info = JOBOBJECT_EXTENDED_LIMIT_INFORMATION{ProcessMemoryLimit: 1}
+	if l := newJobLimits(&info, nil); l != (JobLimits{}) {
+		t.Errorf("%+v", l)
+	}
+
+	for _, l := range []JobLimits{{CPURate: -1}, {CPURate: 100.5}} {
+		if _, _, err := l.information(); err == nil {
+			t.Errorf("%+v: no error", l)
+		}
+	}
+	if _, cpu, _ := (&JobLimits{CPURate: 0.001}).information(); cpu.CpuRate != 1 {
+		t.Errorf("%v!=1", cpu.CpuRate)
+	}
+}
+
+func TestNewJobEvent(t *testing.T) {
+	if e := newJobEvent(uint32(JOB_OBJECT_MSG_EXIT_PROCESS), 1234); e != (JobEvent{JOB_OBJECT_MSG_EXIT_PROCESS, 1234}) {
+		t.Errorf("%+v", e)
+	}
+	if e := newJobEvent(uint32(JOB_OBJECT_MSG_ACTIVE_PROCESS_ZERO), 1234); e != (JobEvent{JOB_OBJECT_MSG_ACTIVE_PROCESS_ZERO, 0}) {
+		t.Errorf("%+v", e)
+	}
+	if s := JOB_OBJECT_MSG_JOB_MEMORY_LIMIT.String(); s != "JOB_MEMORY_LIMIT" {
+		t.Errorf("%v", s)
+	}
+	if s := JobEventType(5).String(); s != "JobEventType(5)" {
+		t.Errorf("%v", s)
+	}
+}
+
+func TestNewJobAccounting(t *testing.T) {
+	info := JOBOBJECT_BASIC_AND_IO_ACCOUNTING_INFORMATION{}
+	info.BasicInfo.TotalUserTime = 15000000
+	info.BasicInfo.TotalProcesses = 4
+	info.BasicInfo.ActiveProcesses = 1
+	info.IoInfo.ReadTransferCount = 100
+	limit := JOBOBJECT_EXTENDED_LIMIT_INFORMATION{PeakJobMemoryUsed: 4096}
+
+	a := newJobAccounting(&info, &limit)
+	if a.TotalUserTime != 1500*time.Millisecond || a.TotalProcesses != 4 || a.ActiveProcesses != 1 ||
+		a.IO.ReadTransferCount != 100 || a.PeakJobMemoryUsed != 4096 {
+		t.Errorf("%+v", a)
+	}
+}
@@ -0,0 +1,260 @@
+package gowindows
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"syscall"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+var (
+	queryInformationJobObject = kernel32.NewProc("QueryInformationJobObject")
+	getQueuedCompletionStatus = kernel32.NewProc("GetQueuedCompletionStatus")
+)
+
+// BOOL QueryInformationJobObject(
+// HANDLE             hJob,
+// JOBOBJECTINFOCLASS JobObjectInformationClass,
+// LPVOID             lpJobObjectInformation,
+// DWORD              cbJobObjectInformationLength,
+// LPDWORD            lpReturnLength
+// );
+func QueryInformationJobObject(job Handle, infoClass uint32, info unsafe.Pointer, infoLength uint32, returnLength *uint32) error {
+	r1, _, e1 := queryInformationJobObject.Call(uintptr(job), uintptr(infoClass), uintptr(info), uintptr(infoLength), uintptr(unsafe.Pointer(returnLength)))
+	if r1 == 0 {
+		return newLastCallError(kernel32.Name, queryInformationJobObject.Name, r1, e1)
+	}
+	return nil
+}
+
+// BOOL GetQueuedCompletionStatus(
+// HANDLE       CompletionPort,
+// LPDWORD      lpNumberOfBytesTransferred,
+// PULONG_PTR   lpCompletionKey,
+// LPOVERLAPPED *lpOverlapped,
+// DWORD        dwMilliseconds
+// );
+// The key is ULONG_PTR, and the overlapped of the job messages is the process id instead of the pointer,
+// so they are uintptr, different from windows.GetQueuedCompletionStatus.
+func GetQueuedCompletionStatus(port Handle, qty *uint32, key *uintptr, overlapped *uintptr, timeout uint32) error {
+	r1, _, e1 := getQueuedCompletionStatus.Call(uintptr(port), uintptr(unsafe.Pointer(qty)), uintptr(unsafe.Pointer(key)), uintptr(unsafe.Pointer(overlapped)), uintptr(timeout))
+	if r1 == 0 {
+		return newLastCallError(kernel32.Name, getQueuedCompletionStatus.Name, r1, e1)
+	}
+	return nil
+}
+
+// The completion keys of the port of JobObject
+const (
+	jobCompletionKey = 1
+	jobCloseKey      = 2
+)
+
+// Job object, the group of the processes
+// The processes are assigned by Assign or ProcessSpec.Job, their child processes are in the job too.
+//
+//	job, _ := NewJobObject("")
+//	job.SetLimits(JobLimits{KillOnClose: true, ProcessMemory: 512 << 20})
+//	p, _ := NewProcessSpec(path).Job(job.Handle()).Start()
+type JobObject struct {
+	handle windows.Handle
+
+	mu     sync.Mutex
+	port   windows.Handle
+	events chan JobEvent
+	done   chan struct{}
+}
+
+// Create the job, the name can be empty.
+// The job is opened if the name exists.
+func NewJobObject(name string) (*JobObject, error) {
+	var namePtr *uint16
+	if name != "" {
+		var err error
+		if namePtr, err = windows.UTF16PtrFromString(name); err != nil {
+			return nil, err
+		}
+	}
+	h, err := windows.CreateJobObject(nil, namePtr)
+	if err != nil {
+		return nil, fmt.Errorf("CreateJobObject, %w", err)
+	}
+	return &JobObject{handle: h}, nil
+}
+
+// The handle of the job, it is valid until Close.
+func (j *JobObject) Handle() Handle {
+	return Handle(j.handle)
+}
+
+func (j *JobObject) setInformation(infoClass uint32, info unsafe.Pointer, size uintptr) error {
+	if _, err := windows.SetInformationJobObject(j.handle, infoClass, uintptr(info), uint32(size)); err != nil {
+		return fmt.Errorf("SetInformationJobObject(%v), %w", infoClass, err)
+	}
+	return nil
+}
+
+func (j *JobObject) queryInformation(infoClass uint32, info unsafe.Pointer, size uintptr) error {
+	if err := QueryInformationJobObject(Handle(j.handle), infoClass, info, uint32(size), nil); err != nil {
+		return fmt.Errorf("QueryInformationJobObject(%v), %w", infoClass, err)
+	}
+	return nil
+}
+
+// Replace the limits of the job
+// The CPU rate needs windows 8, it is not set if it is 0 and the job has no CPU rate.
+func (j *JobObject) SetLimits(limits JobLimits) error {
+	info, cpu, err := limits.information()
+	if err != nil {
+		return err
+	}
+	if err := j.setInformation(JobObjectExtendedLimitInformation, unsafe.Pointer(&info), unsafe.Sizeof(info)); err != nil {
+		return err
+	}
+
+	if cpu.ControlFlags == 0 {
+		old := JOBOBJECT_CPU_RATE_CONTROL_INFORMATION{}
+		if err := j.queryInformation(JobObjectCpuRateControlInformation, unsafe.Pointer(&old), unsafe.Sizeof(old)); err != nil || old.ControlFlags == 0 {
+			return nil
+		}
+	}
+	return j.setInformation(JobObjectCpuRateControlInformation, unsafe.Pointer(&cpu), unsafe.Sizeof(cpu))
+}
+
+// The limits of the job, the limits not in JobLimits are ignored.
+func (j *JobObject) Limits() (JobLimits, error) {
+	info := JOBOBJECT_EXTENDED_LIMIT_INFORMATION{}
+	if err := j.queryInformation(JobObjectExtendedLimitInformation, unsafe.Pointer(&info), unsafe.Sizeof(info)); err != nil {
+		return JobLimits{}, err
+	}
+	cpu := new(JOBOBJECT_CPU_RATE_CONTROL_INFORMATION)
+	if err := j.queryInformation(JobObjectCpuRateControlInformation, unsafe.Pointer(cpu), unsafe.Sizeof(*cpu)); err != nil {
+		// Before windows 8
+		cpu = nil
+	}
+	return newJobLimits(&info, cpu), nil
+}
+
+// Assign the process to the job, the process needs PROCESS_SET_QUOTA and PROCESS_TERMINATE.
+// The process created by ProcessSpec.Job is assigned before it runs.
+func (j *JobObject) Assign(process Handle) error {
+	if err := windows.AssignProcessToJobObject(j.handle, windows.Handle(process)); err != nil {
+		return fmt.Errorf("AssignProcessToJobObject, %w", err)
+	}
+	return nil
+}
+
+func (j *JobObject) Accounting() (JobAccounting, error) {
+	info := JOBOBJECT_BASIC_AND_IO_ACCOUNTING_INFORMATION{}
+	if err := j.queryInformation(JobObjectBasicAndIoAccountingInformation, unsafe.Pointer(&info), unsafe.Sizeof(info)); err != nil {
+		return JobAccounting{}, err
+	}
+	limit := JOBOBJECT_EXTENDED_LIMIT_INFORMATION{}
+	if err := j.queryInformation(JobObjectExtendedLimitInformation, unsafe.Pointer(&limit), unsafe.Sizeof(limit)); err != nil {
+		return JobAccounting{}, err
+	}
+	return newJobAccounting(&info, &limit), nil
+}
+
+// The ids of the active processes in the job
+func (j *JobObject) ProcessIds() ([]uint32, error) {
+	// JOBOBJECT_BASIC_PROCESS_ID_LIST
+	// DWORD NumberOfAssignedProcesses, DWORD NumberOfProcessIdsInList, ULONG_PTR ProcessIdList[1]
+	const header = 8 / ptrSize
+	n := 64
+	for {
+		buf := make([]uintptr, header+uintptr(n))
+		err := QueryInformationJobObject(Handle(j.handle), JobObjectBasicProcessIdList, unsafe.Pointer(&buf[0]), uint32(uintptr(len(buf))*ptrSize), nil)
+		counts := (*[2]uint32)(unsafe.Pointer(&buf[0]))
+		if err == nil {
+			ids := make([]uint32, counts[1])
+			for i := range ids {
+				ids[i] = uint32(buf[header+uintptr(i)])
+			}
+			return ids, nil
+		}
+		if errors.Is(err, windows.ERROR_MORE_DATA) == false {
+			return nil, fmt.Errorf("QueryInformationJobObject(%v), %w", JobObjectBasicProcessIdList, err)
+		}
+		n = int(counts[0]) + 16
+	}
+}
+
+// The notifications of the job
+// The completion port is associated with the job at the first call, the channel is closed by Close.
+// The events are kept in the port until they are received.
+func (j *JobObject) Events() (<-chan JobEvent, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.events != nil {
+		return j.events, nil
+	}
+
+	port, err := windows.CreateIoCompletionPort(windows.InvalidHandle, 0, 0, 1)
+	if err != nil {
+		return nil, fmt.Errorf("CreateIoCompletionPort, %w", err)
+	}
+	info := JOBOBJECT_ASSOCIATE_COMPLETION_PORT{CompletionKey: jobCompletionKey, CompletionPort: Handle(port)}
+	if err := j.setInformation(JobObjectAssociateCompletionPortInformation, unsafe.Pointer(&info), unsafe.Sizeof(info)); err != nil {
+		windows.CloseHandle(port)
+		return nil, err
+	}
+
+	j.port = port
+	j.events = make(chan JobEvent, 16)
+	j.done = make(chan struct{})
+	go readJobEvents(port, j.events, j.done)
+	return j.events, nil
+}
+
+func readJobEvents(port windows.Handle, events chan JobEvent, done chan struct{}) {
+	defer close(events)
+	defer windows.CloseHandle(port)
+	for {
+		var message uint32
+		var key, overlapped uintptr
+		if err := GetQueuedCompletionStatus(Handle(port), &message, &key, &overlapped, INFINITE); err != nil {
+			return
+		}
+		switch key {
+		case jobCloseKey:
+			return
+		case jobCompletionKey:
+			select {
+			case events <- newJobEvent(message, overlapped):
+			case <-done:
+				return
+			}
+		}
+	}
+}
+
+// Terminate all the processes in the job
+func (j *JobObject) Terminate(exitCode uint32) error {
+	if err := windows.TerminateJobObject(j.handle, exitCode); err != nil {
+		return fmt.Errorf("TerminateJobObject, %w", err)
+	}
+	return nil
+}
+
+// Close the job, the processes are terminated if KillOnClose is set and it is the last handle.
+// The pending events are dropped.
+func (j *JobObject) Close() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.handle == 0 {
+		return syscall.EINVAL
+	}
+	if j.port != 0 {
+		// The port is closed by readJobEvents, it must not be used after the reader may return.
+		windows.PostQueuedCompletionStatus(j.port, 0, jobCloseKey, nil)
+		close(j.done)
+		j.port = 0
+	}
+	err := windows.CloseHandle(j.handle)
+	j.handle = 0
+	return err
+}
@@ -0,0 +1,103 @@
+package gowindows
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+// Wait for the event of the types, the other events are skipped.
+func waitJobEvent(t *testing.T, events <-chan JobEvent, eventTypes ...JobEventType) JobEvent {
+	timeout := time.After(10 * time.Second)
+	for {
+		select {
+		case e, ok := <-events:
+			if ok == false {
+				t.Fatalf("the events are closed before %v", eventTypes)
+			}
+			for _, v := range eventTypes {
+				if e.Type == v {
+					return e
+				}
+			}
+		case <-timeout:
+			t.Fatalf("no event %v", eventTypes)
+		}
+	}
+}
+
+func TestJobObject(t *testing.T) {
+	if os.Getenv("GOWINDOWS_PROCESS_HELPER") != "" {
+		t.Skip("helper process")
+	}
+
+	job, err := NewJobObject("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer job.Close()
+
+	limits := JobLimits{ActiveProcesses: 2, JobMemory: 1 << 30, Breakaway: true}
+	if err := job.SetLimits(limits); err != nil {
+		t.Fatal(err)
+	}
+	if l, err := job.Limits(); err != nil || l != limits {
+		t.Errorf("%+v %v!=%+v", l, err, limits)
+	}
+
+	events, err := job.Events()
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := startSleepProcess(t, job.Handle())
+	defer p.Close()
+
+	if e := waitJobEvent(t, events, JOB_OBJECT_MSG_NEW_PROCESS); e.ProcessId != p.Pid() {
+		t.Errorf("%v!=%v", e.ProcessId, p.Pid())
+	}
+	if ids, err := job.ProcessIds(); err != nil || len(ids) != 1 || ids[0] != p.Pid() {
+		t.Errorf("%v %v!=[%v]", ids, err, p.Pid())
+	}
+
+	if err := job.Terminate(7); err != nil {
+		t.Fatal(err)
+	}
+	if e := waitJobEvent(t, events, JOB_OBJECT_MSG_EXIT_PROCESS, JOB_OBJECT_MSG_ABNORMAL_EXIT_PROCESS); e.ProcessId != p.Pid() {
+		t.Errorf("%v!=%v", e.ProcessId, p.Pid())
+	}
+	waitJobEvent(t, events, JOB_OBJECT_MSG_ACTIVE_PROCESS_ZERO)
+
+	a, err := job.Accounting()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.TotalProcesses != 1 || a.ActiveProcesses != 0 || a.TotalTerminatedProcesses != 1 {
+		t.Errorf("%+v", a)
+	}
+
+	job.Close()
+	for range events {
+	}
+}
+
+func TestJobObject_KillOnClose(t *testing.T) {
+	if os.Getenv("GOWINDOWS_PROCESS_HELPER") != "" {
+		t.Skip("helper process")
+	}
+
+	job, err := NewJobObject("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := job.SetLimits(JobLimits{KillOnClose: true}); err != nil {
+		job.Close()
+		t.Fatal(err)
+	}
+	p := startSleepProcess(t, job.Handle())
+	defer p.Close()
+
+	job.Close()
+	if err := p.Wait(timeoutContext(10 * time.Second)); err != nil {
+		t.Fatal(err)
+	}
+}
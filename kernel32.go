@@ -5,3 +5,30 @@ package gowindows
 const InvalidHandle = ^Handle(0)
 
 const MUTANT_ALL_ACCESS uint = 0x001F0001 //0x000F0000 | 0x00100000 | 0x0001
+
+// https://docs.microsoft.com/en-us/windows/win32/sync/synchronization-object-security-and-access-rights
+const (
+	SEMAPHORE_MODIFY_STATE = 0x0002
+	SEMAPHORE_ALL_ACCESS   = 0x001F0003
+
+	MAXIMUM_WAIT_OBJECTS = 64
+	WAIT_TIMEOUT         = 0x00000102
+
+	LOCALE_SYSTEM_DEFAULT      = 0x0800
+	LOCALE_IDEFAULTMACCODEPAGE = 0x00001011 // default mac code page
+)
+
+// https://docs.microsoft.com/en-us/windows/win32/api/sysinfoapi/ns-sysinfoapi-system_info
+type SYSTEM_INFO struct {
+	ProcessorArchitecture     Word
+	Reserved                  Word
+	PageSize                  DWord
+	MinimumApplicationAddress uintptr
+	MaximumApplicationAddress uintptr
+	ActiveProcessorMask       uintptr
+	NumberOfProcessors        DWord
+	ProcessorType             DWord
+	AllocationGranularity     DWord
+	ProcessorLevel            Word
+	ProcessorRevision         Word
+}
@@ -2,7 +2,6 @@ package gowindows
 
 import (
 	"syscall"
-	"unicode/utf16"
 	"unsafe"
 
 	"fmt"
@@ -21,6 +20,12 @@ var (
 	openFileMappingW    = kernel32.NewProc("OpenFileMappingW")
 	getModuleFileName   = kernel32.NewProc("GetModuleFileNameW")
 	wideCharToMultiByte = kernel32.NewProc("WideCharToMultiByte")
+	getSystemInfo       = kernel32.NewProc("GetSystemInfo")
+	createSemaphoreW    = kernel32.NewProc("CreateSemaphoreW")
+	openSemaphoreW      = kernel32.NewProc("OpenSemaphoreW")
+	releaseSemaphore    = kernel32.NewProc("ReleaseSemaphore")
+	getOEMCP            = kernel32.NewProc("GetOEMCP")
+	getLocaleInfoW      = kernel32.NewProc("GetLocaleInfoW")
 )
 
 //BOOL WINAPI ReadProcessMemory(
@@ -36,12 +41,7 @@ func ReadProcessMemory(processHandle Handle, baseAddress uint,
 	r1, _, err := readProcessMemory.Call(uintptr(processHandle), uintptr(baseAddress),
 		uintptr(unsafe.Pointer(bufferData)), uintptr(bufferSize), uintptr(unsafe.Pointer(returnSize)))
 	if r1 == 0 {
-		if err != ERROR_SUCCESS {
-			return err
-		} else {
-			return syscall.EINVAL
-		}
-		return err
+		return newLastCallError(kernel32.Name, readProcessMemory.Name, r1, err)
 	}
 	return nil
 }
@@ -60,7 +60,7 @@ func IsWow64Process(hProcess Handle) (bool, error) {
 
 	r1, _, err := isWow64Process.Call(uintptr(hProcess), uintptr(unsafe.Pointer(&is64)))
 	if r1 == 0 {
-		return false, err
+		return false, newLastCallError(kernel32.Name, isWow64Process.Name, r1, err)
 	}
 
 	switch is64 {
@@ -80,13 +80,13 @@ func Is64System() (bool, error) {
 
 	h, err := windows.GetCurrentProcess()
 	if err != nil {
-		return false, fmt.Errorf("windows.GetCurrentProcess, %v", err)
+		return false, fmt.Errorf("windows.GetCurrentProcess, %w", err)
 	}
 	defer windows.CloseHandle(h)
 
 	is64, err := IsWow64Process(Handle(h))
 	if err != nil {
-		return false, fmt.Errorf("IsWow64Process, %v", err)
+		return false, fmt.Errorf("IsWow64Process, %w", err)
 	}
 
 	return is64, nil
@@ -105,11 +105,7 @@ func Is64System() (bool, error) {
 func LocalFree(h windows.Pointer) error {
 	r1, _, e1 := localFree.Call(uintptr(unsafe.Pointer(h)))
 	if r1 != 0 {
-		if e1 != ERROR_SUCCESS {
-			return e1
-		} else {
-			return syscall.EINVAL
-		}
+		return newLastCallError(kernel32.Name, localFree.Name, r1, e1)
 	}
 	return nil
 }
@@ -140,11 +136,7 @@ func OpenFileMapping(desiredAccess DWord, inheritHandle bool, name string) (Hand
 
 	r1, _, e1 := openFileMappingW.Call(uintptr(desiredAccess), uintptr(_inheritHandle), uintptr(unsafe.Pointer(_name)))
 	if r1 == 0 {
-		if e1 != ERROR_SUCCESS {
-			return 0, e1
-		} else {
-			return 0, syscall.EINVAL
-		}
+		return 0, newLastCallError(kernel32.Name, openFileMappingW.Name, r1, e1)
 	}
 
 	return Handle(r1), nil
@@ -160,11 +152,7 @@ func GetModuleFileName(module HMODULE) (string, error) {
 
 		// Return 0 means function failed
 		if r1 == 0 {
-			if e1 != ERROR_SUCCESS {
-				return "", e1
-			} else {
-				return "", syscall.EINVAL
-			}
+			return "", newLastCallError(kernel32.Name, getModuleFileName.Name, r1, e1)
 		}
 
 		// Maybe there is not enough space, execute again
@@ -195,11 +183,7 @@ func CreateMutex(lpSecurityAttributes uintptr, bInitalOwner bool, name string) (
 	r0, _, e1 := syscall.Syscall(createMutexW.Addr(), 3, uintptr(lpSecurityAttributes), uintptr(_p0), uintptr(unsafe.Pointer(mutexName)))
 	handle = Handle(r0)
 	if handle == InvalidHandle {
-		if e1 != 0 {
-			err = error(e1)
-		} else {
-			err = syscall.EINVAL
-		}
+		err = newLastCallError(kernel32.Name, createMutexW.Name, r0, e1)
 	}
 	return
 }
@@ -224,11 +208,7 @@ func OpenMutex(dwDesiredAccess DWord, bInitalOwner bool, name string) (handle Ha
 	r0, _, e1 := syscall.Syscall(openMutexW.Addr(), 3, uintptr(dwDesiredAccess), uintptr(_p0), uintptr(unsafe.Pointer(mutexName)))
 	handle = Handle(r0)
 	if handle == InvalidHandle {
-		if e1 != 0 {
-			err = error(e1)
-		} else {
-			err = syscall.EINVAL
-		}
+		err = newLastCallError(kernel32.Name, openMutexW.Name, r0, e1)
 	}
 	return
 }
@@ -241,10 +221,11 @@ func ReleaseMutex(handle Handle) (err error) {
 //     syscall.WaitForSingleObject(mu,syscall.INFINITE)
 
 // Returns nil only once, or err if it already exists
+// Deprecated: use SingleInstance, which also releases the abandoned mutex and forwards the args.
 func One(name string) (handle Handle, rerr error) {
 	h, err := CreateMutex(0, false, name)
 	if err != nil {
-		return 0, fmt.Errorf("failed to create semaphore, %v", err)
+		return 0, fmt.Errorf("failed to create semaphore, %w", err)
 	}
 	lasterr := syscall.GetLastError()
 	if lasterr == syscall.ERROR_ALREADY_EXISTS {
@@ -264,6 +245,7 @@ func One(name string) (handle Handle, rerr error) {
 	return 0, fmt.Errorf("the semaphore is already held by other programs")
 }
 
+// Deprecated: use SingleInstance.Release
 func OneDefer(h Handle) {
 	ReleaseMutex(h)
 	syscall.CloseHandle(Handle(h))
@@ -293,49 +275,32 @@ func WideCharToMultiByte(codePage uint32, dwFlags DWord, wchar *uint16, nwchar i
 	)
 
 	if r1 == 0 {
-		if e1 != ERROR_SUCCESS {
-			return 0, e1
-		} else {
-			return 0, syscall.EINVAL
-		}
+		return 0, newLastCallError(kernel32.Name, wideCharToMultiByte.Name, r1, e1)
 	}
 
 	return int32(r1), nil
 }
 
-type CodePage uint32
-
-const (
-	//  Code Page Default Values.
-	//
-	CP_ACP        CodePage = 0  // default to ANSI code page
-	CP_OEMCP      CodePage = 1  // default to OEM  code page
-	CP_MACCP      CodePage = 2  // default to MAC  code page
-	CP_THREAD_ACP CodePage = 3  // current thread's ANSI code page
-	CP_SYMBOL     CodePage = 42 // SYMBOL translations
-
-	CP_UTF7 CodePage = 65000 // UTF-7 translation
-	CP_UTF8 CodePage = 65001 // UTF-8 translation
-)
+// UINT GetOEMCP();
+func GetOEMCP() uint32 {
+	r1, _, _ := getOEMCP.Call()
+	return uint32(r1)
+}
 
-type WideChar2MultiByteFlags DWord
-
-const (
-	WC_COMPOSITECHECK WideChar2MultiByteFlags = 0x00000200 // convert composite to precomposed
-	WC_DISCARDNS      WideChar2MultiByteFlags = 0x00000010 // discard non-spacing chars
-	WC_SEPCHARS       WideChar2MultiByteFlags = 0x00000020 // generate separate chars
-	WC_DEFAULTCHAR    WideChar2MultiByteFlags = 0x00000040 // replace w/ default char
-	//#if (WINVER >= 0x0600)
-	// 	Windows Vista and later: If an invalid input character is encountered, it fails (by returning 0 and setting the last error code to ERROR_NO_UNICODE_TRANSLATION). You can retrieve the last error code by calling GetLastError. If this flag is not set, the function replaces the illegal sequence (coded according to the specified code page) with U + FFFD, and succeeds by returning the length of the converted string. Please note that this flag only applies when the code page is specified as CP_UTF8 or 54936. It cannot be used with other code page values.
-	WC_ERR_INVALID_CHARS WideChar2MultiByteFlags = 0x00000080 // error for invalid chars
-	//#endif
-
-	//#if(WINVER >= 0x0500)
-	// Convert Unicode characters that cannot be directly converted into corresponding multibyte characters into the default characters specified by lpDefaultChar. In other words, if you convert Unicode to multibyte characters and then convert back, you may not necessarily get the same Unicode character, because the default character may be used during this period. This option can be used alone or with other options.
-	// For strings that require authentication, such as files, resources, and user names, applications should always use the WC_NO_BEST_FIT_CHARS flag. This flag prevents the function from mapping characters to characters that look similar but have very different semantics. In some cases, semantic changes may be extreme. For example, the "∞" (infinity) symbol is mapped to 8 (8) in some code pages.
-	WC_NO_BEST_FIT_CHARS WideChar2MultiByteFlags = 0x00000400 // do not use best fit chars
-	//#endif /* WINVER >= 0x0500 */
-)
+// int GetLocaleInfoW(
+// LCID   Locale,
+// LCTYPE LCType,
+// LPWSTR lpLCData,
+// int    cchData
+// );
+func GetLocaleInfo(locale uint32, lcType uint32) (string, error) {
+	buf := make([]uint16, 128)
+	r1, _, e1 := getLocaleInfoW.Call(uintptr(locale), uintptr(lcType), uintptr(unsafe.Pointer(&buf[0])), uintptr(len(buf)))
+	if r1 == 0 {
+		return "", newLastCallError(kernel32.Name, getLocaleInfoW.Name, r1, e1)
+	}
+	return windows.UTF16ToString(buf[:r1]), nil
+}
 
 // wchar(UTF-16LE) converts to char
 // If the input does not contain \0, the output will not.
@@ -359,29 +324,6 @@ func WideChar2MultiByte(codePage CodePage, dwFlags WideChar2MultiByteFlags, wcha
 	return out[:n], nil
 }
 
-type MultiByteToWideCharFlags DWord
-
-const (
-	//
-	//  MBCS and Unicode Translation Flags.
-	//
-
-	// Default; do not use with MB_COMPOSITE. Always use pre-combined characters, that is, characters with a single character value as basic characters or non-spacing character combinations. For example, in the character è, e is a basic character, and accented characters are non-sliding characters. If a single Unicode code point is defined for a character, the application should use it instead of separate basic and non-spacing characters. For example, Ä is represented by a single Unicode code point LATIN CAPITAL LETTER A WITH DIAERESIS (U + 00C4).
-	MB_PRECOMPOSED MultiByteToWideCharFlags = 0x00000001 // use precomposed chars
-
-	// Always use decomposed characters, that is, characters that have different code point values for the base character and one or more non-spacing characters. For example, Ä is represented by A +¨: LATIN CAPITAL LETTER A (U + 0041) + COMBINING DIAERESIS (U + 0308). Please note that this flag cannot be used with MB_PRECOMPOSED.
-	MB_COMPOSITE MultiByteToWideCharFlags = 0x00000002 // use composite chars
-
-	// Use glyph characters instead of control characters.
-	MB_USEGLYPHCHARS MultiByteToWideCharFlags = 0x00000004 // use glyph chars, not ctrl chars
-
-	// If an invalid input character is encountered, it fails.
-	//Starting from Windows Vista, if the application does not set this flag, the function will not delete the illegal code point, but use U + FFFD to replace the illegal sequence (according to the specified code page encoding).
-	//
-	//Windows 2000 with SP4 and higher, Windows XP: If this flag is not set, the function will silently delete illegal code points. The call to GetLastError returns ERROR_NO_UNICODE_TRANSLATION.
-	MB_ERR_INVALID_CHARS MultiByteToWideCharFlags = 0x00000008 // error for invalid chars
-)
-
 // convert char to wchar(UTF-16LE)
 // If the input does not contain \0, the output will not.
 // https://docs.microsoft.com/en-us/windows/desktop/api/stringapiset/nf-stringapiset-multibytetowidechar
@@ -404,23 +346,66 @@ func MultiByte2WideChar(codePage CodePage, dwFlags MultiByteToWideCharFlags, str
 	return out[:n], nil
 }
 
-// Internally implemented using windows api
-// The main purpose is actually to convert go string to windows local encoding
-func Str2MultiByte(codePage CodePage, dwFlags WideChar2MultiByteFlags, str string, lpDefaultChar *byte, pfUsedDefaultChar *bool) ([]byte, error) {
-	utf16Data := utf16.Encode([]rune(str))
+var WaitForSingleObject = windows.WaitForSingleObject
 
-	return WideChar2MultiByte(codePage, dwFlags, utf16Data, lpDefaultChar, pfUsedDefaultChar)
+// https://docs.microsoft.com/en-us/windows/win32/api/winbase/nf-winbase-createsemaphorew
+// HANDLE CreateSemaphoreW(
+// LPSECURITY_ATTRIBUTES lpSemaphoreAttributes,
+// LONG                  lInitialCount,
+// LONG                  lMaximumCount,
+// LPCWSTR               lpName
+// );
+func CreateSemaphore(semaphoreAttributes *windows.SecurityAttributes, initialCount, maximumCount int32, name *uint16) (Handle, error) {
+	r1, _, e1 := createSemaphoreW.Call(uintptr(unsafe.Pointer(semaphoreAttributes)), uintptr(initialCount),
+		uintptr(maximumCount), uintptr(unsafe.Pointer(name)))
+	if r1 == 0 {
+		return 0, newLastCallError(kernel32.Name, createSemaphoreW.Name, r1, e1)
+	}
+	return Handle(r1), nil
 }
 
-// Internally implemented using windows api
-// The main purpose is actually to convert Windows local encoding to go string
-func MultiByte2Str(codePage CodePage, dwFlags MultiByteToWideCharFlags, str []byte) (string, error) {
-	utf16Data, err := MultiByte2WideChar(codePage, dwFlags, str)
+// https://docs.microsoft.com/en-us/windows/win32/api/synchapi/nf-synchapi-opensemaphorew
+// HANDLE OpenSemaphoreW(
+// DWORD   dwDesiredAccess,
+// BOOL    bInheritHandle,
+// LPCWSTR lpName
+// );
+func OpenSemaphore(desiredAccess DWord, inheritHandle bool, name string) (Handle, error) {
+	namePtr, err := windows.UTF16PtrFromString(name)
 	if err != nil {
-		return "", err
+		return 0, err
+	}
+	inherit := 0
+	if inheritHandle {
+		inherit = 1
+	}
+	r1, _, e1 := openSemaphoreW.Call(uintptr(desiredAccess), uintptr(inherit), uintptr(unsafe.Pointer(namePtr)))
+	if r1 == 0 {
+		return 0, newLastCallError(kernel32.Name, openSemaphoreW.Name, r1, e1)
 	}
+	return Handle(r1), nil
+}
 
-	return string(utf16.Decode(utf16Data)), nil
+// https://docs.microsoft.com/en-us/windows/win32/api/synchapi/nf-synchapi-releasesemaphore
+// BOOL ReleaseSemaphore(
+// HANDLE hSemaphore,
+// LONG   lReleaseCount,
+// LPLONG lpPreviousCount
+// );
+func ReleaseSemaphore(semaphore Handle, releaseCount int32, previousCount *int32) error {
+	r1, _, e1 := releaseSemaphore.Call(uintptr(semaphore), uintptr(releaseCount), uintptr(unsafe.Pointer(previousCount)))
+	if r1 == 0 {
+		return newLastCallError(kernel32.Name, releaseSemaphore.Name, r1, e1)
+	}
+	return nil
 }
 
-var WaitForSingleObject = windows.WaitForSingleObject
+// https://docs.microsoft.com/en-us/windows/win32/api/sysinfoapi/nf-sysinfoapi-getsysteminfo
+//void GetSystemInfo(
+//  LPSYSTEM_INFO lpSystemInfo
+//);
+func GetSystemInfo() *SYSTEM_INFO {
+	info := new(SYSTEM_INFO)
+	getSystemInfo.Call(uintptr(unsafe.Pointer(info)))
+	return info
+}
@@ -3,6 +3,7 @@ package gowindows
 import (
 	"bytes"
 	"crypto/rand"
+	"errors"
 	"fmt"
 	"testing"
 	"unsafe"
@@ -114,6 +115,21 @@ func TestOpenFileMapping(t *testing.T) {
 	_ = addr2
 }
 
+func TestOpenSemaphore_notFound(t *testing.T) {
+	_, err := OpenSemaphore(SEMAPHORE_MODIFY_STATE, false, "gowindows-missing-semaphore-7423")
+	if errors.Is(err, ErrNotFound) == false || errors.Is(err, windows.ERROR_FILE_NOT_FOUND) == false {
+		t.Fatalf("%v is not ERROR_FILE_NOT_FOUND", err)
+	}
+
+	var callErr *CallError
+	if errors.As(err, &callErr) == false {
+		t.Fatalf("%v is not CallError", err)
+	}
+	if callErr.Dll != "kernel32.dll" || callErr.Func != "OpenSemaphoreW" || callErr.LastError != windows.ERROR_FILE_NOT_FOUND {
+		t.Errorf("%v %v %v!=kernel32.dll OpenSemaphoreW 2", callErr.Dll, callErr.Func, uint32(callErr.LastError))
+	}
+}
+
 func TestGetModuleFileName(t *testing.T) {
 	path, err := GetModuleFileName(0)
 	if err != nil {
@@ -0,0 +1,425 @@
+// Code generated by mklayout.go from layout.txt; DO NOT EDIT.
+
+package gowindows
+
+import "unsafe"
+
+// The C layout on amd64, the index is out of bounds or overflows if the go structure is not the C layout.
+func _() {
+	var x [1]struct{}
+	// UNICODE_STRING
+	_ = x[unsafe.Offsetof(UNICODE_STRING{}.Length)-0x0]
+	_ = x[unsafe.Offsetof(UNICODE_STRING{}.MaximumLength)-0x2]
+	_ = x[unsafe.Offsetof(UNICODE_STRING{}.Buffer)-0x8]
+	_ = x[unsafe.Sizeof(UNICODE_STRING{})-0x10]
+	// UNICODE_STRING
+	_ = x[unsafe.Offsetof(UNICODE_STRING32{}.Length)-0x0]
+	_ = x[unsafe.Offsetof(UNICODE_STRING32{}.MaximumLength)-0x2]
+	_ = x[unsafe.Offsetof(UNICODE_STRING32{}.Buffer)-0x4]
+	_ = x[unsafe.Sizeof(UNICODE_STRING32{})-0x8]
+	// UNICODE_STRING
+	_ = x[unsafe.Offsetof(UNICODE_STRING64{}.Length)-0x0]
+	_ = x[unsafe.Offsetof(UNICODE_STRING64{}.MaximumLength)-0x2]
+	_ = x[unsafe.Offsetof(UNICODE_STRING64{}.Buffer)-0x8]
+	_ = x[unsafe.Sizeof(UNICODE_STRING64{})-0x10]
+	// PEB
+	_ = x[unsafe.Offsetof(PEB{}.Reserved1)-0x0]
+	_ = x[unsafe.Offsetof(PEB{}.BeingDebugged)-0x2]
+	_ = x[unsafe.Offsetof(PEB{}.Reserved2)-0x3]
+	_ = x[unsafe.Offsetof(PEB{}.Reserved3)-0x8]
+	_ = x[unsafe.Offsetof(PEB{}.Ldr)-0x18]
+	_ = x[unsafe.Offsetof(PEB{}.ProcessParameters)-0x20]
+	_ = x[unsafe.Offsetof(PEB{}.Reserved4)-0x28]
+	_ = x[unsafe.Offsetof(PEB{}.AtlThunkSListPtr)-0x40]
+	_ = x[unsafe.Offsetof(PEB{}.Reserved5)-0x48]
+	_ = x[unsafe.Offsetof(PEB{}.Reserved6)-0x50]
+	_ = x[unsafe.Offsetof(PEB{}.Reserved7)-0x58]
+	_ = x[unsafe.Offsetof(PEB{}.Reserved8)-0x60]
+	_ = x[unsafe.Offsetof(PEB{}.AtlThunkSListPtr32)-0x64]
+	_ = x[unsafe.Offsetof(PEB{}.Reserved9)-0x68]
+	_ = x[unsafe.Offsetof(PEB{}.Reserved10)-0x1D0]
+	_ = x[unsafe.Offsetof(PEB{}.PostProcessInitRoutine)-0x230]
+	_ = x[unsafe.Offsetof(PEB{}.Reserved11)-0x238]
+	_ = x[unsafe.Offsetof(PEB{}.Reserved12)-0x2B8]
+	_ = x[unsafe.Offsetof(PEB{}.SessionId)-0x2C0]
+	_ = x[unsafe.Sizeof(PEB{})-0x2C8]
+	// PEB
+	_ = x[unsafe.Offsetof(PEB32{}.Reserved1)-0x0]
+	_ = x[unsafe.Offsetof(PEB32{}.BeingDebugged)-0x2]
+	_ = x[unsafe.Offsetof(PEB32{}.Reserved2)-0x3]
+	_ = x[unsafe.Offsetof(PEB32{}.Reserved3)-0x4]
+	_ = x[unsafe.Offsetof(PEB32{}.Ldr)-0xC]
+	_ = x[unsafe.Offsetof(PEB32{}.ProcessParameters)-0x10]
+	_ = x[unsafe.Offsetof(PEB32{}.Reserved4)-0x14]
+	_ = x[unsafe.Offsetof(PEB32{}.AtlThunkSListPtr)-0x20]
+	_ = x[unsafe.Offsetof(PEB32{}.Reserved5)-0x24]
+	_ = x[unsafe.Offsetof(PEB32{}.Reserved6)-0x28]
+	_ = x[unsafe.Offsetof(PEB32{}.Reserved7)-0x2C]
+	_ = x[unsafe.Offsetof(PEB32{}.Reserved8)-0x30]
+	_ = x[unsafe.Offsetof(PEB32{}.AtlThunkSListPtr32)-0x34]
+	_ = x[unsafe.Offsetof(PEB32{}.Reserved9)-0x38]
+	_ = x[unsafe.Offsetof(PEB32{}.Reserved10)-0xEC]
+	_ = x[unsafe.Offsetof(PEB32{}.PostProcessInitRoutine)-0x14C]
+	_ = x[unsafe.Offsetof(PEB32{}.Reserved11)-0x150]
+	_ = x[unsafe.Offsetof(PEB32{}.Reserved12)-0x1D0]
+	_ = x[unsafe.Offsetof(PEB32{}.SessionId)-0x1D4]
+	_ = x[unsafe.Sizeof(PEB32{})-0x1D8]
+	// PEB
+	_ = x[unsafe.Offsetof(PEB64{}.Reserved1)-0x0]
+	_ = x[unsafe.Offsetof(PEB64{}.BeingDebugged)-0x2]
+	_ = x[unsafe.Offsetof(PEB64{}.Reserved2)-0x3]
+	_ = x[unsafe.Offsetof(PEB64{}.Ldr)-0x18]
+	_ = x[unsafe.Offsetof(PEB64{}.ProcessParameters)-0x20]
+	_ = x[unsafe.Offsetof(PEB64{}.Reserved3)-0x28]
+	_ = x[unsafe.Offsetof(PEB64{}.PostProcessInitRoutine)-0x230]
+	_ = x[unsafe.Offsetof(PEB64{}.Reserved4)-0x238]
+	_ = x[unsafe.Offsetof(PEB64{}.SessionId)-0x2C0]
+	_ = x[unsafe.Sizeof(PEB64{})-0x2C8]
+	// RTL_USER_PROCESS_PARAMETERS
+	_ = x[unsafe.Offsetof(RTL_USER_PROCESS_PARAMETERS{}.Reserved1)-0x0]
+	_ = x[unsafe.Offsetof(RTL_USER_PROCESS_PARAMETERS{}.Reserved2)-0x10]
+	_ = x[unsafe.Offsetof(RTL_USER_PROCESS_PARAMETERS{}.ImagePathName)-0x60]
+	_ = x[unsafe.Offsetof(RTL_USER_PROCESS_PARAMETERS{}.CommandLine)-0x70]
+	_ = x[unsafe.Sizeof(RTL_USER_PROCESS_PARAMETERS{})-0x80]
+	// RTL_USER_PROCESS_PARAMETERS
+	_ = x[unsafe.Offsetof(RTL_USER_PROCESS_PARAMETERS32{}.Reserved1)-0x0]
+	_ = x[unsafe.Offsetof(RTL_USER_PROCESS_PARAMETERS32{}.Reserved2)-0x10]
+	_ = x[unsafe.Offsetof(RTL_USER_PROCESS_PARAMETERS32{}.ImagePathName)-0x38]
+	_ = x[unsafe.Offsetof(RTL_USER_PROCESS_PARAMETERS32{}.CommandLine)-0x40]
+	_ = x[unsafe.Sizeof(RTL_USER_PROCESS_PARAMETERS32{})-0x48]
+	// RTL_USER_PROCESS_PARAMETERS
+	_ = x[unsafe.Offsetof(RTL_USER_PROCESS_PARAMETERS64{}.Reserved1)-0x0]
+	_ = x[unsafe.Offsetof(RTL_USER_PROCESS_PARAMETERS64{}.Reserved2)-0x10]
+	_ = x[unsafe.Offsetof(RTL_USER_PROCESS_PARAMETERS64{}.ImagePathName)-0x60]
+	_ = x[unsafe.Offsetof(RTL_USER_PROCESS_PARAMETERS64{}.CommandLine)-0x70]
+	_ = x[unsafe.Sizeof(RTL_USER_PROCESS_PARAMETERS64{})-0x80]
+	// CURDIR
+	_ = x[unsafe.Offsetof(CURDIR32{}.DosPath)-0x0]
+	_ = x[unsafe.Offsetof(CURDIR32{}.Handle)-0x8]
+	_ = x[unsafe.Sizeof(CURDIR32{})-0xC]
+	// CURDIR
+	_ = x[unsafe.Offsetof(CURDIR64{}.DosPath)-0x0]
+	_ = x[unsafe.Offsetof(CURDIR64{}.Handle)-0x10]
+	_ = x[unsafe.Sizeof(CURDIR64{})-0x18]
+	// RTL_DRIVE_LETTER_CURDIR
+	_ = x[unsafe.Offsetof(RTL_DRIVE_LETTER_CURDIR32{}.Flags)-0x0]
+	_ = x[unsafe.Offsetof(RTL_DRIVE_LETTER_CURDIR32{}.Length)-0x2]
+	_ = x[unsafe.Offsetof(RTL_DRIVE_LETTER_CURDIR32{}.TimeStamp)-0x4]
+	_ = x[unsafe.Offsetof(RTL_DRIVE_LETTER_CURDIR32{}.DosPath)-0x8]
+	_ = x[unsafe.Sizeof(RTL_DRIVE_LETTER_CURDIR32{})-0x10]
+	// RTL_DRIVE_LETTER_CURDIR
+	_ = x[unsafe.Offsetof(RTL_DRIVE_LETTER_CURDIR64{}.Flags)-0x0]
+	_ = x[unsafe.Offsetof(RTL_DRIVE_LETTER_CURDIR64{}.Length)-0x2]
+	_ = x[unsafe.Offsetof(RTL_DRIVE_LETTER_CURDIR64{}.TimeStamp)-0x4]
+	_ = x[unsafe.Offsetof(RTL_DRIVE_LETTER_CURDIR64{}.DosPath)-0x8]
+	_ = x[unsafe.Sizeof(RTL_DRIVE_LETTER_CURDIR64{})-0x18]
+	// RTL_USER_PROCESS_PARAMETERS
+	_ = x[unsafe.Offsetof(RTL_USER_PROCESS_PARAMETERS_FULL32{}.MaximumLength)-0x0]
+	_ = x[unsafe.Offsetof(RTL_USER_PROCESS_PARAMETERS_FULL32{}.Length)-0x4]
+	_ = x[unsafe.Offsetof(RTL_USER_PROCESS_PARAMETERS_FULL32{}.Flags)-0x8]
+	_ = x[unsafe.Offsetof(RTL_USER_PROCESS_PARAMETERS_FULL32{}.DebugFlags)-0xC]
+	_ = x[unsafe.Offsetof(RTL_USER_PROCESS_PARAMETERS_FULL32{}.ConsoleHandle)-0x10]
+	_ = x[unsafe.Offsetof(RTL_USER_PROCESS_PARAMETERS_FULL32{}.ConsoleFlags)-0x14]
+	_ = x[unsafe.Offsetof(RTL_USER_PROCESS_PARAMETERS_FULL32{}.StandardInput)-0x18]
+	_ = x[unsafe.Offsetof(RTL_USER_PROCESS_PARAMETERS_FULL32{}.StandardOutput)-0x1C]
+	_ = x[unsafe.Offsetof(RTL_USER_PROCESS_PARAMETERS_FULL32{}.StandardError)-0x20]
+	_ = x[unsafe.Offsetof(RTL_USER_PROCESS_PARAMETERS_FULL32{}.CurrentDirectory)-0x24]
+	_ = x[unsafe.Offsetof(RTL_USER_PROCESS_PARAMETERS_FULL32{}.DllPath)-0x30]
+	_ = x[unsafe.Offsetof(RTL_USER_PROCESS_PARAMETERS_FULL32{}.ImagePathName)-0x38]
+	_ = x[unsafe.Offsetof(RTL_USER_PROCESS_PARAMETERS_FULL32{}.CommandLine)-0x40]
+	_ = x[unsafe.Offsetof(RTL_USER_PROCESS_PARAMETERS_FULL32{}.Environment)-0x48]
+	_ = x[unsafe.Offsetof(RTL_USER_PROCESS_PARAMETERS_FULL32{}.StartingX)-0x4C]
+	_ = x[unsafe.Offsetof(RTL_USER_PROCESS_PARAMETERS_FULL32{}.StartingY)-0x50]
+	_ = x[unsafe.Offsetof(RTL_USER_PROCESS_PARAMETERS_FULL32{}.CountX)-0x54]
+	_ = x[unsafe.Offsetof(RTL_USER_PROCESS_PARAMETERS_FULL32{}.CountY)-0x58]
+	_ = x[unsafe.Offsetof(RTL_USER_PROCESS_PARAMETERS_FULL32{}.CountCharsX)-0x5C]
+	_ = x[unsafe.Offsetof(RTL_USER_PROCESS_PARAMETERS_FULL32{}.CountCharsY)-0x60]
+	_ = x[unsafe.Offsetof(RTL_USER_PROCESS_PARAMETERS_FULL32{}.FillAttribute)-0x64]
+	_ = x[unsafe.Offsetof(RTL_USER_PROCESS_PARAMETERS_FULL32{}.WindowFlags)-0x68]
+	_ = x[unsafe.Offsetof(RTL_USER_PROCESS_PARAMETERS_FULL32{}.ShowWindowFlags)-0x6C]
+	_ = x[unsafe.Offsetof(RTL_USER_PROCESS_PARAMETERS_FULL32{}.WindowTitle)-0x70]
+	_ = x[unsafe.Offsetof(RTL_USER_PROCESS_PARAMETERS_FULL32{}.DesktopInfo)-0x78]
+	_ = x[unsafe.Offsetof(RTL_USER_PROCESS_PARAMETERS_FULL32{}.ShellInfo)-0x80]
+	_ = x[unsafe.Offsetof(RTL_USER_PROCESS_PARAMETERS_FULL32{}.RuntimeData)-0x88]
+	_ = x[unsafe.Offsetof(RTL_USER_PROCESS_PARAMETERS_FULL32{}.CurrentDirectories)-0x90]
+	_ = x[unsafe.Offsetof(RTL_USER_PROCESS_PARAMETERS_FULL32{}.EnvironmentSize)-0x290]
+	_ = x[unsafe.Offsetof(RTL_USER_PROCESS_PARAMETERS_FULL32{}.EnvironmentVersion)-0x294]
+	_ = x[unsafe.Offsetof(RTL_USER_PROCESS_PARAMETERS_FULL32{}.PackageDependencyData)-0x298]
+	_ = x[unsafe.Offsetof(RTL_USER_PROCESS_PARAMETERS_FULL32{}.ProcessGroupId)-0x29C]
+	_ = x[unsafe.Offsetof(RTL_USER_PROCESS_PARAMETERS_FULL32{}.LoaderThreads)-0x2A0]
+	_ = x[unsafe.Sizeof(RTL_USER_PROCESS_PARAMETERS_FULL32{})-0x2A4]
+	// RTL_USER_PROCESS_PARAMETERS
+	_ = x[unsafe.Offsetof(RTL_USER_PROCESS_PARAMETERS_FULL64{}.MaximumLength)-0x0]
+	_ = x[unsafe.Offsetof(RTL_USER_PROCESS_PARAMETERS_FULL64{}.Length)-0x4]
+	_ = x[unsafe.Offsetof(RTL_USER_PROCESS_PARAMETERS_FULL64{}.Flags)-0x8]
+	_ = x[unsafe.Offsetof(RTL_USER_PROCESS_PARAMETERS_FULL64{}.DebugFlags)-0xC]
+	_ = x[unsafe.Offsetof(RTL_USER_PROCESS_PARAMETERS_FULL64{}.ConsoleHandle)-0x10]
+	_ = x[unsafe.Offsetof(RTL_USER_PROCESS_PARAMETERS_FULL64{}.ConsoleFlags)-0x18]
+	_ = x[unsafe.Offsetof(RTL_USER_PROCESS_PARAMETERS_FULL64{}.StandardInput)-0x20]
+	_ = x[unsafe.Offsetof(RTL_USER_PROCESS_PARAMETERS_FULL64{}.StandardOutput)-0x28]
+	_ = x[unsafe.Offsetof(RTL_USER_PROCESS_PARAMETERS_FULL64{}.StandardError)-0x30]
+	_ = x[unsafe.Offsetof(RTL_USER_PROCESS_PARAMETERS_FULL64{}.CurrentDirectory)-0x38]
+	_ = x[unsafe.Offsetof(RTL_USER_PROCESS_PARAMETERS_FULL64{}.DllPath)-0x50]
+	_ = x[unsafe.Offsetof(RTL_USER_PROCESS_PARAMETERS_FULL64{}.ImagePathName)-0x60]
+	_ = x[unsafe.Offsetof(RTL_USER_PROCESS_PARAMETERS_FULL64{}.CommandLine)-0x70]
+	_ = x[unsafe.Offsetof(RTL_USER_PROCESS_PARAMETERS_FULL64{}.Environment)-0x80]
+	_ = x[unsafe.Offsetof(RTL_USER_PROCESS_PARAMETERS_FULL64{}.StartingX)-0x88]
+	_ = x[unsafe.Offsetof(RTL_USER_PROCESS_PARAMETERS_FULL64{}.StartingY)-0x8C]
+	_ = x[unsafe.Offsetof(RTL_USER_PROCESS_PARAMETERS_FULL64{}.CountX)-0x90]
+	_ = x[unsafe.Offsetof(RTL_USER_PROCESS_PARAMETERS_FULL64{}.CountY)-0x94]
+	_ = x[unsafe.Offsetof(RTL_USER_PROCESS_PARAMETERS_FULL64{}.CountCharsX)-0x98]
+	_ = x[unsafe.Offsetof(RTL_USER_PROCESS_PARAMETERS_FULL64{}.CountCharsY)-0x9C]
+	_ = x[unsafe.Offsetof(RTL_USER_PROCESS_PARAMETERS_FULL64{}.FillAttribute)-0xA0]
+	_ = x[unsafe.Offsetof(RTL_USER_PROCESS_PARAMETERS_FULL64{}.WindowFlags)-0xA4]
+	_ = x[unsafe.Offsetof(RTL_USER_PROCESS_PARAMETERS_FULL64{}.ShowWindowFlags)-0xA8]
+	_ = x[unsafe.Offsetof(RTL_USER_PROCESS_PARAMETERS_FULL64{}.WindowTitle)-0xB0]
+	_ = x[unsafe.Offsetof(RTL_USER_PROCESS_PARAMETERS_FULL64{}.DesktopInfo)-0xC0]
+	_ = x[unsafe.Offsetof(RTL_USER_PROCESS_PARAMETERS_FULL64{}.ShellInfo)-0xD0]
+	_ = x[unsafe.Offsetof(RTL_USER_PROCESS_PARAMETERS_FULL64{}.RuntimeData)-0xE0]
+	_ = x[unsafe.Offsetof(RTL_USER_PROCESS_PARAMETERS_FULL64{}.CurrentDirectories)-0xF0]
+	_ = x[unsafe.Offsetof(RTL_USER_PROCESS_PARAMETERS_FULL64{}.EnvironmentSize)-0x3F0]
+	_ = x[unsafe.Offsetof(RTL_USER_PROCESS_PARAMETERS_FULL64{}.EnvironmentVersion)-0x3F8]
+	_ = x[unsafe.Offsetof(RTL_USER_PROCESS_PARAMETERS_FULL64{}.PackageDependencyData)-0x400]
+	_ = x[unsafe.Offsetof(RTL_USER_PROCESS_PARAMETERS_FULL64{}.ProcessGroupId)-0x408]
+	_ = x[unsafe.Offsetof(RTL_USER_PROCESS_PARAMETERS_FULL64{}.LoaderThreads)-0x40C]
+	_ = x[unsafe.Sizeof(RTL_USER_PROCESS_PARAMETERS_FULL64{})-0x410]
+	// LIST_ENTRY
+	_ = x[unsafe.Offsetof(LIST_ENTRY32{}.Flink)-0x0]
+	_ = x[unsafe.Offsetof(LIST_ENTRY32{}.Blink)-0x4]
+	_ = x[unsafe.Sizeof(LIST_ENTRY32{})-0x8]
+	// LIST_ENTRY
+	_ = x[unsafe.Offsetof(LIST_ENTRY64{}.Flink)-0x0]
+	_ = x[unsafe.Offsetof(LIST_ENTRY64{}.Blink)-0x8]
+	_ = x[unsafe.Sizeof(LIST_ENTRY64{})-0x10]
+	// PEB_LDR_DATA
+	_ = x[unsafe.Offsetof(PEB_LDR_DATA32{}.Length)-0x0]
+	_ = x[unsafe.Offsetof(PEB_LDR_DATA32{}.Initialized)-0x4]
+	_ = x[unsafe.Offsetof(PEB_LDR_DATA32{}.SsHandle)-0x8]
+	_ = x[unsafe.Offsetof(PEB_LDR_DATA32{}.InLoadOrderModuleList)-0xC]
+	_ = x[unsafe.Offsetof(PEB_LDR_DATA32{}.InMemoryOrderModuleList)-0x14]
+	_ = x[unsafe.Offsetof(PEB_LDR_DATA32{}.InInitializationOrderModuleList)-0x1C]
+	_ = x[unsafe.Sizeof(PEB_LDR_DATA32{})-0x24]
+	// PEB_LDR_DATA
+	_ = x[unsafe.Offsetof(PEB_LDR_DATA64{}.Length)-0x0]
+	_ = x[unsafe.Offsetof(PEB_LDR_DATA64{}.Initialized)-0x4]
+	_ = x[unsafe.Offsetof(PEB_LDR_DATA64{}.SsHandle)-0x8]
+	_ = x[unsafe.Offsetof(PEB_LDR_DATA64{}.InLoadOrderModuleList)-0x10]
+	_ = x[unsafe.Offsetof(PEB_LDR_DATA64{}.InMemoryOrderModuleList)-0x20]
+	_ = x[unsafe.Offsetof(PEB_LDR_DATA64{}.InInitializationOrderModuleList)-0x30]
+	_ = x[unsafe.Sizeof(PEB_LDR_DATA64{})-0x40]
+	// LDR_DATA_TABLE_ENTRY
+	_ = x[unsafe.Offsetof(LDR_DATA_TABLE_ENTRY32{}.InLoadOrderLinks)-0x0]
+	_ = x[unsafe.Offsetof(LDR_DATA_TABLE_ENTRY32{}.InMemoryOrderLinks)-0x8]
+	_ = x[unsafe.Offsetof(LDR_DATA_TABLE_ENTRY32{}.InInitializationOrderLinks)-0x10]
+	_ = x[unsafe.Offsetof(LDR_DATA_TABLE_ENTRY32{}.DllBase)-0x18]
+	_ = x[unsafe.Offsetof(LDR_DATA_TABLE_ENTRY32{}.EntryPoint)-0x1C]
+	_ = x[unsafe.Offsetof(LDR_DATA_TABLE_ENTRY32{}.SizeOfImage)-0x20]
+	_ = x[unsafe.Offsetof(LDR_DATA_TABLE_ENTRY32{}.FullDllName)-0x24]
+	_ = x[unsafe.Offsetof(LDR_DATA_TABLE_ENTRY32{}.BaseDllName)-0x2C]
+	_ = x[unsafe.Offsetof(LDR_DATA_TABLE_ENTRY32{}.Flags)-0x34]
+	_ = x[unsafe.Offsetof(LDR_DATA_TABLE_ENTRY32{}.ObsoleteLoadCount)-0x38]
+	_ = x[unsafe.Offsetof(LDR_DATA_TABLE_ENTRY32{}.TlsIndex)-0x3A]
+	_ = x[unsafe.Sizeof(LDR_DATA_TABLE_ENTRY32{})-0x3C]
+	// LDR_DATA_TABLE_ENTRY
+	_ = x[unsafe.Offsetof(LDR_DATA_TABLE_ENTRY64{}.InLoadOrderLinks)-0x0]
+	_ = x[unsafe.Offsetof(LDR_DATA_TABLE_ENTRY64{}.InMemoryOrderLinks)-0x10]
+	_ = x[unsafe.Offsetof(LDR_DATA_TABLE_ENTRY64{}.InInitializationOrderLinks)-0x20]
+	_ = x[unsafe.Offsetof(LDR_DATA_TABLE_ENTRY64{}.DllBase)-0x30]
+	_ = x[unsafe.Offsetof(LDR_DATA_TABLE_ENTRY64{}.EntryPoint)-0x38]
+	_ = x[unsafe.Offsetof(LDR_DATA_TABLE_ENTRY64{}.SizeOfImage)-0x40]
+	_ = x[unsafe.Offsetof(LDR_DATA_TABLE_ENTRY64{}.FullDllName)-0x48]
+	_ = x[unsafe.Offsetof(LDR_DATA_TABLE_ENTRY64{}.BaseDllName)-0x58]
+	_ = x[unsafe.Offsetof(LDR_DATA_TABLE_ENTRY64{}.Flags)-0x68]
+	_ = x[unsafe.Offsetof(LDR_DATA_TABLE_ENTRY64{}.ObsoleteLoadCount)-0x6C]
+	_ = x[unsafe.Offsetof(LDR_DATA_TABLE_ENTRY64{}.TlsIndex)-0x6E]
+	_ = x[unsafe.Sizeof(LDR_DATA_TABLE_ENTRY64{})-0x70]
+	// PROCESS_BASIC_INFORMATION
+	_ = x[unsafe.Offsetof(PROCESS_BASIC_INFORMATION{}.Reserved1)-0x0]
+	_ = x[unsafe.Offsetof(PROCESS_BASIC_INFORMATION{}.PebBaseAddress)-0x8]
+	_ = x[unsafe.Offsetof(PROCESS_BASIC_INFORMATION{}.Reserved2)-0x10]
+	_ = x[unsafe.Offsetof(PROCESS_BASIC_INFORMATION{}.UniqueProcessId)-0x20]
+	_ = x[unsafe.Offsetof(PROCESS_BASIC_INFORMATION{}.Reserved3)-0x28]
+	_ = x[unsafe.Sizeof(PROCESS_BASIC_INFORMATION{})-0x30]
+	// PROCESS_BASIC_INFORMATION
+	_ = x[unsafe.Offsetof(PROCESS_BASIC_INFORMATION64{}.Reserved1)-0x0]
+	_ = x[unsafe.Offsetof(PROCESS_BASIC_INFORMATION64{}.PebBaseAddress)-0x8]
+	_ = x[unsafe.Offsetof(PROCESS_BASIC_INFORMATION64{}.Reserved2)-0x10]
+	_ = x[unsafe.Offsetof(PROCESS_BASIC_INFORMATION64{}.UniqueProcessId)-0x20]
+	_ = x[unsafe.Offsetof(PROCESS_BASIC_INFORMATION64{}.Reserved3)-0x28]
+	_ = x[unsafe.Sizeof(PROCESS_BASIC_INFORMATION64{})-0x30]
+	// VM_COUNTERS_EX
+	_ = x[unsafe.Offsetof(VM_COUNTERS_EX{}.PeakVirtualSize)-0x0]
+	_ = x[unsafe.Offsetof(VM_COUNTERS_EX{}.VirtualSize)-0x8]
+	_ = x[unsafe.Offsetof(VM_COUNTERS_EX{}.PageFaultCount)-0x10]
+	_ = x[unsafe.Offsetof(VM_COUNTERS_EX{}.PeakWorkingSetSize)-0x18]
+	_ = x[unsafe.Offsetof(VM_COUNTERS_EX{}.WorkingSetSize)-0x20]
+	_ = x[unsafe.Offsetof(VM_COUNTERS_EX{}.QuotaPeakPagedPoolUsage)-0x28]
+	_ = x[unsafe.Offsetof(VM_COUNTERS_EX{}.QuotaPagedPoolUsage)-0x30]
+	_ = x[unsafe.Offsetof(VM_COUNTERS_EX{}.QuotaPeakNonPagedPoolUsage)-0x38]
+	_ = x[unsafe.Offsetof(VM_COUNTERS_EX{}.QuotaNonPagedPoolUsage)-0x40]
+	_ = x[unsafe.Offsetof(VM_COUNTERS_EX{}.PagefileUsage)-0x48]
+	_ = x[unsafe.Offsetof(VM_COUNTERS_EX{}.PeakPagefileUsage)-0x50]
+	_ = x[unsafe.Offsetof(VM_COUNTERS_EX{}.PrivateUsage)-0x58]
+	_ = x[unsafe.Sizeof(VM_COUNTERS_EX{})-0x60]
+	// KERNEL_USER_TIMES
+	_ = x[unsafe.Offsetof(KERNEL_USER_TIMES{}.CreateTime)-0x0]
+	_ = x[unsafe.Offsetof(KERNEL_USER_TIMES{}.ExitTime)-0x8]
+	_ = x[unsafe.Offsetof(KERNEL_USER_TIMES{}.KernelTime)-0x10]
+	_ = x[unsafe.Offsetof(KERNEL_USER_TIMES{}.UserTime)-0x18]
+	_ = x[unsafe.Sizeof(KERNEL_USER_TIMES{})-0x20]
+	// PROCESS_SESSION_INFORMATION
+	_ = x[unsafe.Offsetof(PROCESS_SESSION_INFORMATION{}.SessionId)-0x0]
+	_ = x[unsafe.Sizeof(PROCESS_SESSION_INFORMATION{})-0x4]
+	// PS_PROTECTION
+	_ = x[unsafe.Offsetof(PS_PROTECTION{}.Level)-0x0]
+	_ = x[unsafe.Sizeof(PS_PROTECTION{})-0x1]
+	// SYSTEM_PROCESS_INFORMATION
+	_ = x[unsafe.Offsetof(SYSTEM_PROCESS_INFORMATION{}.NextEntryOffset)-0x0]
+	_ = x[unsafe.Offsetof(SYSTEM_PROCESS_INFORMATION{}.NumberOfThreads)-0x4]
+	_ = x[unsafe.Offsetof(SYSTEM_PROCESS_INFORMATION{}.WorkingSetPrivateSize)-0x8]
+	_ = x[unsafe.Offsetof(SYSTEM_PROCESS_INFORMATION{}.HardFaultCount)-0x10]
+	_ = x[unsafe.Offsetof(SYSTEM_PROCESS_INFORMATION{}.NumberOfThreadsHighWatermark)-0x14]
+	_ = x[unsafe.Offsetof(SYSTEM_PROCESS_INFORMATION{}.CycleTime)-0x18]
+	_ = x[unsafe.Offsetof(SYSTEM_PROCESS_INFORMATION{}.CreateTime)-0x20]
+	_ = x[unsafe.Offsetof(SYSTEM_PROCESS_INFORMATION{}.UserTime)-0x28]
+	_ = x[unsafe.Offsetof(SYSTEM_PROCESS_INFORMATION{}.KernelTime)-0x30]
+	_ = x[unsafe.Offsetof(SYSTEM_PROCESS_INFORMATION{}.ImageName)-0x38]
+	_ = x[unsafe.Offsetof(SYSTEM_PROCESS_INFORMATION{}.BasePriority)-0x48]
+	_ = x[unsafe.Offsetof(SYSTEM_PROCESS_INFORMATION{}.UniqueProcessId)-0x50]
+	_ = x[unsafe.Offsetof(SYSTEM_PROCESS_INFORMATION{}.InheritedFromUniqueProcessId)-0x58]
+	_ = x[unsafe.Offsetof(SYSTEM_PROCESS_INFORMATION{}.HandleCount)-0x60]
+	_ = x[unsafe.Offsetof(SYSTEM_PROCESS_INFORMATION{}.SessionId)-0x64]
+	_ = x[unsafe.Offsetof(SYSTEM_PROCESS_INFORMATION{}.UniqueProcessKey)-0x68]
+	_ = x[unsafe.Offsetof(SYSTEM_PROCESS_INFORMATION{}.PeakVirtualSize)-0x70]
+	_ = x[unsafe.Offsetof(SYSTEM_PROCESS_INFORMATION{}.VirtualSize)-0x78]
+	_ = x[unsafe.Offsetof(SYSTEM_PROCESS_INFORMATION{}.PageFaultCount)-0x80]
+	_ = x[unsafe.Offsetof(SYSTEM_PROCESS_INFORMATION{}.PeakWorkingSetSize)-0x88]
+	_ = x[unsafe.Offsetof(SYSTEM_PROCESS_INFORMATION{}.WorkingSetSize)-0x90]
+	_ = x[unsafe.Offsetof(SYSTEM_PROCESS_INFORMATION{}.QuotaPeakPagedPoolUsage)-0x98]
+	_ = x[unsafe.Offsetof(SYSTEM_PROCESS_INFORMATION{}.QuotaPagedPoolUsage)-0xA0]
+	_ = x[unsafe.Offsetof(SYSTEM_PROCESS_INFORMATION{}.QuotaPeakNonPagedPoolUsage)-0xA8]
+	_ = x[unsafe.Offsetof(SYSTEM_PROCESS_INFORMATION{}.QuotaNonPagedPoolUsage)-0xB0]
+	_ = x[unsafe.Offsetof(SYSTEM_PROCESS_INFORMATION{}.PagefileUsage)-0xB8]
+	_ = x[unsafe.Offsetof(SYSTEM_PROCESS_INFORMATION{}.PeakPagefileUsage)-0xC0]
+	_ = x[unsafe.Offsetof(SYSTEM_PROCESS_INFORMATION{}.PrivatePageCount)-0xC8]
+	_ = x[unsafe.Offsetof(SYSTEM_PROCESS_INFORMATION{}.ReadOperationCount)-0xD0]
+	_ = x[unsafe.Offsetof(SYSTEM_PROCESS_INFORMATION{}.WriteOperationCount)-0xD8]
+	_ = x[unsafe.Offsetof(SYSTEM_PROCESS_INFORMATION{}.OtherOperationCount)-0xE0]
+	_ = x[unsafe.Offsetof(SYSTEM_PROCESS_INFORMATION{}.ReadTransferCount)-0xE8]
+	_ = x[unsafe.Offsetof(SYSTEM_PROCESS_INFORMATION{}.WriteTransferCount)-0xF0]
+	_ = x[unsafe.Offsetof(SYSTEM_PROCESS_INFORMATION{}.OtherTransferCount)-0xF8]
+	_ = x[unsafe.Sizeof(SYSTEM_PROCESS_INFORMATION{})-0x100]
+	// JOBOBJECT_BASIC_LIMIT_INFORMATION
+	_ = x[unsafe.Offsetof(JOBOBJECT_BASIC_LIMIT_INFORMATION{}.PerProcessUserTimeLimit)-0x0]
+	_ = x[unsafe.Offsetof(JOBOBJECT_BASIC_LIMIT_INFORMATION{}.PerJobUserTimeLimit)-0x8]
+	_ = x[unsafe.Offsetof(JOBOBJECT_BASIC_LIMIT_INFORMATION{}.LimitFlags)-0x10]
+	_ = x[unsafe.Offsetof(JOBOBJECT_BASIC_LIMIT_INFORMATION{}.MinimumWorkingSetSize)-0x18]
+	_ = x[unsafe.Offsetof(JOBOBJECT_BASIC_LIMIT_INFORMATION{}.MaximumWorkingSetSize)-0x20]
+	_ = x[unsafe.Offsetof(JOBOBJECT_BASIC_LIMIT_INFORMATION{}.ActiveProcessLimit)-0x28]
+	_ = x[unsafe.Offsetof(JOBOBJECT_BASIC_LIMIT_INFORMATION{}.Affinity)-0x30]
+	_ = x[unsafe.Offsetof(JOBOBJECT_BASIC_LIMIT_INFORMATION{}.PriorityClass)-0x38]
+	_ = x[unsafe.Offsetof(JOBOBJECT_BASIC_LIMIT_INFORMATION{}.SchedulingClass)-0x3C]
+	_ = x[unsafe.Sizeof(JOBOBJECT_BASIC_LIMIT_INFORMATION{})-0x40]
+	// IO_COUNTERS
+	_ = x[unsafe.Offsetof(IO_COUNTERS{}.ReadOperationCount)-0x0]
+	_ = x[unsafe.Offsetof(IO_COUNTERS{}.WriteOperationCount)-0x8]
+	_ = x[unsafe.Offsetof(IO_COUNTERS{}.OtherOperationCount)-0x10]
+	_ = x[unsafe.Offsetof(IO_COUNTERS{}.ReadTransferCount)-0x18]
+	_ = x[unsafe.Offsetof(IO_COUNTERS{}.WriteTransferCount)-0x20]
+	_ = x[unsafe.Offsetof(IO_COUNTERS{}.OtherTransferCount)-0x28]
+	_ = x[unsafe.Sizeof(IO_COUNTERS{})-0x30]
+	// JOBOBJECT_EXTENDED_LIMIT_INFORMATION
+	_ = x[unsafe.Offsetof(JOBOBJECT_EXTENDED_LIMIT_INFORMATION{}.BasicLimitInformation)-0x0]
+	_ = x[unsafe.Offsetof(JOBOBJECT_EXTENDED_LIMIT_INFORMATION{}.IoInfo)-0x40]
+	_ = x[unsafe.Offsetof(JOBOBJECT_EXTENDED_LIMIT_INFORMATION{}.ProcessMemoryLimit)-0x70]
+	_ = x[unsafe.Offsetof(JOBOBJECT_EXTENDED_LIMIT_INFORMATION{}.JobMemoryLimit)-0x78]
+	_ = x[unsafe.Offsetof(JOBOBJECT_EXTENDED_LIMIT_INFORMATION{}.PeakProcessMemoryUsed)-0x80]
+	_ = x[unsafe.Offsetof(JOBOBJECT_EXTENDED_LIMIT_INFORMATION{}.PeakJobMemoryUsed)-0x88]
+	_ = x[unsafe.Sizeof(JOBOBJECT_EXTENDED_LIMIT_INFORMATION{})-0x90]
+	// JOBOBJECT_CPU_RATE_CONTROL_INFORMATION
+	_ = x[unsafe.Offsetof(JOBOBJECT_CPU_RATE_CONTROL_INFORMATION{}.ControlFlags)-0x0]
+	_ = x[unsafe.Offsetof(JOBOBJECT_CPU_RATE_CONTROL_INFORMATION{}.CpuRate)-0x4]
+	_ = x[unsafe.Sizeof(JOBOBJECT_CPU_RATE_CONTROL_INFORMATION{})-0x8]
+	// JOBOBJECT_BASIC_ACCOUNTING_INFORMATION
+	_ = x[unsafe.Offsetof(JOBOBJECT_BASIC_ACCOUNTING_INFORMATION{}.TotalUserTime)-0x0]
+	_ = x[unsafe.Offsetof(JOBOBJECT_BASIC_ACCOUNTING_INFORMATION{}.TotalKernelTime)-0x8]
+	_ = x[unsafe.Offsetof(JOBOBJECT_BASIC_ACCOUNTING_INFORMATION{}.ThisPeriodTotalUserTime)-0x10]
+	_ = x[unsafe.Offsetof(JOBOBJECT_BASIC_ACCOUNTING_INFORMATION{}.ThisPeriodTotalKernelTime)-0x18]
+	_ = x[unsafe.Offsetof(JOBOBJECT_BASIC_ACCOUNTING_INFORMATION{}.TotalPageFaultCount)-0x20]
+	_ = x[unsafe.Offsetof(JOBOBJECT_BASIC_ACCOUNTING_INFORMATION{}.TotalProcesses)-0x24]
+	_ = x[unsafe.Offsetof(JOBOBJECT_BASIC_ACCOUNTING_INFORMATION{}.ActiveProcesses)-0x28]
+	_ = x[unsafe.Offsetof(JOBOBJECT_BASIC_ACCOUNTING_INFORMATION{}.TotalTerminatedProcesses)-0x2C]
+	_ = x[unsafe.Sizeof(JOBOBJECT_BASIC_ACCOUNTING_INFORMATION{})-0x30]
+	// JOBOBJECT_BASIC_AND_IO_ACCOUNTING_INFORMATION
+	_ = x[unsafe.Offsetof(JOBOBJECT_BASIC_AND_IO_ACCOUNTING_INFORMATION{}.BasicInfo)-0x0]
+	_ = x[unsafe.Offsetof(JOBOBJECT_BASIC_AND_IO_ACCOUNTING_INFORMATION{}.IoInfo)-0x30]
+	_ = x[unsafe.Sizeof(JOBOBJECT_BASIC_AND_IO_ACCOUNTING_INFORMATION{})-0x60]
+	// JOBOBJECT_ASSOCIATE_COMPLETION_PORT
+	_ = x[unsafe.Offsetof(JOBOBJECT_ASSOCIATE_COMPLETION_PORT{}.CompletionKey)-0x0]
+	_ = x[unsafe.Offsetof(JOBOBJECT_ASSOCIATE_COMPLETION_PORT{}.CompletionPort)-0x8]
+	_ = x[unsafe.Sizeof(JOBOBJECT_ASSOCIATE_COMPLETION_PORT{})-0x10]
+	// FWPM_DISPLAY_DATA0
+	_ = x[unsafe.Offsetof(FwpmDisplayData0{}.Name)-0x0]
+	_ = x[unsafe.Offsetof(FwpmDisplayData0{}.Description)-0x8]
+	_ = x[unsafe.Sizeof(FwpmDisplayData0{})-0x10]
+	// FWP_BYTE_BLOB
+	_ = x[unsafe.Offsetof(FwpByteBlob{}.Size)-0x0]
+	_ = x[unsafe.Offsetof(FwpByteBlob{}.Data)-0x8]
+	_ = x[unsafe.Sizeof(FwpByteBlob{})-0x10]
+	// FWP_VALUE0
+	_ = x[unsafe.Offsetof(FwpValue0{}.Type)-0x0]
+	_ = x[unsafe.Offsetof(FwpValue0{}.Data)-0x8]
+	_ = x[unsafe.Sizeof(FwpValue0{})-0x10]
+	// FWP_CONDITION_VALUE0
+	_ = x[unsafe.Offsetof(FwpConditionValue0{}.Type)-0x0]
+	_ = x[unsafe.Offsetof(FwpConditionValue0{}.Data)-0x8]
+	_ = x[unsafe.Sizeof(FwpConditionValue0{})-0x10]
+	// FWPM_FILTER_CONDITION0
+	_ = x[unsafe.Offsetof(FwpmFilterCondition0{}.FieldKey)-0x0]
+	_ = x[unsafe.Offsetof(FwpmFilterCondition0{}.MatchType)-0x10]
+	_ = x[unsafe.Offsetof(FwpmFilterCondition0{}.ConditionValue)-0x18]
+	_ = x[unsafe.Sizeof(FwpmFilterCondition0{})-0x28]
+	// FWPM_ACTION0
+	_ = x[unsafe.Offsetof(FwpmAction0{}.Type)-0x0]
+	_ = x[unsafe.Offsetof(FwpmAction0{}.FilterTypeOrCalloutKey)-0x4]
+	_ = x[unsafe.Sizeof(FwpmAction0{})-0x14]
+	// FWPM_SESSION0
+	_ = x[unsafe.Offsetof(FwpmSession0{}.SessionKey)-0x0]
+	_ = x[unsafe.Offsetof(FwpmSession0{}.DisplayData)-0x10]
+	_ = x[unsafe.Offsetof(FwpmSession0{}.Flags)-0x20]
+	_ = x[unsafe.Offsetof(FwpmSession0{}.TxnWaitTimeoutInMSec)-0x24]
+	_ = x[unsafe.Offsetof(FwpmSession0{}.ProcessId)-0x28]
+	_ = x[unsafe.Offsetof(FwpmSession0{}.Sid)-0x30]
+	_ = x[unsafe.Offsetof(FwpmSession0{}.Username)-0x38]
+	_ = x[unsafe.Offsetof(FwpmSession0{}.KernelMode)-0x40]
+	_ = x[unsafe.Sizeof(FwpmSession0{})-0x48]
+	// FWPM_SUBLAYER0
+	_ = x[unsafe.Offsetof(FwpmSublayer0{}.SubLayerKey)-0x0]
+	_ = x[unsafe.Offsetof(FwpmSublayer0{}.DisplayData)-0x10]
+	_ = x[unsafe.Offsetof(FwpmSublayer0{}.Flags)-0x20]
+	_ = x[unsafe.Offsetof(FwpmSublayer0{}.ProviderKey)-0x28]
+	_ = x[unsafe.Offsetof(FwpmSublayer0{}.ProviderData)-0x30]
+	_ = x[unsafe.Offsetof(FwpmSublayer0{}.Weight)-0x40]
+	_ = x[unsafe.Sizeof(FwpmSublayer0{})-0x48]
+	// FWPM_FILTER0
+	_ = x[unsafe.Offsetof(FwpmFilter0{}.FilterKey)-0x0]
+	_ = x[unsafe.Offsetof(FwpmFilter0{}.DisplayData)-0x10]
+	_ = x[unsafe.Offsetof(FwpmFilter0{}.Flags)-0x20]
+	_ = x[unsafe.Offsetof(FwpmFilter0{}.ProviderKey)-0x28]
+	_ = x[unsafe.Offsetof(FwpmFilter0{}.ProviderData)-0x30]
+	_ = x[unsafe.Offsetof(FwpmFilter0{}.LayerKey)-0x40]
+	_ = x[unsafe.Offsetof(FwpmFilter0{}.SubLayerKey)-0x50]
+	_ = x[unsafe.Offsetof(FwpmFilter0{}.Weight)-0x60]
+	_ = x[unsafe.Offsetof(FwpmFilter0{}.NumFilterConditions)-0x70]
+	_ = x[unsafe.Offsetof(FwpmFilter0{}.FilterCondition)-0x78]
+	_ = x[unsafe.Offsetof(FwpmFilter0{}.Action)-0x80]
+	_ = x[unsafe.Offsetof(FwpmFilter0{}.ProviderContextKey)-0x98]
+	_ = x[unsafe.Offsetof(FwpmFilter0{}.Reserved)-0xA8]
+	_ = x[unsafe.Offsetof(FwpmFilter0{}.FilterId)-0xB0]
+	_ = x[unsafe.Offsetof(FwpmFilter0{}.EffectiveWeight)-0xB8]
+	_ = x[unsafe.Sizeof(FwpmFilter0{})-0xC8]
+	// IP_ADAPTER_DNS_SUFFIX
+	_ = x[unsafe.Offsetof(IpAdapterDnsSuffix{}.Next)-0x0]
+	_ = x[unsafe.Offsetof(IpAdapterDnsSuffix{}.String)-0x8]
+	_ = x[unsafe.Sizeof(IpAdapterDnsSuffix{})-0x208]
+}
@@ -0,0 +1,40 @@
+package gowindows
+
+//go:generate go run mklayout.go
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// layout_*_test.go asserts the structures of layout.txt at compile time,
+// build the tests of the other architectures so that the assertions of them are checked on one system.
+// windows/arm64 is not built, golang.org/x/sys of go.mod does not support it.
+func TestLayoutCrossBuild(t *testing.T) {
+	if testing.Short() {
+		t.Skip("the cross build is slow")
+	}
+	gocmd := filepath.Join(runtime.GOROOT(), "bin", "go")
+	if _, err := os.Stat(gocmd); err != nil {
+		t.Skip(err)
+	}
+	dir, err := ioutil.TempDir("", "gowindows")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	for _, target := range []string{"linux/386", "linux/amd64", "linux/arm64", "windows/386", "windows/amd64"} {
+		platform := strings.Split(target, "/")
+		cmd := exec.Command(gocmd, "test", "-c", "-vet=off", "-o", filepath.Join(dir, platform[0]+"_"+platform[1]+".test"), ".")
+		cmd.Env = append(os.Environ(), "GOOS="+platform[0], "GOARCH="+platform[1], "CGO_ENABLED=0")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Errorf("%v: %v\n%s", target, err, out)
+		}
+	}
+}
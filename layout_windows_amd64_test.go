@@ -0,0 +1,94 @@
+// Code generated by mklayout.go from layout.txt; DO NOT EDIT.
+
+package gowindows
+
+import "unsafe"
+
+// The C layout on amd64, the index is out of bounds or overflows if the go structure is not the C layout.
+func _() {
+	var x [1]struct{}
+	// STARTUPINFOEXW
+	_ = x[unsafe.Offsetof(STARTUPINFOEX{}.StartupInfo)-0x0]
+	_ = x[unsafe.Offsetof(STARTUPINFOEX{}.AttributeList)-0x68]
+	_ = x[unsafe.Sizeof(STARTUPINFOEX{})-0x70]
+	// OSVERSIONINFOW
+	_ = x[unsafe.Offsetof(OsVsersionInfow{}.OSVersionInfoSize)-0x0]
+	_ = x[unsafe.Offsetof(OsVsersionInfow{}.MajorVersion)-0x4]
+	_ = x[unsafe.Offsetof(OsVsersionInfow{}.MinorVersion)-0x8]
+	_ = x[unsafe.Offsetof(OsVsersionInfow{}.BuildNumber)-0xC]
+	_ = x[unsafe.Offsetof(OsVsersionInfow{}.PlatformId)-0x10]
+	_ = x[unsafe.Offsetof(OsVsersionInfow{}.CSDVersion)-0x14]
+	_ = x[unsafe.Sizeof(OsVsersionInfow{})-0x114]
+	// OSVERSIONINFOEXW
+	_ = x[unsafe.Offsetof(OsVersionInfoExw{}.OsVsersionInfow)-0x0]
+	_ = x[unsafe.Offsetof(OsVersionInfoExw{}.ServicePackMajor)-0x114]
+	_ = x[unsafe.Offsetof(OsVersionInfoExw{}.ServicePackMinor)-0x116]
+	_ = x[unsafe.Offsetof(OsVersionInfoExw{}.SuiteMask)-0x118]
+	_ = x[unsafe.Offsetof(OsVersionInfoExw{}.ProductType)-0x11A]
+	_ = x[unsafe.Offsetof(OsVersionInfoExw{}.Reserved)-0x11B]
+	_ = x[unsafe.Sizeof(OsVersionInfoExw{})-0x11C]
+	// IP_ADAPTER_WINS_SERVER_ADDRESS_LH
+	_ = x[unsafe.Offsetof(IpAdapterWinsServerAddress{}.Length)-0x0]
+	_ = x[unsafe.Offsetof(IpAdapterWinsServerAddress{}.Reserved)-0x4]
+	_ = x[unsafe.Offsetof(IpAdapterWinsServerAddress{}.Next)-0x8]
+	_ = x[unsafe.Offsetof(IpAdapterWinsServerAddress{}.Address)-0x10]
+	_ = x[unsafe.Sizeof(IpAdapterWinsServerAddress{})-0x20]
+	// IP_ADAPTER_GATEWAY_ADDRESS_LH
+	_ = x[unsafe.Offsetof(IpAdapterGatewayAddress{}.Length)-0x0]
+	_ = x[unsafe.Offsetof(IpAdapterGatewayAddress{}.Reserved)-0x4]
+	_ = x[unsafe.Offsetof(IpAdapterGatewayAddress{}.Next)-0x8]
+	_ = x[unsafe.Offsetof(IpAdapterGatewayAddress{}.Address)-0x10]
+	_ = x[unsafe.Sizeof(IpAdapterGatewayAddress{})-0x20]
+	// IP_ADAPTER_ADDRESSES_LH
+	_ = x[unsafe.Offsetof(IpAdapterAddresses{}.Length)-0x0]
+	_ = x[unsafe.Offsetof(IpAdapterAddresses{}.IfIndex)-0x4]
+	_ = x[unsafe.Offsetof(IpAdapterAddresses{}.Next)-0x8]
+	_ = x[unsafe.Offsetof(IpAdapterAddresses{}.AdapterName)-0x10]
+	_ = x[unsafe.Offsetof(IpAdapterAddresses{}.FirstUnicastAddress)-0x18]
+	_ = x[unsafe.Offsetof(IpAdapterAddresses{}.FirstAnycastAddress)-0x20]
+	_ = x[unsafe.Offsetof(IpAdapterAddresses{}.FirstMulticastAddress)-0x28]
+	_ = x[unsafe.Offsetof(IpAdapterAddresses{}.FirstDnsServerAddress)-0x30]
+	_ = x[unsafe.Offsetof(IpAdapterAddresses{}.DnsSuffix)-0x38]
+	_ = x[unsafe.Offsetof(IpAdapterAddresses{}.Description)-0x40]
+	_ = x[unsafe.Offsetof(IpAdapterAddresses{}.FriendlyName)-0x48]
+	_ = x[unsafe.Offsetof(IpAdapterAddresses{}.PhysicalAddress)-0x50]
+	_ = x[unsafe.Offsetof(IpAdapterAddresses{}.PhysicalAddressLength)-0x58]
+	_ = x[unsafe.Offsetof(IpAdapterAddresses{}.Flags)-0x5C]
+	_ = x[unsafe.Offsetof(IpAdapterAddresses{}.Mtu)-0x60]
+	_ = x[unsafe.Offsetof(IpAdapterAddresses{}.IfType)-0x64]
+	_ = x[unsafe.Offsetof(IpAdapterAddresses{}.OperStatus)-0x68]
+	_ = x[unsafe.Offsetof(IpAdapterAddresses{}.ipv6IfIndex)-0x6C]
+	_ = x[unsafe.Offsetof(IpAdapterAddresses{}.zoneIndices)-0x70]
+	_ = x[unsafe.Offsetof(IpAdapterAddresses{}.firstPrefix)-0xB0]
+	_ = x[unsafe.Offsetof(IpAdapterAddresses{}.transmitLinkSpeed)-0xB8]
+	_ = x[unsafe.Offsetof(IpAdapterAddresses{}.receiveLinkSpeed)-0xC0]
+	_ = x[unsafe.Offsetof(IpAdapterAddresses{}.firstWinsServerAddress)-0xC8]
+	_ = x[unsafe.Offsetof(IpAdapterAddresses{}.firstGatewayAddress)-0xD0]
+	_ = x[unsafe.Offsetof(IpAdapterAddresses{}.ipv4Metric)-0xD8]
+	_ = x[unsafe.Offsetof(IpAdapterAddresses{}.ipv6Metric)-0xDC]
+	_ = x[unsafe.Offsetof(IpAdapterAddresses{}.luid)-0xE0]
+	_ = x[unsafe.Offsetof(IpAdapterAddresses{}.dhcpv4Server)-0xE8]
+	_ = x[unsafe.Offsetof(IpAdapterAddresses{}.compartmentId)-0xF8]
+	_ = x[unsafe.Offsetof(IpAdapterAddresses{}.networkGuid)-0xFC]
+	_ = x[unsafe.Offsetof(IpAdapterAddresses{}.connectionType)-0x10C]
+	_ = x[unsafe.Offsetof(IpAdapterAddresses{}.tunnelType)-0x110]
+	_ = x[unsafe.Offsetof(IpAdapterAddresses{}.dhcpv6Server)-0x118]
+	_ = x[unsafe.Offsetof(IpAdapterAddresses{}.dhcpv6ClientDuid)-0x128]
+	_ = x[unsafe.Offsetof(IpAdapterAddresses{}.dhcpv6ClientDuidLength)-0x1AC]
+	_ = x[unsafe.Offsetof(IpAdapterAddresses{}.dhcpv6Iaid)-0x1B0]
+	_ = x[unsafe.Offsetof(IpAdapterAddresses{}.firstDnsSuffix)-0x1B8]
+	_ = x[unsafe.Sizeof(IpAdapterAddresses{})-0x1C0]
+	// MIB_IPADDRROW_W2K
+	_ = x[unsafe.Offsetof(MibIpAddrRowW2k{}.Addr)-0x0]
+	_ = x[unsafe.Offsetof(MibIpAddrRowW2k{}.Index)-0x4]
+	_ = x[unsafe.Offsetof(MibIpAddrRowW2k{}.Mask)-0x8]
+	_ = x[unsafe.Offsetof(MibIpAddrRowW2k{}.BCastAddr)-0xC]
+	_ = x[unsafe.Offsetof(MibIpAddrRowW2k{}.ReasmSize)-0x10]
+	_ = x[unsafe.Offsetof(MibIpAddrRowW2k{}.Unused1)-0x14]
+	_ = x[unsafe.Offsetof(MibIpAddrRowW2k{}.Unused2)-0x16]
+	_ = x[unsafe.Sizeof(MibIpAddrRowW2k{})-0x18]
+	// MIB_IPADDRTABLE
+	_ = x[unsafe.Offsetof(MibIpAddrTable{}.NumEntries)-0x0]
+	_ = x[unsafe.Offsetof(MibIpAddrTable{}.Table)-0x4]
+	_ = x[unsafe.Sizeof(MibIpAddrTable{})-0x1C]
+}
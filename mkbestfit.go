@@ -0,0 +1,181 @@
+// +build ignore
+
+// Generate codepage_bestfit_table.go from the best fit tables of windows
+// https://www.unicode.org/Public/MAPPINGS/VENDORS/MICSFT/WindowsBestFit/
+//
+//	go run mkbestfit.go [dir of bestfit1252.txt, bestfit437.txt, bestfit866.txt, bestfit932.txt, bestfit936.txt and bestfit950.txt]
+//
+// The dir is bestfit by default, only the one way mappings of WCTABLE are generated.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var codePages = []struct {
+	number int
+	name   string
+}{
+	{1252, "CP_WINDOWS_1252"},
+	{437, "CP_IBM437"},
+	{866, "CP_IBM866"},
+	{932, "CP_SHIFT_JIS"},
+	{936, "CP_GBK"},
+	{950, "CP_BIG5"},
+}
+
+type mapping struct {
+	u uint16
+	v uint16
+}
+
+func parseHex(s string) (uint16, error) {
+	v, err := strconv.ParseUint(s, 0, 16)
+	return uint16(v), err
+}
+
+// The one way mappings of WCTABLE, the character is not decoded from the bytes it is encoded to.
+func parse(path string, number int) ([]mapping, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	decode := make(map[uint16]uint16)
+	var wc []mapping
+	section := ""
+	lead := -1
+	rest := 0
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		line := scanner.Text()
+		comment := ""
+		if i := strings.Index(line, ";"); i >= 0 {
+			line, comment = line[:i], line[i+1:]
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		if rest == 0 {
+			switch fields[0] {
+			case "CODEPAGE":
+				if len(fields) != 2 || fields[1] != strconv.Itoa(number) {
+					return nil, fmt.Errorf("%v:%v: code page is not %v", path, n, number)
+				}
+			case "CPINFO", "DBCSRANGE", "ENDCODEPAGE":
+			case "MBTABLE", "WCTABLE", "DBCSTABLE":
+				if len(fields) != 2 {
+					return nil, fmt.Errorf("%v:%v: invalid line %q", path, n, line)
+				}
+				if rest, err = strconv.Atoi(fields[1]); err != nil {
+					return nil, fmt.Errorf("%v:%v: %v", path, n, err)
+				}
+				section = fields[0]
+				if section == "DBCSTABLE" {
+					// DBCSTABLE 126 ;LeadByte = 0x81
+					i := strings.Index(comment, "=")
+					if i < 0 {
+						return nil, fmt.Errorf("%v:%v: no lead byte", path, n)
+					}
+					v, err := parseHex(strings.TrimSpace(comment[i+1:]))
+					if err != nil || v > 0xFF {
+						return nil, fmt.Errorf("%v:%v: invalid lead byte %q", path, n, comment)
+					}
+					lead = int(v)
+				}
+			default:
+				// The ranges of the lead bytes after DBCSRANGE
+				if len(fields) != 2 {
+					return nil, fmt.Errorf("%v:%v: invalid line %q", path, n, line)
+				}
+			}
+			continue
+		}
+
+		rest--
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("%v:%v: invalid line %q", path, n, line)
+		}
+		a, err := parseHex(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("%v:%v: %v", path, n, err)
+		}
+		b, err := parseHex(fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("%v:%v: %v", path, n, err)
+		}
+		switch section {
+		case "MBTABLE":
+			decode[a] = b
+		case "DBCSTABLE":
+			decode[uint16(lead)<<8|a] = b
+		case "WCTABLE":
+			wc = append(wc, mapping{a, b})
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if rest != 0 || len(decode) == 0 || len(wc) == 0 {
+		return nil, fmt.Errorf("%v: incomplete %v", path, section)
+	}
+
+	var result []mapping
+	for _, m := range wc {
+		if u, ok := decode[m.v]; ok && u == m.u {
+			continue
+		}
+		result = append(result, m)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].u < result[j].u
+	})
+	return result, nil
+}
+
+func main() {
+	dir := "bestfit"
+	if len(os.Args) > 1 {
+		dir = os.Args[1]
+	}
+
+	var b bytes.Buffer
+	b.WriteString("// Code generated by mkbestfit.go from the best fit tables of windows; DO NOT EDIT.\n\n")
+	b.WriteString("package gowindows\n\n")
+	b.WriteString("func init() {\n")
+	b.WriteString("\tcodePageBestFitTables = map[CodePage]map[uint16]uint16{\n")
+	for _, cp := range codePages {
+		mappings, err := parse(filepath.Join(dir, fmt.Sprintf("bestfit%v.txt", cp.number)), cp.number)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Fprintf(&b, "\t\t%s: {\n", cp.name)
+		for _, m := range mappings {
+			fmt.Fprintf(&b, "\t\t\t0x%04X: 0x%02X,\n", m.u, m.v)
+		}
+		b.WriteString("\t\t},\n")
+	}
+	b.WriteString("\t}\n")
+	b.WriteString("}\n")
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := ioutil.WriteFile("codepage_bestfit_table.go", src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,316 @@
+// +build ignore
+
+// Generate the layout assertions of layout_*_test.go from layout.txt
+//
+//	go run mklayout.go
+//
+// The C layout of the structure is computed by the MSVC rules for every architecture,
+// the offsets of layout.txt must be the same as it, so that a typo of layout.txt is not a passed test.
+// The generated files assert the go structure at compile time, go vet or go test fails to build
+// with "index out of bounds" or "overflows uintptr" if the go structure is not the C layout.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+)
+
+type arch struct {
+	name    string
+	ptrSize int64
+}
+
+// The order of the offsets of layout.txt
+var archs = []arch{{"386", 4}, {"amd64", 8}, {"arm64", 8}}
+
+type field struct {
+	line    int
+	name    string // the go field, - if the go structure has not the field
+	ctype   string
+	offsets []int64
+}
+
+type structure struct {
+	line    int
+	goName  string
+	cName   string
+	windows bool  // the go structure is only on windows
+	align   int64 // the minimum alignment, union { ULONGLONG Alignment; ... }
+	fields  []field
+	sizes   []int64
+}
+
+type spec struct {
+	path       string
+	typedefs   map[string]string
+	structures []*structure
+	byName     map[string]*structure
+}
+
+func parseOffsets(fields []string) ([]int64, error) {
+	if len(fields) != len(archs) {
+		return nil, fmt.Errorf("%v offsets, want %v", len(fields), len(archs))
+	}
+	result := make([]int64, len(fields))
+	for i, s := range fields {
+		n, err := strconv.ParseInt(s, 0, 64)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = n
+	}
+	return result, nil
+}
+
+func parse(path string) (*spec, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	s := &spec{path: path, typedefs: make(map[string]string), byName: make(map[string]*structure)}
+	var current *structure
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		switch {
+		case fields[0] == "typedef":
+			if current != nil || len(fields) != 3 {
+				return nil, fmt.Errorf("%v:%v: invalid typedef %q", path, n, line)
+			}
+			s.typedefs[fields[2]] = fields[1]
+		case fields[0] == "struct":
+			if current != nil || len(fields) < 3 {
+				return nil, fmt.Errorf("%v:%v: invalid struct %q", path, n, line)
+			}
+			if _, ok := s.byName[fields[1]]; ok {
+				return nil, fmt.Errorf("%v:%v: duplicate struct %v", path, n, fields[1])
+			}
+			current = &structure{line: n, goName: fields[1], cName: fields[2], align: 1}
+			for _, option := range fields[3:] {
+				switch {
+				case option == "windows":
+					current.windows = true
+				case strings.HasPrefix(option, "align="):
+					current.align, err = strconv.ParseInt(option[len("align="):], 10, 64)
+					if err != nil {
+						return nil, fmt.Errorf("%v:%v: %v", path, n, err)
+					}
+				default:
+					return nil, fmt.Errorf("%v:%v: invalid option %q", path, n, option)
+				}
+			}
+		case fields[0] == "size":
+			if current == nil {
+				return nil, fmt.Errorf("%v:%v: size without struct", path, n)
+			}
+			current.sizes, err = parseOffsets(fields[1:])
+			if err != nil {
+				return nil, fmt.Errorf("%v:%v: %v", path, n, err)
+			}
+			s.structures = append(s.structures, current)
+			s.byName[current.goName] = current
+			current = nil
+		default:
+			if current == nil || len(fields) < 2 {
+				return nil, fmt.Errorf("%v:%v: invalid line %q", path, n, line)
+			}
+			offsets, err := parseOffsets(fields[2:])
+			if err != nil {
+				return nil, fmt.Errorf("%v:%v: %v", path, n, err)
+			}
+			current.fields = append(current.fields, field{n, fields[0], fields[1], offsets})
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if current != nil {
+		return nil, fmt.Errorf("%v:%v: struct %v without size", path, current.line, current.goName)
+	}
+	return s, nil
+}
+
+func roundUp(n, align int64) int64 {
+	return (n + align - 1) / align * align
+}
+
+// The size and the alignment of the C type, the 8-byte integers are aligned to 8 bytes on 386 too.
+func (s *spec) sizeof(ctype string, a arch) (size, align int64, err error) {
+	if t, ok := s.typedefs[ctype]; ok {
+		return s.sizeof(t, a)
+	}
+	switch {
+	case strings.HasSuffix(ctype, "*"):
+		return a.ptrSize, a.ptrSize, nil
+	case strings.HasSuffix(ctype, "]"):
+		i := strings.LastIndex(ctype, "[")
+		if i < 0 {
+			return 0, 0, fmt.Errorf("invalid array %q", ctype)
+		}
+		n, err := strconv.ParseInt(ctype[i+1:len(ctype)-1], 10, 64)
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid array %q, %v", ctype, err)
+		}
+		size, align, err := s.sizeof(ctype[:i], a)
+		return size * n, align, err
+	case strings.HasPrefix(ctype, "union(") && strings.HasSuffix(ctype, ")"):
+		for _, member := range strings.Split(ctype[len("union("):len(ctype)-1], ",") {
+			memberSize, memberAlign, err := s.sizeof(member, a)
+			if err != nil {
+				return 0, 0, err
+			}
+			if memberSize > size {
+				size = memberSize
+			}
+			if memberAlign > align {
+				align = memberAlign
+			}
+		}
+		return roundUp(size, align), align, nil
+	case strings.HasPrefix(ctype, "struct(") && strings.HasSuffix(ctype, ")"):
+		align = 1
+		for _, member := range strings.Split(ctype[len("struct("):len(ctype)-1], ",") {
+			memberSize, memberAlign, err := s.sizeof(member, a)
+			if err != nil {
+				return 0, 0, err
+			}
+			size = roundUp(size, memberAlign) + memberSize
+			if memberAlign > align {
+				align = memberAlign
+			}
+		}
+		return roundUp(size, align), align, nil
+	}
+	if st, ok := s.byName[ctype]; ok {
+		_, size, align, err := s.layout(st, a)
+		return size, align, err
+	}
+
+	switch ctype {
+	case "BYTE", "UCHAR", "CHAR", "BOOLEAN", "UINT8", "INT8":
+		return 1, 1, nil
+	case "WCHAR", "USHORT", "WORD", "UINT16", "INT16":
+		return 2, 2, nil
+	case "ULONG", "LONG", "DWORD", "UINT32", "INT32", "INT", "BOOL":
+		return 4, 4, nil
+	case "ULONGLONG", "ULONG64", "UINT64", "LONGLONG", "INT64", "LARGE_INTEGER":
+		return 8, 8, nil
+	case "PVOID", "HANDLE", "SIZE_T", "ULONG_PTR", "PWSTR", "PWCHAR", "PCHAR":
+		return a.ptrSize, a.ptrSize, nil
+	case "PTR32":
+		return 4, 4, nil
+	case "PTR64":
+		return 8, 8, nil
+	case "GUID":
+		return 16, 4, nil
+	}
+	return 0, 0, fmt.Errorf("unknown type %q", ctype)
+}
+
+// The C offsets of the fields, the size and the alignment of the structure.
+func (s *spec) layout(st *structure, a arch) (offsets []int64, size, align int64, err error) {
+	align = st.align
+	for _, f := range st.fields {
+		fieldSize, fieldAlign, err := s.sizeof(f.ctype, a)
+		if err != nil {
+			return nil, 0, 0, fmt.Errorf("%v:%v: %v", s.path, f.line, err)
+		}
+		size = roundUp(size, fieldAlign)
+		offsets = append(offsets, size)
+		size += fieldSize
+		if fieldAlign > align {
+			align = fieldAlign
+		}
+	}
+	return offsets, roundUp(size, align), align, nil
+}
+
+// Compare the offsets of layout.txt with the C layout.
+func (s *spec) check() []string {
+	var errs []string
+	for _, st := range s.structures {
+		for i, a := range archs {
+			offsets, size, _, err := s.layout(st, a)
+			if err != nil {
+				errs = append(errs, err.Error())
+				continue
+			}
+			for j, f := range st.fields {
+				if f.offsets[i] != offsets[j] {
+					errs = append(errs, fmt.Sprintf("%v:%v: %v.%v on %v is 0x%X, not 0x%X",
+						s.path, f.line, st.goName, f.name, a.name, offsets[j], f.offsets[i]))
+				}
+			}
+			if st.sizes[i] != size {
+				errs = append(errs, fmt.Sprintf("%v:%v: sizeof(%v) on %v is 0x%X, not 0x%X",
+					s.path, st.line, st.goName, a.name, size, st.sizes[i]))
+			}
+		}
+	}
+	return errs
+}
+
+func generate(s *spec, i int, windows bool) ([]byte, error) {
+	a := archs[i]
+	var b bytes.Buffer
+	b.WriteString("// Code generated by mklayout.go from layout.txt; DO NOT EDIT.\n\n")
+	b.WriteString("package gowindows\n\n")
+	b.WriteString("import \"unsafe\"\n\n")
+	fmt.Fprintf(&b, "// The C layout on %v, the index is out of bounds or overflows if the go structure is not the C layout.\n", a.name)
+	b.WriteString("func _() {\n")
+	b.WriteString("\tvar x [1]struct{}\n")
+	for _, st := range s.structures {
+		if st.windows != windows {
+			continue
+		}
+		fmt.Fprintf(&b, "\t// %v\n", st.cName)
+		for _, f := range st.fields {
+			if f.name == "-" {
+				continue
+			}
+			fmt.Fprintf(&b, "\t_ = x[unsafe.Offsetof(%v{}.%v)-0x%X]\n", st.goName, f.name, f.offsets[i])
+		}
+		fmt.Fprintf(&b, "\t_ = x[unsafe.Sizeof(%v{})-0x%X]\n", st.goName, st.sizes[i])
+	}
+	b.WriteString("}\n")
+	return format.Source(b.Bytes())
+}
+
+func main() {
+	s, err := parse("layout.txt")
+	if err != nil {
+		log.Fatal(err)
+	}
+	if errs := s.check(); len(errs) != 0 {
+		log.Fatal("the offsets are not the C layout\n" + strings.Join(errs, "\n"))
+	}
+
+	for i, a := range archs {
+		for _, windows := range []bool{false, true} {
+			name := "layout_" + a.name + "_test.go"
+			if windows {
+				name = "layout_windows_" + a.name + "_test.go"
+			}
+			src, err := generate(s, i, windows)
+			if err != nil {
+				log.Fatal(err)
+			}
+			if err := ioutil.WriteFile(name, src, 0644); err != nil {
+				log.Fatal(err)
+			}
+		}
+	}
+}
@@ -0,0 +1,105 @@
+// +build ignore
+
+// Generate ntstatus_table.go from ntstatus.txt
+//
+//	go run mkntstatus.go
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type status struct {
+	value   uint32
+	name    string
+	win32   string
+	message string
+}
+
+func parse(path string) ([]status, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var result []status
+	names := make(map[string]bool)
+	values := make(map[uint32]bool)
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.SplitN(line, " ", 4)
+		if len(fields) != 4 {
+			return nil, fmt.Errorf("%v:%v: invalid line %q", path, n, line)
+		}
+		value, err := strconv.ParseUint(fields[0], 0, 32)
+		if err != nil {
+			return nil, fmt.Errorf("%v:%v: %v", path, n, err)
+		}
+		s := status{uint32(value), fields[1], fields[2], strings.TrimSpace(fields[3])}
+		if strings.HasPrefix(s.name, "STATUS_") == false {
+			return nil, fmt.Errorf("%v:%v: invalid name %q", path, n, s.name)
+		}
+		if s.win32 == "-" {
+			// ERROR_MR_MID_NOT_FOUND
+			s.win32 = "317"
+		} else if _, err := strconv.ParseUint(s.win32, 10, 16); err != nil {
+			return nil, fmt.Errorf("%v:%v: invalid win32 error %q", path, n, s.win32)
+		}
+		if names[s.name] || values[s.value] {
+			return nil, fmt.Errorf("%v:%v: duplicate %v 0x%08X", path, n, s.name, s.value)
+		}
+		names[s.name] = true
+		values[s.value] = true
+		result = append(result, s)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].value < result[j].value
+	})
+	return result, nil
+}
+
+func main() {
+	statuses, err := parse("ntstatus.txt")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var b bytes.Buffer
+	b.WriteString("// Code generated by mkntstatus.go from ntstatus.txt; DO NOT EDIT.\n\n")
+	b.WriteString("package gowindows\n\n")
+	b.WriteString("const (\n")
+	for _, s := range statuses {
+		fmt.Fprintf(&b, "\t%s NTStatus = 0x%08X\n", s.name, s.value)
+	}
+	b.WriteString(")\n\n")
+	b.WriteString("var ntStatusTable = map[NTStatus]ntStatusInfo{\n")
+	for _, s := range statuses {
+		fmt.Fprintf(&b, "\t%s: {%q, %s, %q},\n", s.name, s.name, s.win32, s.message)
+	}
+	b.WriteString("}\n")
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := ioutil.WriteFile("ntstatus_table.go", src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
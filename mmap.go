@@ -1,21 +1,67 @@
 package gowindows
 
-import "C"
+import "fmt"
+
+// Permission of the section object
+const (
+	SECTION_QUERY       = 0x0001
+	SECTION_MAP_WRITE   = 0x0002
+	SECTION_MAP_READ    = 0x0004
+	SECTION_MAP_EXECUTE = 0x0008
+)
+
+const maxInt = int(^uint(0) >> 1)
 
 type Mmap struct {
 	fileHandle Handle
-	addr       uintptr
-	size       int
+	// The view starts at addr, the requested offset is at addr+delta
+	addr  uintptr
+	size  int
+	delta int
+
+	// The file of the file backed mapping, linux uses fileHandle for both.
+	file   Handle
+	offset int64
+	write  bool
+
+	// linux only, the file path of the named object
+	path string
 }
 
 func (m *Mmap) GetHandle() Handle {
 	return m.fileHandle
 }
 
+// The bytes of the view, it becomes invalid after Grow and Close.
 func (m *Mmap) GetBytes() []byte {
 	if m.size == 0 || m.addr == uintptr(0) {
 		return nil
 	}
 
-	return ToBytes(m.addr, m.size, m.size)
+	return ToBytes(m.addr+uintptr(m.delta), m.size-m.delta, m.size-m.delta)
+}
+
+// Offset of the view in the file or the named object
+func (m *Mmap) Offset() int64 {
+	return m.offset
+}
+
+// Length of the view
+func (m *Mmap) Len() int64 {
+	return int64(m.size - m.delta)
+}
+
+// The view must start at a multiple of the allocation granularity,
+// returns the aligned offset and the size of the view.
+func alignView(offset, length int64, granularity int) (alignedOffset int64, viewSize int, err error) {
+	if offset < 0 || length <= 0 {
+		return 0, 0, fmt.Errorf("invalid offset %v or length %v", offset, length)
+	}
+
+	alignedOffset = offset - offset%int64(granularity)
+	size := length + offset - alignedOffset
+	if size > int64(maxInt) {
+		return 0, 0, fmt.Errorf("length %v is too large", length)
+	}
+	return alignedOffset, int(size), nil
 }
@@ -0,0 +1,372 @@
+package gowindows
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+// The linux implementation of the named memory share
+// The named object is a file in /dev/shm (the same as shm_open).
+// Global\name is mapped to /dev/shm/gowindows.global.name, which is visible to all users,
+// Local\name and name are mapped to /dev/shm/gowindows.<uid>.name, which is visible to the current user,
+// the empty name uses memfd.
+// The file holds a shared flock while it is opened, and is deleted when the last Mmap is closed,
+// so the life cycle is the same as the file mapping object of windows.
+var shmDir = "/dev/shm"
+
+// The file path of the named object
+func shmPath(name string) string {
+	scope := strconv.Itoa(os.Getuid())
+	switch {
+	case strings.HasPrefix(name, `Global\`):
+		scope = "global"
+		name = name[len(`Global\`):]
+	case strings.HasPrefix(name, `Local\`):
+		name = name[len(`Local\`):]
+	}
+	return filepath.Join(shmDir, "gowindows."+scope+"."+url.PathEscape(name))
+}
+
+// Create no-file named memory share
+func CreateMmap(name string, size uint32, write bool) (*Mmap, error) {
+	return CreateMmapWithSecurityDescriptor(name, size, write, "")
+}
+
+// Create with specified permissions
+// Only the DACL of the security descriptor is used, it is mapped to the file mode by SecurityDescriptorInfo.UnixMode,
+// the owner always has read and write permission.
+func CreateMmapWithSecurityDescriptor(name string, size uint32, write bool, securityDescriptor string) (*Mmap, error) {
+	return CreateMmap64(name, int64(size), write, securityDescriptor)
+}
+
+// The same as CreateMmapWithSecurityDescriptor, but the size is 64-bit
+func CreateMmap64(name string, size int64, write bool, securityDescriptor string) (*Mmap, error) {
+	if size <= 0 {
+		return nil, fmt.Errorf("size<=0")
+	}
+
+	sd, err := ParseSDDL(securityDescriptor)
+	if err != nil {
+		return nil, fmt.Errorf("ParseSDDL, %w", err)
+	}
+	mode := sd.UnixMode(SECTION_MAP_READ, SECTION_MAP_WRITE, SECTION_MAP_EXECUTE)&0666 | 0600
+
+	var fd int
+	var path string
+	if name == "" {
+		fd, err = unix.MemfdCreate("gowindows", unix.MFD_CLOEXEC)
+		if err != nil {
+			return nil, fmt.Errorf("memfd_create, %w", err)
+		}
+		if err := unix.Ftruncate(fd, size); err != nil {
+			unix.Close(fd)
+			return nil, fmt.Errorf("ftruncate, %w", err)
+		}
+	} else {
+		path = shmPath(name)
+		fd, err = shmOpen(path, true, size, mode, write)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return newMmap(fd, path, 0, size, write)
+}
+
+// Turn on no-name memory sharing
+func OpenMmap(name string, size uint32, write bool) (*Mmap, error) {
+	return OpenMmap64(name, 0, int64(size), write)
+}
+
+// Open the partial view of the named memory share, offset does not need to be aligned.
+func OpenMmap64(name string, offset, length int64, write bool) (*Mmap, error) {
+	if length <= 0 {
+		return nil, fmt.Errorf("length<=0")
+	}
+	if name == "" {
+		return nil, fmt.Errorf("name is empty")
+	}
+
+	path := shmPath(name)
+	fd, err := shmOpen(path, false, offset+length, 0, write)
+	if err != nil {
+		return nil, err
+	}
+
+	return newMmap(fd, path, offset, length, write)
+}
+
+// Map the file
+// offset does not need to be aligned, length == 0 means to the end of the file.
+// With write, the file is created if it does not exist, and extended if it is smaller than offset+length.
+func OpenFileMmap(path string, offset, length int64, write bool) (*Mmap, error) {
+	if offset < 0 || length < 0 {
+		return nil, fmt.Errorf("invalid offset %v or length %v", offset, length)
+	}
+
+	flags := unix.O_RDONLY
+	if write {
+		flags = unix.O_RDWR | unix.O_CREAT
+	}
+	fd, err := unix.Open(path, flags|unix.O_CLOEXEC, 0666)
+	if err != nil {
+		return nil, fmt.Errorf("open %v, %w", path, err)
+	}
+
+	var st unix.Stat_t
+	if err := unix.Fstat(fd, &st); err != nil {
+		unix.Close(fd)
+		return nil, fmt.Errorf("fstat %v, %w", path, err)
+	}
+
+	if length == 0 {
+		length = st.Size - offset
+		if length <= 0 {
+			unix.Close(fd)
+			return nil, fmt.Errorf("offset %v >= file size %v", offset, st.Size)
+		}
+	}
+	if offset+length > st.Size {
+		if write == false {
+			unix.Close(fd)
+			return nil, fmt.Errorf("offset %v + length %v > file size %v", offset, length, st.Size)
+		}
+		if err := unix.Ftruncate(fd, offset+length); err != nil {
+			unix.Close(fd)
+			return nil, fmt.Errorf("ftruncate %v, %w", path, err)
+		}
+	}
+
+	m, err := newMmap(fd, "", offset, length, write)
+	if err != nil {
+		return nil, err
+	}
+	m.file = Handle(fd)
+	return m, nil
+}
+
+// Open the file and hold the shared lock
+// The file is recreated if it is deleted by the last Close while waiting for the lock.
+// A file without any lock holder is left over by a crashed process, it is deleted and treated as nonexistent.
+func shmOpen(path string, create bool, size int64, mode uint32, write bool) (int, error) {
+	flags := unix.O_RDONLY
+	if write {
+		flags = unix.O_RDWR
+	}
+
+	for {
+		if create {
+			fd, err := unix.Open(path, unix.O_RDWR|unix.O_CREAT|unix.O_EXCL|unix.O_CLOEXEC, mode)
+			if err == nil {
+				if err := shmInit(fd, path, size, mode); err != nil {
+					unix.Close(fd)
+					unix.Unlink(path)
+					return -1, err
+				}
+				if shmSameFile(fd, path) == false {
+					unix.Close(fd)
+					continue
+				}
+				return fd, nil
+			}
+			if err != unix.EEXIST {
+				return -1, fmt.Errorf("open %v, %w", path, err)
+			}
+		}
+
+		fd, err := unix.Open(path, flags|unix.O_CLOEXEC, 0)
+		if err != nil {
+			if err == unix.ENOENT && create {
+				continue
+			}
+			return -1, fmt.Errorf("open %v, %w", path, err)
+		}
+
+		if err := unix.Flock(fd, unix.LOCK_EX|unix.LOCK_NB); err == nil {
+			// No other holder, it is left over by a crashed process
+			if shmSameFile(fd, path) {
+				unix.Unlink(path)
+			}
+			unix.Close(fd)
+			if create {
+				continue
+			}
+			return -1, fmt.Errorf("open %v, %v", path, unix.ENOENT)
+		}
+
+		if err := unix.Flock(fd, unix.LOCK_SH); err != nil {
+			unix.Close(fd)
+			return -1, fmt.Errorf("flock %v, %w", path, err)
+		}
+
+		if shmSameFile(fd, path) == false {
+			unix.Close(fd)
+			continue
+		}
+
+		var st unix.Stat_t
+		if err := unix.Fstat(fd, &st); err != nil {
+			unix.Close(fd)
+			return -1, fmt.Errorf("fstat %v, %w", path, err)
+		}
+		if st.Size < size {
+			unix.Close(fd)
+			return -1, fmt.Errorf("%v size %v < %v", path, st.Size, size)
+		}
+		return fd, nil
+	}
+}
+
+// Initialize the new file under the exclusive lock, then downgrade to the shared lock.
+func shmInit(fd int, path string, size int64, mode uint32) error {
+	if err := unix.Flock(fd, unix.LOCK_EX); err != nil {
+		return fmt.Errorf("flock %v, %w", path, err)
+	}
+	// The mode of open is affected by umask
+	if err := unix.Fchmod(fd, mode); err != nil {
+		return fmt.Errorf("fchmod %v, %w", path, err)
+	}
+	if err := unix.Ftruncate(fd, size); err != nil {
+		return fmt.Errorf("ftruncate %v, %w", path, err)
+	}
+	if err := unix.Flock(fd, unix.LOCK_SH); err != nil {
+		return fmt.Errorf("flock %v, %w", path, err)
+	}
+	return nil
+}
+
+// Whether path still points to the opened file
+func shmSameFile(fd int, path string) bool {
+	var st1, st2 unix.Stat_t
+	if unix.Fstat(fd, &st1) != nil || unix.Stat(path, &st2) != nil {
+		return false
+	}
+	return st1.Dev == st2.Dev && st1.Ino == st2.Ino
+}
+
+// The view of mmap must start at a multiple of the page size.
+func AllocationGranularity() int {
+	return os.Getpagesize()
+}
+
+func newMmap(fd int, path string, offset, length int64, write bool) (*Mmap, error) {
+	m := &Mmap{fileHandle: Handle(fd), path: path, write: write}
+	if err := m.mapView(offset, length); err != nil {
+		shmClose(fd, path)
+		return nil, err
+	}
+
+	runtime.SetFinalizer(m, (*Mmap).Close)
+
+	return m, nil
+}
+
+func (m *Mmap) mapView(offset, length int64) error {
+	alignedOffset, viewSize, err := alignView(offset, length, AllocationGranularity())
+	if err != nil {
+		return err
+	}
+
+	prot := unix.PROT_READ
+	if m.write {
+		prot |= unix.PROT_WRITE
+	}
+
+	b, err := unix.Mmap(int(m.fileHandle), alignedOffset, viewSize, prot, unix.MAP_SHARED)
+	if err != nil {
+		return fmt.Errorf("mmap, %w", err)
+	}
+
+	m.addr = uintptr(unsafe.Pointer(&b[0]))
+	m.size = viewSize
+	m.delta = int(offset - alignedOffset)
+	m.offset = offset
+	return nil
+}
+
+// Write the dirty pages of the view to the file
+func (m *Mmap) Flush() error {
+	if m.addr == uintptr(0) {
+		return fmt.Errorf("mmap is closed")
+	}
+
+	if err := unix.Msync(ToBytes(m.addr, m.size, m.size), unix.MS_SYNC); err != nil {
+		return fmt.Errorf("msync, %w", err)
+	}
+	if m.file != 0 && m.write {
+		if err := unix.Fsync(int(m.file)); err != nil {
+			return fmt.Errorf("fsync, %w", err)
+		}
+	}
+	return nil
+}
+
+// Grow the view of the file backed mapping to length, the file is extended if necessary.
+// The view is remapped, the bytes returned by GetBytes before become invalid.
+func (m *Mmap) Grow(length int64) error {
+	if m.file == 0 || m.write == false {
+		return fmt.Errorf("only the writable file backed mapping can grow")
+	}
+	if length <= m.Len() {
+		return nil
+	}
+
+	var st unix.Stat_t
+	if err := unix.Fstat(int(m.file), &st); err != nil {
+		return fmt.Errorf("fstat, %w", err)
+	}
+	if m.offset+length > st.Size {
+		if err := unix.Ftruncate(int(m.file), m.offset+length); err != nil {
+			return fmt.Errorf("ftruncate, %w", err)
+		}
+	}
+
+	if err := unix.Munmap(ToBytes(m.addr, m.size, m.size)); err != nil {
+		return fmt.Errorf("munmap, %w", err)
+	}
+	m.addr = 0
+	m.size = 0
+
+	return m.mapView(m.offset, length)
+}
+
+// Delete the file if it is the last holder
+func shmClose(fd int, path string) error {
+	if path != "" && unix.Flock(fd, unix.LOCK_EX|unix.LOCK_NB) == nil && shmSameFile(fd, path) {
+		unix.Unlink(path)
+	}
+	return unix.Close(fd)
+}
+
+// close and release
+func (m *Mmap) Close() error {
+	if m.addr != uintptr(0) {
+		err := unix.Munmap(ToBytes(m.addr, m.size, m.size))
+		if err != nil {
+			return fmt.Errorf("munmap, %w", err)
+		}
+		m.size = 0
+		m.addr = uintptr(0)
+	}
+
+	if m.fileHandle != 0 {
+		err := shmClose(int(m.fileHandle), m.path)
+		if err != nil {
+			return fmt.Errorf("close, %w", err)
+		}
+		m.size = 0
+		m.fileHandle = 0
+		m.file = 0
+	}
+
+	runtime.KeepAlive(m)
+	return nil
+}
@@ -0,0 +1,189 @@
+package gowindows
+
+import (
+	"bytes"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+func TestAll(t *testing.T) {
+	m1, err := CreateMmap("name111111", 4096, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m2, err := CreateMmap("name111111", 4096, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m3, err := OpenMmap("name111111", 4096, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b1 := m1.GetBytes()
+	b2 := m2.GetBytes()
+	b3 := m3.GetBytes()
+
+	b := []byte{1, 4, 7, 8, 5, 2, 9, 6, 3}
+	copy(b1, b)
+
+	if v := b2[:len(b)]; bytes.Equal(v, b) == false {
+		t.Errorf("%#v!=%#v", v, b)
+	}
+	if v := b3[:len(b)]; bytes.Equal(v, b) == false {
+		t.Errorf("%#v!=%#v", v, b)
+	}
+
+	for _, m := range []*Mmap{m1, m2, m3} {
+		if err := m.Close(); err != nil {
+			t.Error(err)
+		}
+	}
+
+	// The object is deleted after the last close
+	if _, err := os.Stat(shmPath("name111111")); os.IsNotExist(err) == false {
+		t.Errorf("%v exists, %v", shmPath("name111111"), err)
+	}
+	if _, err := OpenMmap("name111111", 4096, false); err == nil {
+		t.Error("OpenMmap == nil")
+	}
+}
+
+func TestCreateMmapAndOpenAndClose(t *testing.T) {
+	const name = "name111111"
+	m1, err := CreateMmap(name, 4096, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer m1.Close()
+
+	wg := sync.WaitGroup{}
+	errs := make(chan error, 100)
+
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			var m *Mmap
+			var err error
+
+			time.Sleep(time.Duration(rand.Int31n(100)) * time.Millisecond)
+
+			if rand.Int31n(2) == 1 {
+				m, err = CreateMmap(name, 4096, true)
+			} else {
+				m, err = OpenMmap(name, 4096, true)
+			}
+			if err != nil {
+				errs <- err
+				return
+			}
+
+			time.Sleep(time.Duration(rand.Int31n(100)) * time.Millisecond)
+
+			if err := m.Close(); err != nil {
+				errs <- err
+			}
+		}()
+	}
+
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+}
+
+func TestMmap_sizeAndNames(t *testing.T) {
+	m1, err := CreateMmap(`Global\gowindows_test_size`, 1024, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer m1.Close()
+
+	if _, err := OpenMmap(`Global\gowindows_test_size`, 4096, true); err == nil {
+		t.Error("OpenMmap larger size == nil")
+	}
+	if _, err := OpenMmap(`gowindows_test_size`, 1024, true); err == nil {
+		t.Error("OpenMmap different scope == nil")
+	}
+
+	if v := shmPath(`Local\a/b`); v != shmPath(`a/b`) {
+		t.Errorf("%v!=%v", v, shmPath(`a/b`))
+	}
+
+	// No name
+	m2, err := CreateMmap("", 1024, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m2.GetBytes()[1023] = 1
+	if err := m2.Close(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCreateMmapWithSecurityDescriptor(t *testing.T) {
+	for _, v := range []struct {
+		sddl string
+		mode uint32
+	}{
+		{"", 0600},
+		{"D:P(A;OICI;GWGR;;;SY)(A;OICI;GWGR;;;BA)(A;OICI;GWGR;;;IU)(A;OICI;GWGR;;;RC)", 0666},
+		{"D:(A;;GR;;;WD)", 0644},
+		{"D:(A;;GA;;;OW)(A;;GR;;;CG)", 0640},
+	} {
+		m, err := CreateMmapWithSecurityDescriptor("gowindows_test_sd", 1024, true, v.sddl)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var st unix.Stat_t
+		if err := unix.Fstat(int(m.GetHandle()), &st); err != nil {
+			t.Fatal(err)
+		}
+		if mode := st.Mode & 0777; mode != v.mode {
+			t.Errorf("%v: %o!=%o", v.sddl, mode, v.mode)
+		}
+
+		m.Close()
+	}
+}
+
+func TestOpenMmap_stale(t *testing.T) {
+	// Left over by a crashed process, nobody holds the lock
+	path := shmPath("gowindows_test_stale")
+	if err := ioutil.WriteFile(path, make([]byte, 1024), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := OpenMmap("gowindows_test_stale", 1024, true); err == nil {
+		t.Error("OpenMmap stale == nil")
+	}
+	if _, err := os.Stat(path); os.IsNotExist(err) == false {
+		t.Errorf("%v exists, %v", path, err)
+	}
+
+	if err := ioutil.WriteFile(path, []byte{1, 2, 3}, 0600); err != nil {
+		t.Fatal(err)
+	}
+	m, err := CreateMmap("gowindows_test_stale", 1024, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer m.Close()
+
+	if b := m.GetBytes(); b[0] != 0 {
+		t.Errorf("content of the stale object is reserved, %v", b[:3])
+	}
+}
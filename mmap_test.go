@@ -1 +1,148 @@
 package gowindows
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestAlignView(t *testing.T) {
+	for _, v := range []struct {
+		offset, length int64
+		aligned        int64
+		size           int
+	}{
+		{0, 10, 0, 10},
+		{4096, 10, 4096, 10},
+		{4097, 10, 4096, 11},
+		{8191, 4096, 4096, 8191},
+	} {
+		aligned, size, err := alignView(v.offset, v.length, 4096)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if aligned != v.aligned || size != v.size {
+			t.Errorf("%v %v: %v %v!=%v %v", v.offset, v.length, aligned, size, v.aligned, v.size)
+		}
+	}
+
+	if _, _, err := alignView(-1, 10, 4096); err == nil {
+		t.Error("offset -1 == nil")
+	}
+	if _, _, err := alignView(0, 0, 4096); err == nil {
+		t.Error("length 0 == nil")
+	}
+}
+
+func TestOpenFileMmap(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gowindows")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "mmap")
+
+	granularity := AllocationGranularity()
+	offset := int64(granularity + 3)
+
+	// Create and extend the file
+	m, err := OpenFileMmap(path, offset, 10, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Offset() != offset || m.Len() != 10 || len(m.GetBytes()) != 10 {
+		t.Errorf("%v %v %v", m.Offset(), m.Len(), len(m.GetBytes()))
+	}
+	copy(m.GetBytes(), "0123456789")
+	if err := m.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Grow keeps the content
+	if err := m.Grow(int64(granularity) * 2); err != nil {
+		t.Fatal(err)
+	}
+	b := m.GetBytes()
+	if len(b) != granularity*2 || string(b[:10]) != "0123456789" {
+		t.Errorf("%v %q", len(b), b[:10])
+	}
+	b[len(b)-1] = 'x'
+	if err := m.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if int64(len(data)) != offset+int64(granularity)*2 {
+		t.Errorf("file size %v!=%v", len(data), offset+int64(granularity)*2)
+	}
+	if string(data[offset:offset+10]) != "0123456789" || data[len(data)-1] != 'x' {
+		t.Errorf("%q", data[offset:offset+10])
+	}
+
+	// Read only, to the end of the file
+	m, err = OpenFileMmap(path, offset, 0, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := m.GetBytes(); bytes.Equal(v, data[offset:]) == false {
+		t.Errorf("content mismatch")
+	}
+	if err := m.Grow(int64(granularity) * 4); err == nil {
+		t.Error("read only Grow == nil")
+	}
+	m.Close()
+
+	if _, err := OpenFileMmap(path, int64(len(data)), 1, false); err == nil {
+		t.Error("read only out of range == nil")
+	}
+	if _, err := OpenFileMmap(filepath.Join(dir, "none"), 0, 0, false); err == nil {
+		t.Error("not exist == nil")
+	}
+}
+
+func TestOpenMmap64(t *testing.T) {
+	name := fmt.Sprintf("gowindows_test_mmap64_%v", time.Now().UnixNano())
+	granularity := int64(AllocationGranularity())
+
+	m1, err := CreateMmap64(name, granularity*3, true, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer m1.Close()
+	copy(m1.GetBytes()[granularity+5:], "hello")
+
+	m2, err := OpenMmap64(name, granularity+5, 5, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer m2.Close()
+	if v := string(m2.GetBytes()); v != "hello" {
+		t.Errorf("%v!=hello", v)
+	}
+
+	if err := m1.Grow(granularity * 4); err == nil {
+		t.Error("named Grow == nil")
+	}
+	if err := m2.Flush(); err != nil {
+		t.Error(err)
+	}
+}
+
+// The wrappers keep the error of the system in the chain
+func TestOpenMmap_notExist(t *testing.T) {
+	name := fmt.Sprintf("gowindows_test_mmap_none_%v", time.Now().UnixNano())
+	if _, err := OpenMmap(name, 4096, false); errors.Is(err, os.ErrNotExist) == false {
+		t.Errorf("%v is not %v", err, os.ErrNotExist)
+	}
+}
@@ -1,15 +1,18 @@
 package gowindows
 
-import "C"
 import (
 	"fmt"
 	"runtime"
 	"syscall"
-	"unsafe"
 
 	"golang.org/x/sys/windows"
 )
 
+// The view of MapViewOfFile must start at a multiple of it, usually 64K.
+func AllocationGranularity() int {
+	return int(GetSystemInfo().AllocationGranularity)
+}
+
 // Create no-file named memory share
 func CreateMmap(name string, size uint32, write bool) (*Mmap, error) {
 	return CreateMmapWithSecurityDescriptor(name, size, write, "")
@@ -22,55 +25,43 @@ func CreateMmap(name string, size uint32, write bool) (*Mmap, error) {
 // https://blog.csdn.net/qinlicang/article/details/5538307
 // https://stackoverflow.com/questions/898683/how-to-share-memory-between-services-and-user-processes
 func CreateMmapWithSecurityDescriptor(name string, size uint32, write bool, securityDescriptor string) (*Mmap, error) {
-	if size == 0 {
-		return nil, fmt.Errorf("size==0")
-	}
+	return CreateMmap64(name, int64(size), write, securityDescriptor)
+}
 
-	cancel := false
+// The same as CreateMmapWithSecurityDescriptor, but the size is 64-bit
+func CreateMmap64(name string, size int64, write bool, securityDescriptor string) (*Mmap, error) {
+	if size <= 0 {
+		return nil, fmt.Errorf("size<=0")
+	}
 
-	namePtr, _ := windows.UTF16PtrFromString(name)
+	var namePtr *uint16
+	if name != "" {
+		namePtr, _ = windows.UTF16PtrFromString(name)
+	}
 
 	prot := uint32(syscall.PAGE_READONLY)
 	if write {
 		prot = syscall.PAGE_READWRITE
 	}
 
-	var security *windows.SecurityAttributes
-	if len(securityDescriptor) != 0 {
-		security = new(windows.SecurityAttributes)
-		err := ConvertStringSecurityDescriptorToSecurityDescriptor(securityDescriptor, SDDL_REVISION_1, (*SecurityDescriptor)(unsafe.Pointer(&security.SecurityDescriptor)), nil)
-		if err != nil {
-			return nil, fmt.Errorf("ConvertStringSecurityDescriptorToSecurityDescriptor, %v", err)
-		}
-		defer func() {
-			LocalFree(windows.Pointer(unsafe.Pointer(security.SecurityDescriptor)))
-		}()
+	security, free, err := newSecurityAttributes(securityDescriptor)
+	if err != nil {
+		return nil, err
 	}
+	defer free()
 
 	fileHandle, err := windows.CreateFileMapping(windows.Handle(INVALID_HANDLE_VALUE),
-		security, prot, 0, uint32(size), namePtr)
+		security, prot, uint32(size>>32), uint32(size), namePtr)
 	if err != nil {
-		return nil, fmt.Errorf("CreateFileMapping, %v", err)
+		return nil, fmt.Errorf("CreateFileMapping, %w", err)
 	}
-	defer func() {
-		if cancel {
-			windows.CloseHandle(fileHandle)
-		}
-	}()
 
-	access := uint32(windows.FILE_MAP_READ)
-	if write {
-		access = windows.FILE_MAP_WRITE
-	}
-	addr, err := windows.MapViewOfFile(fileHandle, access, 0,
-		0, uintptr(size))
-	if err != nil {
-		cancel = true
-		return nil, fmt.Errorf("MapViewOfFile, %v", err)
+	m := &Mmap{fileHandle: Handle(fileHandle), write: write}
+	if err := m.mapView(0, size); err != nil {
+		windows.CloseHandle(fileHandle)
+		return nil, err
 	}
 
-	m := &Mmap{fileHandle: Handle(fileHandle), addr: addr, size: int(size)}
-
 	runtime.SetFinalizer(m, (*Mmap).Close)
 
 	return m, nil
@@ -79,11 +70,14 @@ func CreateMmapWithSecurityDescriptor(name string, size uint32, write bool, secu
 // Turn on no-name memory sharing
 // Although the MapViewOfFile function allows 0, it cannot be converted to []byte when size==0, so size == 0 is not supported.
 func OpenMmap(name string, size uint32, write bool) (*Mmap, error) {
-	if size == 0 {
-		return nil, fmt.Errorf("size==0")
-	}
+	return OpenMmap64(name, 0, int64(size), write)
+}
 
-	cancel := false
+// Open the partial view of the named memory share, offset does not need to be aligned.
+func OpenMmap64(name string, offset, length int64, write bool) (*Mmap, error) {
+	if length <= 0 {
+		return nil, fmt.Errorf("length<=0")
+	}
 
 	access := uint32(windows.FILE_MAP_READ)
 	if write {
@@ -91,34 +85,169 @@ func OpenMmap(name string, size uint32, write bool) (*Mmap, error) {
 	}
 	fileHandle, err := OpenFileMapping(DWord(access), false, name)
 	if err != nil {
-		return nil, fmt.Errorf("OpenFileMapping, %v", err)
+		return nil, fmt.Errorf("OpenFileMapping, %w", err)
+	}
+
+	m := &Mmap{fileHandle: fileHandle, write: write}
+	if err := m.mapView(offset, length); err != nil {
+		windows.CloseHandle(windows.Handle(fileHandle))
+		return nil, err
 	}
-	defer func() {
-		if cancel {
-			windows.CloseHandle(windows.Handle(fileHandle))
-		}
-	}()
 
-	addr, err := windows.MapViewOfFile(windows.Handle(fileHandle), uint32(access), 0,
-		0, uintptr(size))
+	runtime.SetFinalizer(m, (*Mmap).Close)
+
+	return m, nil
+}
+
+// Map the file
+// offset does not need to be aligned, length == 0 means to the end of the file.
+// With write, the file is created if it does not exist, and extended if it is smaller than offset+length.
+func OpenFileMmap(path string, offset, length int64, write bool) (*Mmap, error) {
+	if offset < 0 || length < 0 {
+		return nil, fmt.Errorf("invalid offset %v or length %v", offset, length)
+	}
+
+	pathPtr, err := windows.UTF16PtrFromString(path)
 	if err != nil {
-		cancel = true
-		return nil, fmt.Errorf("MapViewOfFile, %v", err)
+		return nil, err
 	}
 
-	m := &Mmap{fileHandle: fileHandle, addr: addr, size: int(size)}
+	access := uint32(windows.GENERIC_READ)
+	createMode := uint32(windows.OPEN_EXISTING)
+	if write {
+		access |= windows.GENERIC_WRITE
+		createMode = windows.OPEN_ALWAYS
+	}
+	file, err := windows.CreateFile(pathPtr, access, windows.FILE_SHARE_READ|windows.FILE_SHARE_WRITE|windows.FILE_SHARE_DELETE,
+		nil, createMode, windows.FILE_ATTRIBUTE_NORMAL, 0)
+	if err != nil {
+		return nil, fmt.Errorf("CreateFile, %w", err)
+	}
+
+	var info windows.ByHandleFileInformation
+	if err := windows.GetFileInformationByHandle(file, &info); err != nil {
+		windows.CloseHandle(file)
+		return nil, fmt.Errorf("GetFileInformationByHandle, %w", err)
+	}
+	fileSize := int64(info.FileSizeHigh)<<32 | int64(info.FileSizeLow)
+
+	if length == 0 {
+		length = fileSize - offset
+		if length <= 0 {
+			windows.CloseHandle(file)
+			return nil, fmt.Errorf("offset %v >= file size %v", offset, fileSize)
+		}
+	}
+	if write == false && offset+length > fileSize {
+		windows.CloseHandle(file)
+		return nil, fmt.Errorf("offset %v + length %v > file size %v", offset, length, fileSize)
+	}
+
+	m := &Mmap{file: Handle(file), write: write}
+	if err := m.createFileMapping(offset + length); err != nil {
+		windows.CloseHandle(file)
+		return nil, err
+	}
+	if err := m.mapView(offset, length); err != nil {
+		windows.CloseHandle(windows.Handle(m.fileHandle))
+		windows.CloseHandle(file)
+		return nil, err
+	}
 
 	runtime.SetFinalizer(m, (*Mmap).Close)
 
 	return m, nil
 }
 
+// The file is extended to size if it is smaller
+func (m *Mmap) createFileMapping(size int64) error {
+	prot := uint32(syscall.PAGE_READONLY)
+	if m.write {
+		prot = syscall.PAGE_READWRITE
+	}
+
+	fileHandle, err := windows.CreateFileMapping(windows.Handle(m.file), nil, prot, uint32(size>>32), uint32(size), nil)
+	if err != nil {
+		return fmt.Errorf("CreateFileMapping, %w", err)
+	}
+	m.fileHandle = Handle(fileHandle)
+	return nil
+}
+
+func (m *Mmap) mapView(offset, length int64) error {
+	alignedOffset, viewSize, err := alignView(offset, length, AllocationGranularity())
+	if err != nil {
+		return err
+	}
+
+	access := uint32(windows.FILE_MAP_READ)
+	if m.write {
+		access = windows.FILE_MAP_WRITE
+	}
+	addr, err := windows.MapViewOfFile(windows.Handle(m.fileHandle), access,
+		uint32(alignedOffset>>32), uint32(alignedOffset), uintptr(viewSize))
+	if err != nil {
+		return fmt.Errorf("MapViewOfFile, %w", err)
+	}
+
+	m.addr = addr
+	m.size = viewSize
+	m.delta = int(offset - alignedOffset)
+	m.offset = offset
+	return nil
+}
+
+// Write the dirty pages of the view to the file
+// https://docs.microsoft.com/en-us/windows/win32/api/memoryapi/nf-memoryapi-flushviewoffile
+func (m *Mmap) Flush() error {
+	if m.addr == uintptr(0) {
+		return fmt.Errorf("mmap is closed")
+	}
+
+	if err := windows.FlushViewOfFile(m.addr, uintptr(m.size)); err != nil {
+		return fmt.Errorf("FlushViewOfFile, %w", err)
+	}
+	if m.file != 0 && m.write {
+		if err := windows.FlushFileBuffers(windows.Handle(m.file)); err != nil {
+			return fmt.Errorf("FlushFileBuffers, %w", err)
+		}
+	}
+	return nil
+}
+
+// Grow the view of the file backed mapping to length, the file is extended if necessary.
+// The view is remapped, the bytes returned by GetBytes before become invalid.
+func (m *Mmap) Grow(length int64) error {
+	if m.file == 0 || m.write == false {
+		return fmt.Errorf("only the writable file backed mapping can grow")
+	}
+	if length <= m.Len() {
+		return nil
+	}
+
+	if err := windows.UnmapViewOfFile(m.addr); err != nil {
+		return fmt.Errorf("UnmapViewOfFile, %w", err)
+	}
+	m.addr = 0
+	m.size = 0
+
+	if err := windows.CloseHandle(windows.Handle(m.fileHandle)); err != nil {
+		return fmt.Errorf("CloseHandle, %w", err)
+	}
+	m.fileHandle = 0
+
+	if err := m.createFileMapping(m.offset + length); err != nil {
+		return err
+	}
+	return m.mapView(m.offset, length)
+}
+
 // close and release
 func (m *Mmap) Close() error {
 	if m.addr != uintptr(0) {
 		err := windows.UnmapViewOfFile(uintptr(m.addr))
 		if err != nil {
-			return fmt.Errorf("UnmapViewOfFile, %v", err)
+			return fmt.Errorf("UnmapViewOfFile, %w", err)
 		}
 		m.size = 0
 		m.addr = uintptr(0)
@@ -127,12 +256,20 @@ func (m *Mmap) Close() error {
 	if m.fileHandle != 0 {
 		err := windows.CloseHandle(windows.Handle(m.fileHandle))
 		if err != nil {
-			return fmt.Errorf("CloseHandle, %v", err)
+			return fmt.Errorf("CloseHandle, %w", err)
 		}
 		m.size = 0
 		m.fileHandle = 0
 	}
 
+	if m.file != 0 {
+		err := windows.CloseHandle(windows.Handle(m.file))
+		if err != nil {
+			return fmt.Errorf("CloseHandle, %w", err)
+		}
+		m.file = 0
+	}
+
 	runtime.KeepAlive(m)
 
 	// Test to ensure that runtime.SetFinalizer is working properly
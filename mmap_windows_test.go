@@ -2,6 +2,7 @@ package gowindows
 
 import (
 	"bytes"
+	"errors"
 	"math/rand"
 	"sync"
 	"testing"
@@ -117,3 +118,14 @@ func TestCreateMmapWithSecurityDescriptor(t *testing.T) {
 	}
 
 }
+
+func TestOpenMmap_notFound(t *testing.T) {
+	_, err := OpenMmap("gowindows_test_mmap_none", 4096, false)
+	if errors.Is(err, ErrNotFound) == false {
+		t.Errorf("%v is not %v", err, ErrNotFound)
+	}
+	var callErr *CallError
+	if errors.As(err, &callErr) == false || callErr.Func != "OpenFileMappingW" {
+		t.Errorf("%#v", err)
+	}
+}
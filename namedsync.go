@@ -0,0 +1,181 @@
+package gowindows
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Named synchronization objects shared between processes
+// The names are the same as the other kernel objects (Local\ and Global\ prefix), and they share the namespace,
+// so a mutex can not be opened as an event.
+// The objects are created if they do not exist, the security descriptor (SDDL) is only used when creating them.
+var (
+	// The mutex was held by a thread or process terminated without releasing it,
+	// the mutex is acquired, but the data protected by it may be inconsistent.
+	ErrAbandoned = errors.New("the mutex is abandoned")
+	// NamedSemaphore.Release exceeds the maximum count
+	ErrSemaphoreLimit = errors.New("the semaphore count exceeds the maximum")
+	// The object exists but it is another type
+	ErrSyncObjectType = errors.New("the object is another type")
+)
+
+// *NamedMutex, *NamedEvent, *NamedSemaphore, and *Process on windows (signaled when it exits)
+type Waitable interface {
+	// Wait until the object is signaled or ctx is done
+	// The mutex is acquired, the count of the semaphore is decreased, the auto reset event is reset.
+	Wait(ctx context.Context) error
+	Close() error
+
+	object() *syncObject
+}
+
+const (
+	syncKindMutex = iota + 1
+	syncKindEvent
+	syncKindSemaphore
+	syncKindProcess
+)
+
+// Acquire the mutex without waiting
+func (o *syncObject) tryLock() (bool, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := o.Wait(ctx)
+	if err == context.Canceled {
+		return false, nil
+	}
+	return err == nil || errors.Is(err, ErrAbandoned), err
+}
+
+// Named mutex
+// On windows the owner is the thread, the goroutine is locked to the thread until Unlock,
+// so Unlock must be called by the goroutine that locks it.
+// Locking it again by the same goroutine is not supported.
+type NamedMutex struct {
+	syncObject
+}
+
+// Create or open the named mutex, it is not acquired.
+func CreateNamedMutex(name string, securityDescriptor string) (*NamedMutex, error) {
+	o, err := newSyncObject(syncKindMutex, name, true, false, 0, 0, securityDescriptor)
+	if err != nil {
+		return nil, err
+	}
+	return &NamedMutex{o}, nil
+}
+
+// Open the existing named mutex
+func OpenNamedMutex(name string) (*NamedMutex, error) {
+	o, err := newSyncObject(syncKindMutex, name, false, false, 0, 0, "")
+	if err != nil {
+		return nil, err
+	}
+	return &NamedMutex{o}, nil
+}
+
+// Acquire the mutex, ErrAbandoned is returned with the mutex acquired if the previous owner terminated.
+func (m *NamedMutex) Lock(ctx context.Context) error {
+	return m.Wait(ctx)
+}
+
+// Acquire the mutex without waiting
+func (m *NamedMutex) TryLock() (bool, error) {
+	return m.tryLock()
+}
+
+// Release the mutex
+func (m *NamedMutex) Unlock() error {
+	return m.unlock()
+}
+
+// Named event
+type NamedEvent struct {
+	syncObject
+}
+
+// Create or open the named event, manualReset and initialState are only used when creating it.
+// The manual reset event stays signaled until Reset, the auto reset event is reset when a waiter is released.
+func CreateNamedEvent(name string, manualReset, initialState bool, securityDescriptor string) (*NamedEvent, error) {
+	initial := 0
+	if initialState {
+		initial = 1
+	}
+	o, err := newSyncObject(syncKindEvent, name, true, manualReset, initial, 1, securityDescriptor)
+	if err != nil {
+		return nil, err
+	}
+	return &NamedEvent{o}, nil
+}
+
+// Open the existing named event
+func OpenNamedEvent(name string) (*NamedEvent, error) {
+	o, err := newSyncObject(syncKindEvent, name, false, false, 0, 0, "")
+	if err != nil {
+		return nil, err
+	}
+	return &NamedEvent{o}, nil
+}
+
+// Signal the event
+func (e *NamedEvent) Set() error {
+	return e.set()
+}
+
+// Reset the event to nonsignaled
+func (e *NamedEvent) Reset() error {
+	return e.reset()
+}
+
+// Named semaphore
+type NamedSemaphore struct {
+	syncObject
+}
+
+// Create or open the named semaphore, the counts are only used when creating it.
+func CreateNamedSemaphore(name string, initialCount, maximumCount int, securityDescriptor string) (*NamedSemaphore, error) {
+	if initialCount < 0 || maximumCount <= 0 || initialCount > maximumCount || maximumCount > 0x7FFFFFFF {
+		return nil, fmt.Errorf("invalid initial count %v or maximum count %v", initialCount, maximumCount)
+	}
+	o, err := newSyncObject(syncKindSemaphore, name, true, false, initialCount, maximumCount, securityDescriptor)
+	if err != nil {
+		return nil, err
+	}
+	return &NamedSemaphore{o}, nil
+}
+
+// Open the existing named semaphore
+func OpenNamedSemaphore(name string) (*NamedSemaphore, error) {
+	o, err := newSyncObject(syncKindSemaphore, name, false, false, 0, 0, "")
+	if err != nil {
+		return nil, err
+	}
+	return &NamedSemaphore{o}, nil
+}
+
+// Increase the count by n, returns the previous count.
+func (s *NamedSemaphore) Release(n int) (int, error) {
+	if n <= 0 {
+		return 0, fmt.Errorf("invalid release count %v", n)
+	}
+	return s.release(n)
+}
+
+func (o *syncObject) object() *syncObject {
+	return o
+}
+
+// Wait for any or all of the objects
+// With waitAll false, the index of the signaled object is returned, only that object is acquired;
+// with waitAll true, all the objects are acquired at the same time and the index is 0.
+// ErrAbandoned is returned if an abandoned mutex is acquired, the index is the mutex for waitAll false.
+func WaitMultiple(ctx context.Context, waitAll bool, objects ...Waitable) (int, error) {
+	if len(objects) == 0 || len(objects) > MAXIMUM_WAIT_OBJECTS {
+		return 0, errors.New("the number of objects must be between 1 and MAXIMUM_WAIT_OBJECTS")
+	}
+	list := make([]*syncObject, len(objects))
+	for i, o := range objects {
+		list[i] = o.object()
+	}
+	return waitMultiple(ctx, waitAll, list)
+}
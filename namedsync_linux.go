@@ -0,0 +1,311 @@
+package gowindows
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"sync/atomic"
+	"time"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+// The linux implementation of the named synchronization objects
+// They are small named memory shares (see mmap_linux.go), so the names and the life cycle are the same as Mmap.
+// Memory layout:
+//
+//	0  state   uint32 mutex: 1 while it is held; event: 1 if signaled; semaphore: the count
+//	4  type    uint32 kind | manual reset << 8, 0xFFFFFFFF while initializing
+//	8  maximum uint32 the maximum count of the semaphore
+//
+// The event and the semaphore are waited by futex, the futex of MAP_SHARED memory works across processes.
+// The mutex is the open file description lock of the file, it is released by the kernel when the process exits,
+// and the next owner finds the state is still 1, so it is abandoned.
+// WaitMultiple polls the objects.
+const (
+	syncObjectSize = 16
+
+	syncOffsetState   = 0
+	syncOffsetType    = 4
+	syncOffsetMaximum = 8
+
+	syncTypeInitializing = 0xFFFFFFFF
+	syncManualReset      = 0x100
+
+	futexWaitOp  = 0
+	futexWakeOp  = 1
+	futexWakeAll = 1<<31 - 1
+)
+
+// Wait while *addr == val, returns nil when woken up, EAGAIN if *addr != val, ETIMEDOUT or EINTR.
+func futexWait(addr *uint32, val uint32, timeout time.Duration) error {
+	ts := unix.NsecToTimespec(int64(timeout))
+	_, _, e1 := unix.Syscall6(unix.SYS_FUTEX, uintptr(unsafe.Pointer(addr)), futexWaitOp, uintptr(val),
+		uintptr(unsafe.Pointer(&ts)), 0, 0)
+	if e1 != 0 {
+		return e1
+	}
+	return nil
+}
+
+func futexWake(addr *uint32, n int) error {
+	_, _, e1 := unix.Syscall6(unix.SYS_FUTEX, uintptr(unsafe.Pointer(addr)), futexWakeOp, uintptr(n), 0, 0, 0)
+	if e1 != 0 {
+		return e1
+	}
+	return nil
+}
+
+type syncObject struct {
+	m           *Mmap
+	mem         []byte
+	kind        int
+	manualReset bool
+	// The lock belongs to the file description, goroutines of the process are serialized by local.
+	local chan struct{}
+}
+
+func newSyncObject(kind int, name string, create bool, manualReset bool, initial, maximum int, securityDescriptor string) (syncObject, error) {
+	var m *Mmap
+	var err error
+	if create {
+		m, err = CreateMmap64(name, syncObjectSize, true, securityDescriptor)
+	} else {
+		m, err = OpenMmap64(name, 0, syncObjectSize, true)
+	}
+	if err != nil {
+		return syncObject{}, err
+	}
+
+	o := syncObject{m: m, mem: m.GetBytes(), kind: kind, local: make(chan struct{}, 1)}
+	typ := o.uint32(syncOffsetType)
+
+	desc := uint32(kind)
+	if manualReset {
+		desc |= syncManualReset
+	}
+	if create && atomic.CompareAndSwapUint32(typ, 0, syncTypeInitializing) {
+		atomic.StoreUint32(o.uint32(syncOffsetState), uint32(initial))
+		atomic.StoreUint32(o.uint32(syncOffsetMaximum), uint32(maximum))
+		atomic.StoreUint32(typ, desc)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		desc = atomic.LoadUint32(typ)
+		if desc != syncTypeInitializing && desc != 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			m.Close()
+			return syncObject{}, fmt.Errorf("%v is not initialized", name)
+		}
+		runtime.Gosched()
+	}
+
+	if int(desc&0xFF) != kind {
+		m.Close()
+		return syncObject{}, fmt.Errorf("%w, %v", ErrSyncObjectType, name)
+	}
+	o.manualReset = desc&syncManualReset != 0
+	return o, nil
+}
+
+func (o *syncObject) uint32(offset int) *uint32 {
+	return (*uint32)(unsafe.Pointer(&o.mem[offset]))
+}
+
+func (o *syncObject) fcntl(typ int16) error {
+	lk := unix.Flock_t{Type: typ, Whence: 0, Start: 0, Len: 1}
+	return unix.FcntlFlock(uintptr(o.m.GetHandle()), unix.F_OFD_SETLK, &lk)
+}
+
+// Acquire the object without waiting
+func (o *syncObject) tryAcquire() (bool, error) {
+	state := o.uint32(syncOffsetState)
+	switch o.kind {
+	case syncKindMutex:
+		select {
+		case o.local <- struct{}{}:
+		default:
+			return false, nil
+		}
+		if err := o.fcntl(unix.F_WRLCK); err != nil {
+			<-o.local
+			if err == unix.EAGAIN || err == unix.EACCES || err == unix.EINTR {
+				return false, nil
+			}
+			return false, fmt.Errorf("fcntl, %w", err)
+		}
+		if atomic.SwapUint32(state, 1) != 0 {
+			return true, ErrAbandoned
+		}
+		return true, nil
+	case syncKindEvent:
+		if o.manualReset {
+			return atomic.LoadUint32(state) != 0, nil
+		}
+		return atomic.CompareAndSwapUint32(state, 1, 0), nil
+	default:
+		for {
+			v := atomic.LoadUint32(state)
+			if v == 0 {
+				return false, nil
+			}
+			if atomic.CompareAndSwapUint32(state, v, v-1) {
+				return true, nil
+			}
+		}
+	}
+}
+
+// Undo tryAcquire
+func (o *syncObject) rollback() {
+	switch o.kind {
+	case syncKindMutex:
+		o.unlock()
+	case syncKindEvent:
+		if o.manualReset == false {
+			o.set()
+		}
+	default:
+		o.release(1)
+	}
+}
+
+func (o *syncObject) Wait(ctx context.Context) error {
+	if o.kind == syncKindMutex {
+		_, err := waitMultiple(ctx, false, []*syncObject{o})
+		return err
+	}
+
+	// Wake up the futex when ctx is done, the other waiters check the state again.
+	state := o.uint32(syncOffsetState)
+	if ctx.Done() != nil {
+		stop := make(chan struct{})
+		defer close(stop)
+		go func() {
+			select {
+			case <-ctx.Done():
+				futexWake(state, futexWakeAll)
+			case <-stop:
+			}
+		}()
+	}
+
+	for {
+		ok, err := o.tryAcquire()
+		if ok || err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		// The timeout only guards against the wake up before the futex wait
+		switch err := futexWait(state, 0, 100*time.Millisecond); err {
+		case nil, unix.EAGAIN, unix.EINTR, unix.ETIMEDOUT:
+		default:
+			return fmt.Errorf("futex, %w", err)
+		}
+	}
+}
+
+func waitMultiple(ctx context.Context, waitAll bool, objects []*syncObject) (int, error) {
+	interval := time.Millisecond
+	for {
+		if waitAll {
+			var abandoned error
+			i := 0
+			for ; i < len(objects); i++ {
+				ok, err := objects[i].tryAcquire()
+				if errors.Is(err, ErrAbandoned) {
+					abandoned = err
+				} else if err != nil || ok == false {
+					for _, o := range objects[:i] {
+						o.rollback()
+					}
+					if err != nil {
+						return 0, err
+					}
+					break
+				}
+			}
+			if i == len(objects) {
+				return 0, abandoned
+			}
+		} else {
+			for i, o := range objects {
+				ok, err := o.tryAcquire()
+				if ok || err != nil {
+					return i, err
+				}
+			}
+		}
+
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+		case <-timer.C:
+		}
+		timer.Stop()
+		if interval < 10*time.Millisecond {
+			interval *= 2
+		}
+	}
+}
+
+func (o *syncObject) unlock() error {
+	if len(o.local) == 0 {
+		return fmt.Errorf("the mutex is not locked")
+	}
+
+	atomic.StoreUint32(o.uint32(syncOffsetState), 0)
+	err := o.fcntl(unix.F_UNLCK)
+	<-o.local
+	if err != nil {
+		return fmt.Errorf("fcntl, %w", err)
+	}
+	return nil
+}
+
+func (o *syncObject) set() error {
+	state := o.uint32(syncOffsetState)
+	atomic.StoreUint32(state, 1)
+	if err := futexWake(state, futexWakeAll); err != nil {
+		return fmt.Errorf("futex, %w", err)
+	}
+	return nil
+}
+
+func (o *syncObject) reset() error {
+	atomic.StoreUint32(o.uint32(syncOffsetState), 0)
+	return nil
+}
+
+func (o *syncObject) release(n int) (int, error) {
+	state := o.uint32(syncOffsetState)
+	maximum := atomic.LoadUint32(o.uint32(syncOffsetMaximum))
+	for {
+		v := atomic.LoadUint32(state)
+		if uint64(v)+uint64(n) > uint64(maximum) {
+			return int(v), ErrSemaphoreLimit
+		}
+		if atomic.CompareAndSwapUint32(state, v, v+uint32(n)) {
+			if err := futexWake(state, n); err != nil {
+				return int(v), fmt.Errorf("futex, %w", err)
+			}
+			return int(v), nil
+		}
+	}
+}
+
+// Close the object, the held mutex is abandoned.
+func (o *syncObject) Close() error {
+	return o.m.Close()
+}
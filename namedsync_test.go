@@ -0,0 +1,260 @@
+package gowindows
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// The context is released after the timeout
+func timeoutContext(d time.Duration) context.Context {
+	ctx, cancel := context.WithTimeout(context.Background(), d)
+	time.AfterFunc(d+time.Second, cancel)
+	return ctx
+}
+
+func TestNamedEvent(t *testing.T) {
+	for _, manualReset := range []bool{false, true} {
+		e1, err := CreateNamedEvent("gowindows_test_event", manualReset, false, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		e2, err := OpenNamedEvent("gowindows_test_event")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := e2.Wait(timeoutContext(10 * time.Millisecond)); err != context.DeadlineExceeded {
+			t.Errorf("%v!=%v", err, context.DeadlineExceeded)
+		}
+
+		set := make(chan struct{})
+		go func() {
+			time.Sleep(20 * time.Millisecond)
+			e1.Set()
+			close(set)
+		}()
+		if err := e2.Wait(timeoutContext(time.Second)); err != nil {
+			t.Error(err)
+		}
+		<-set
+
+		// The auto reset event is reset by the waiter
+		if err := e1.Wait(timeoutContext(0)); (err == nil) != manualReset {
+			t.Errorf("%v: %v", manualReset, err)
+		}
+		e1.Reset()
+		if err := e1.Wait(timeoutContext(0)); err == nil {
+			t.Errorf("%v: wait after reset", manualReset)
+		}
+
+		e1.Close()
+		e2.Close()
+	}
+
+	e, err := CreateNamedEvent("gowindows_test_event", true, true, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer e.Close()
+	if err := e.Wait(timeoutContext(0)); err != nil {
+		t.Errorf("initial state, %v", err)
+	}
+
+	// Canceled while waiting
+	e.Reset()
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		cancel()
+	}()
+	if err := e.Wait(ctx); err != context.Canceled {
+		t.Errorf("%v!=%v", err, context.Canceled)
+	}
+
+	if _, err := OpenNamedEvent("gowindows_test_event_none"); err == nil {
+		t.Error("OpenNamedEvent == nil")
+	}
+	if _, err := CreateNamedMutex("gowindows_test_event", ""); errors.Is(err, ErrSyncObjectType) == false {
+		t.Errorf("%v!=%v", err, ErrSyncObjectType)
+	}
+}
+
+func TestNamedMutex(t *testing.T) {
+	m1, err := CreateNamedMutex("gowindows_test_mutex", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer m1.Close()
+	m2, err := OpenNamedMutex("gowindows_test_mutex")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer m2.Close()
+
+	// The race detector does not know the lock, so the exclusion is checked by atomic
+	inside := int32(0)
+	wg := sync.WaitGroup{}
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(m *NamedMutex) {
+			defer wg.Done()
+			if err := m.Lock(timeoutContext(10 * time.Second)); err != nil {
+				t.Error(err)
+				return
+			}
+			if v := atomic.AddInt32(&inside, 1); v != 1 {
+				t.Errorf("%v goroutines hold the mutex", v)
+			}
+			time.Sleep(time.Millisecond)
+			atomic.AddInt32(&inside, -1)
+			if err := m.Unlock(); err != nil {
+				t.Error(err)
+			}
+		}([]*NamedMutex{m1, m2}[i%2])
+	}
+	wg.Wait()
+
+	done := make(chan struct{})
+	locked := make(chan struct{})
+	unlocked := make(chan struct{})
+	go func() {
+		m1.Lock(context.Background())
+		close(locked)
+		<-done
+		m1.Unlock()
+		close(unlocked)
+	}()
+	<-locked
+	if ok, err := m2.TryLock(); err != nil || ok {
+		t.Errorf("TryLock %v %v", ok, err)
+	}
+	if err := m2.Lock(timeoutContext(10 * time.Millisecond)); err != context.DeadlineExceeded {
+		t.Errorf("%v!=%v", err, context.DeadlineExceeded)
+	}
+	close(done)
+	<-unlocked
+}
+
+func TestNamedSemaphore(t *testing.T) {
+	s, err := CreateNamedSemaphore("gowindows_test_semaphore", 1, 2, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	if err := s.Wait(timeoutContext(0)); err != nil {
+		t.Error(err)
+	}
+	if err := s.Wait(timeoutContext(10 * time.Millisecond)); err != context.DeadlineExceeded {
+		t.Errorf("%v!=%v", err, context.DeadlineExceeded)
+	}
+
+	released := make(chan struct{})
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		s.Release(2)
+		close(released)
+	}()
+	if err := s.Wait(timeoutContext(time.Second)); err != nil {
+		t.Error(err)
+	}
+	<-released
+	if n, err := s.Release(1); err != nil || n != 1 {
+		t.Errorf("Release %v %v", n, err)
+	}
+	if _, err := s.Release(1); err != ErrSemaphoreLimit {
+		t.Errorf("%v!=%v", err, ErrSemaphoreLimit)
+	}
+
+	if _, err := CreateNamedSemaphore("gowindows_test_semaphore2", 2, 1, ""); err == nil {
+		t.Error("initial > maximum == nil")
+	}
+}
+
+func TestWaitMultiple(t *testing.T) {
+	e1, _ := CreateNamedEvent("gowindows_test_wait1", false, false, "")
+	defer e1.Close()
+	e2, _ := CreateNamedEvent("gowindows_test_wait2", false, false, "")
+	defer e2.Close()
+	s, _ := CreateNamedSemaphore("gowindows_test_wait3", 0, 1, "")
+	defer s.Close()
+
+	set := make(chan struct{})
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		e2.Set()
+		close(set)
+	}()
+	if i, err := WaitMultiple(timeoutContext(time.Second), false, e1, e2, s); err != nil || i != 1 {
+		t.Errorf("WaitMultiple %v %v", i, err)
+	}
+	<-set
+
+	// Nothing is acquired if not all are signaled
+	e1.Set()
+	if _, err := WaitMultiple(timeoutContext(20*time.Millisecond), true, e1, s); err != context.DeadlineExceeded {
+		t.Errorf("%v!=%v", err, context.DeadlineExceeded)
+	}
+	s.Release(1)
+	if _, err := WaitMultiple(timeoutContext(time.Second), true, e1, s); err != nil {
+		t.Error(err)
+	}
+	if _, err := WaitMultiple(timeoutContext(10*time.Millisecond), false, e1, e2, s); err != context.DeadlineExceeded {
+		t.Errorf("%v!=%v", err, context.DeadlineExceeded)
+	}
+
+	if _, err := WaitMultiple(context.Background(), false); err == nil {
+		t.Error("WaitMultiple without objects == nil")
+	}
+}
+
+// Lock the mutex and exit without Unlock
+func TestNamedMutexHelperProcess(t *testing.T) {
+	name := os.Getenv("GOWINDOWS_MUTEX_HELPER")
+	if name == "" {
+		t.Skip("helper process")
+	}
+
+	m, err := OpenNamedMutex(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Lock(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	os.Exit(0)
+}
+
+func TestNamedMutex_abandoned(t *testing.T) {
+	if os.Getenv("GOWINDOWS_MUTEX_HELPER") != "" {
+		t.Skip("helper process")
+	}
+
+	m, err := CreateNamedMutex("gowindows_test_abandoned", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer m.Close()
+
+	cmd := exec.Command(os.Args[0], "-test.run=^TestNamedMutexHelperProcess$", "-test.count=1")
+	cmd.Env = append(os.Environ(), "GOWINDOWS_MUTEX_HELPER=gowindows_test_abandoned")
+	if err := cmd.Run(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := m.Lock(timeoutContext(time.Second)); err != ErrAbandoned {
+		t.Errorf("%v!=%v", err, ErrAbandoned)
+	}
+	m.Unlock()
+
+	if err := m.Lock(timeoutContext(time.Second)); err != nil {
+		t.Error(err)
+	}
+	m.Unlock()
+}
@@ -0,0 +1,185 @@
+package gowindows
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+
+	"golang.org/x/sys/windows"
+)
+
+type syncObject struct {
+	handle windows.Handle
+	kind   int
+}
+
+func newSyncObject(kind int, name string, create bool, manualReset bool, initial, maximum int, securityDescriptor string) (syncObject, error) {
+	var namePtr *uint16
+	if name != "" {
+		var err error
+		if namePtr, err = windows.UTF16PtrFromString(name); err != nil {
+			return syncObject{}, err
+		}
+	}
+
+	var h windows.Handle
+	var err error
+	if create {
+		var security *windows.SecurityAttributes
+		var free func()
+		if security, free, err = newSecurityAttributes(securityDescriptor); err != nil {
+			return syncObject{}, err
+		}
+		defer free()
+
+		switch kind {
+		case syncKindMutex:
+			h, err = windows.CreateMutex(security, false, namePtr)
+		case syncKindEvent:
+			manual := uint32(0)
+			if manualReset {
+				manual = 1
+			}
+			h, err = windows.CreateEvent(security, manual, uint32(initial), namePtr)
+		default:
+			var v Handle
+			v, err = CreateSemaphore(security, int32(initial), int32(maximum), namePtr)
+			h = windows.Handle(v)
+		}
+	} else {
+		access := uint32(windows.SYNCHRONIZE)
+		switch kind {
+		case syncKindMutex:
+			h, err = windows.OpenMutex(access, false, namePtr)
+		case syncKindEvent:
+			h, err = windows.OpenEvent(access|windows.EVENT_MODIFY_STATE, false, namePtr)
+		default:
+			var v Handle
+			v, err = OpenSemaphore(DWord(access|SEMAPHORE_MODIFY_STATE), false, name)
+			h = windows.Handle(v)
+		}
+	}
+
+	if errors.Is(err, windows.ERROR_INVALID_HANDLE) {
+		// The name is used by an object of another type
+		return syncObject{}, fmt.Errorf("%w, %v", ErrSyncObjectType, name)
+	}
+	if err != nil {
+		return syncObject{}, err
+	}
+	return syncObject{handle: h, kind: kind}, nil
+}
+
+func (o *syncObject) Wait(ctx context.Context) error {
+	_, err := waitMultiple(ctx, false, []*syncObject{o})
+	return err
+}
+
+// The thread is locked while waiting, and locked once more for each acquired mutex.
+func waitMultiple(ctx context.Context, waitAll bool, objects []*syncObject) (int, error) {
+	handles := make([]windows.Handle, len(objects), len(objects)+1)
+	for i, o := range objects {
+		handles[i] = o.handle
+	}
+
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	var event uint32
+	var err error
+	switch {
+	case ctx.Done() == nil:
+		event, err = windows.WaitForMultipleObjects(handles, waitAll, windows.INFINITE)
+	case waitAll == false && len(handles) < MAXIMUM_WAIT_OBJECTS:
+		// The extra event is set when ctx is done, the objects before it take precedence.
+		var cancel windows.Handle
+		if cancel, err = windows.CreateEvent(nil, 1, 0, nil); err != nil {
+			return 0, fmt.Errorf("CreateEvent, %w", err)
+		}
+		defer windows.CloseHandle(cancel)
+		stop := make(chan struct{})
+		defer close(stop)
+		go func() {
+			select {
+			case <-ctx.Done():
+				windows.SetEvent(cancel)
+			case <-stop:
+			}
+		}()
+
+		event, err = windows.WaitForMultipleObjects(append(handles, cancel), false, windows.INFINITE)
+		if err == nil && event == windows.WAIT_OBJECT_0+uint32(len(objects)) {
+			return 0, ctx.Err()
+		}
+	default:
+		for {
+			event, err = windows.WaitForMultipleObjects(handles, waitAll, 50)
+			if err != nil || event != WAIT_TIMEOUT {
+				break
+			}
+			if err := ctx.Err(); err != nil {
+				return 0, err
+			}
+		}
+	}
+	if err != nil {
+		return 0, fmt.Errorf("WaitForMultipleObjects, %w", err)
+	}
+
+	index := 0
+	var result error
+	switch {
+	case event >= windows.WAIT_OBJECT_0 && event < windows.WAIT_OBJECT_0+uint32(len(objects)):
+		index = int(event - windows.WAIT_OBJECT_0)
+	case event >= windows.WAIT_ABANDONED && event < windows.WAIT_ABANDONED+uint32(len(objects)):
+		index = int(event - windows.WAIT_ABANDONED)
+		result = ErrAbandoned
+	default:
+		return 0, fmt.Errorf("WaitForMultipleObjects, unexpected result 0x%x", event)
+	}
+
+	if waitAll {
+		index = 0
+		for _, o := range objects {
+			if o.kind == syncKindMutex {
+				runtime.LockOSThread()
+			}
+		}
+	} else if objects[index].kind == syncKindMutex {
+		runtime.LockOSThread()
+	}
+	return index, result
+}
+
+func (o *syncObject) unlock() error {
+	if err := windows.ReleaseMutex(o.handle); err != nil {
+		return fmt.Errorf("ReleaseMutex, %w", err)
+	}
+	runtime.UnlockOSThread()
+	return nil
+}
+
+func (o *syncObject) set() error {
+	return windows.SetEvent(o.handle)
+}
+
+func (o *syncObject) reset() error {
+	return windows.ResetEvent(o.handle)
+}
+
+func (o *syncObject) release(n int) (int, error) {
+	previous := int32(0)
+	if err := ReleaseSemaphore(Handle(o.handle), int32(n), &previous); err != nil {
+		if errors.Is(err, windows.ERROR_TOO_MANY_POSTS) {
+			return 0, ErrSemaphoreLimit
+		}
+		return 0, fmt.Errorf("ReleaseSemaphore, %w", err)
+	}
+	return int(previous), nil
+}
+
+// Close the handle, the object is deleted when the last handle is closed.
+func (o *syncObject) Close() error {
+	return windows.CloseHandle(o.handle)
+}
@@ -1,7 +1,23 @@
 package gowindows
 
+// PROCESSINFOCLASS of NtQueryInformationProcess
+// https://docs.microsoft.com/en-us/windows/win32/api/winternl/nf-winternl-ntqueryinformationprocess
 const (
-	ProcessBasicInformation = 0
+	ProcessBasicInformation       = 0
+	ProcessIoCounters             = 2
+	ProcessVmCounters             = 3
+	ProcessTimes                  = 4
+	ProcessHandleCount            = 20
+	ProcessSessionInformation     = 24
+	ProcessWow64Information       = 26
+	ProcessImageFileName          = 27
+	ProcessImageFileNameWin32     = 43
+	ProcessCommandLineInformation = 60
+	ProcessProtectionInformation  = 61
+)
+
+const (
+	SystemProcessInformation = 5
 
 	PROCESS_VM_READ = 0x0010
 )
@@ -23,3 +39,139 @@ type PROCESS_BASIC_INFORMATION64 struct {
 	UniqueProcessId uint64
 	Reserved3       uint64
 }
+
+// ProcessVmCounters, the sizes are the pointer size of the current program.
+// typedef struct _VM_COUNTERS_EX {
+// SIZE_T PeakVirtualSize;
+// SIZE_T VirtualSize;
+// ULONG  PageFaultCount;
+// SIZE_T PeakWorkingSetSize;
+// SIZE_T WorkingSetSize;
+// SIZE_T QuotaPeakPagedPoolUsage;
+// SIZE_T QuotaPagedPoolUsage;
+// SIZE_T QuotaPeakNonPagedPoolUsage;
+// SIZE_T QuotaNonPagedPoolUsage;
+// SIZE_T PagefileUsage;
+// SIZE_T PeakPagefileUsage;
+// SIZE_T PrivateUsage;
+// } VM_COUNTERS_EX, *PVM_COUNTERS_EX;
+type VM_COUNTERS_EX struct {
+	PeakVirtualSize            uint
+	VirtualSize                uint
+	PageFaultCount             uint32
+	PeakWorkingSetSize         uint
+	WorkingSetSize             uint
+	QuotaPeakPagedPoolUsage    uint
+	QuotaPagedPoolUsage        uint
+	QuotaPeakNonPagedPoolUsage uint
+	QuotaNonPagedPoolUsage     uint
+	PagefileUsage              uint
+	PeakPagefileUsage          uint
+	PrivateUsage               uint
+}
+
+// ProcessTimes, the times are FILETIME.
+// typedef struct _KERNEL_USER_TIMES {
+// LARGE_INTEGER CreateTime;
+// LARGE_INTEGER ExitTime;
+// LARGE_INTEGER KernelTime;
+// LARGE_INTEGER UserTime;
+// } KERNEL_USER_TIMES, *PKERNEL_USER_TIMES;
+type KERNEL_USER_TIMES struct {
+	CreateTime int64
+	ExitTime   int64 // 0 if the process is running
+	KernelTime int64 // 100-nanosecond units
+	UserTime   int64
+}
+
+// ProcessSessionInformation
+// typedef struct _PROCESS_SESSION_INFORMATION {
+// ULONG SessionId;
+// } PROCESS_SESSION_INFORMATION, *PPROCESS_SESSION_INFORMATION;
+type PROCESS_SESSION_INFORMATION struct {
+	SessionId uint32
+}
+
+// PS_PROTECTED_TYPE
+const (
+	PsProtectedTypeNone           = 0
+	PsProtectedTypeProtectedLight = 1
+	PsProtectedTypeProtected      = 2
+)
+
+// PS_PROTECTED_SIGNER
+const (
+	PsProtectedSignerNone         = 0
+	PsProtectedSignerAuthenticode = 1
+	PsProtectedSignerCodeGen      = 2
+	PsProtectedSignerAntimalware  = 3
+	PsProtectedSignerLsa          = 4
+	PsProtectedSignerWindows      = 5
+	PsProtectedSignerWinTcb       = 6
+	PsProtectedSignerWinSystem    = 7
+	PsProtectedSignerApp          = 8
+)
+
+// ProcessProtectionInformation, since windows 8.1
+// The union of Level and the bit fields Type : 3, Audit : 1, Signer : 4.
+// typedef struct _PS_PROTECTION {
+// UCHAR Level;
+// } PS_PROTECTION, *PPS_PROTECTION;
+type PS_PROTECTION struct {
+	Level uint8
+}
+
+// PsProtectedType*
+func (p PS_PROTECTION) Type() uint8 {
+	return p.Level & 0x7
+}
+
+func (p PS_PROTECTION) Audit() bool {
+	return p.Level&0x8 != 0
+}
+
+// PsProtectedSigner*
+func (p PS_PROTECTION) Signer() uint8 {
+	return p.Level >> 4
+}
+
+// The process of SystemProcessInformation, the threads (SYSTEM_THREAD_INFORMATION) follow it.
+// The layout is the same as PROCESS_BASIC_INFORMATION, it is the pointer size of the current program.
+// https://docs.microsoft.com/en-us/windows/win32/api/winternl/nf-winternl-ntquerysysteminformation
+// http://terminus.rewolf.pl/terminus/structures/ntdll/_SYSTEM_PROCESS_INFORMATION_combined.html
+type SYSTEM_PROCESS_INFORMATION struct {
+	NextEntryOffset              uint32 // 0 for the last process
+	NumberOfThreads              uint32
+	WorkingSetPrivateSize        int64
+	HardFaultCount               uint32
+	NumberOfThreadsHighWatermark uint32
+	CycleTime                    uint64
+	CreateTime                   int64 // FILETIME
+	UserTime                     int64
+	KernelTime                   int64
+	ImageName                    UNICODE_STRING
+	BasePriority                 int32
+	UniqueProcessId              uint
+	InheritedFromUniqueProcessId uint
+	HandleCount                  uint32
+	SessionId                    uint32
+	UniqueProcessKey             uint
+	PeakVirtualSize              uint
+	VirtualSize                  uint
+	PageFaultCount               uint32
+	PeakWorkingSetSize           uint
+	WorkingSetSize               uint
+	QuotaPeakPagedPoolUsage      uint
+	QuotaPagedPoolUsage          uint
+	QuotaPeakNonPagedPoolUsage   uint
+	QuotaNonPagedPoolUsage       uint
+	PagefileUsage                uint
+	PeakPagefileUsage            uint
+	PrivatePageCount             uint
+	ReadOperationCount           int64
+	WriteOperationCount          int64
+	OtherOperationCount          int64
+	ReadTransferCount            int64
+	WriteTransferCount           int64
+	OtherTransferCount           int64
+}
@@ -1 +1,18 @@
 package gowindows
+
+import (
+	"testing"
+)
+
+func TestPS_PROTECTION(t *testing.T) {
+	// PsProtectedSignerWinTcb, PsProtectedTypeProtected
+	p := PS_PROTECTION{0x62}
+	if p.Type() != PsProtectedTypeProtected || p.Signer() != PsProtectedSignerWinTcb || p.Audit() {
+		t.Errorf("%v %v %v", p.Type(), p.Signer(), p.Audit())
+	}
+	// PsProtectedSignerAntimalware, PsProtectedTypeProtectedLight, audit
+	p = PS_PROTECTION{0x39}
+	if p.Type() != PsProtectedTypeProtectedLight || p.Signer() != PsProtectedSignerAntimalware || p.Audit() == false {
+		t.Errorf("%v %v %v", p.Type(), p.Signer(), p.Audit())
+	}
+}
@@ -1,24 +1,25 @@
 package gowindows
 
 import (
+	"errors"
 	"fmt"
+	"syscall"
+	"unicode/utf16"
 
 	"unsafe"
 
-	"syscall"
-
 	"golang.org/x/sys/windows"
 )
 
-const STATUS_SUCCESS = 0
-
 var (
 	ntdll                            *windows.DLL
 	ntWow64QueryInformationProcess64 *windows.Proc
 	ntWow64ReadVirtualMemory64       *windows.Proc
 	ntQueryInformationProcess        *windows.Proc
 	ntReadVirtualMemory              *windows.Proc
+	ntQuerySystemInformation         *windows.Proc
 	ntRtlGetVersion                  *windows.Proc
+	ntRtlNtStatusToDosError          *windows.Proc
 )
 
 func init() {
@@ -30,8 +31,10 @@ func init() {
 		ntWow64ReadVirtualMemory64, _ = ntdll.FindProc("NtWow64ReadVirtualMemory64")
 		ntQueryInformationProcess, _ = ntdll.FindProc("NtQueryInformationProcess")
 		ntReadVirtualMemory, _ = ntdll.FindProc("NtReadVirtualMemory")
+		ntQuerySystemInformation, _ = ntdll.FindProc("NtQuerySystemInformation")
 
 		ntRtlGetVersion, _ = ntdll.FindProc("RtlGetVersion")
+		ntRtlNtStatusToDosError, _ = ntdll.FindProc("RtlNtStatusToDosError")
 	}
 }
 
@@ -43,18 +46,14 @@ func NtWow64QueryInformationProcess64(processHandle Handle, processInformationCl
 		return fmt.Errorf("ntWow64QueryInformationProcess64==nil")
 	}
 
-	r1, _, err := ntWow64QueryInformationProcess64.Call(uintptr(processHandle), uintptr(processInformationClass),
+	r1, _, _ := ntWow64QueryInformationProcess64.Call(uintptr(processHandle), uintptr(processInformationClass),
 		uintptr(unsafe.Pointer(processInformation)), uintptr(processInformationLength),
 		uintptr(unsafe.Pointer(returnLength)))
 
-	if int(r1) < 0 {
-		// If a space mismatch is found inside the function, r1 will be equal to STATUS_INFO_LENGTH_MISMATCH(0xC0000004),
+	if status := NTStatus(r1); status.Success() == false {
+		// If a space mismatch is found inside the function, the status is STATUS_INFO_LENGTH_MISMATCH,
 		// indicates that the space does not match.
-		if err != ERROR_SUCCESS {
-			return err
-		} else {
-			return syscall.EINVAL
-		}
+		return newNTStatusCallError(ntdll.Name, ntWow64QueryInformationProcess64.Name, r1)
 	}
 
 	return nil
@@ -74,19 +73,40 @@ func NtQueryInformationProcess(processHandle Handle, processInformationClass int
 		return fmt.Errorf("ntQueryInformationProcess==nil")
 	}
 
-	r1, _, err := ntQueryInformationProcess.Call(uintptr(processHandle), uintptr(processInformationClass),
+	r1, _, _ := ntQueryInformationProcess.Call(uintptr(processHandle), uintptr(processInformationClass),
 		uintptr(unsafe.Pointer(processInformation)), uintptr(processInformationLength),
 		uintptr(unsafe.Pointer(returnLength)))
 
-	if int(r1) < 0 {
-		// If a space mismatch is found inside the function, r1 will be equal to STATUS_INFO_LENGTH_MISMATCH(0xC0000004),
+	if status := NTStatus(r1); status.Success() == false {
+		// If a space mismatch is found inside the function, the status is STATUS_INFO_LENGTH_MISMATCH,
 		// indicates that the space does not match.
+		return newNTStatusCallError(ntdll.Name, ntQueryInformationProcess.Name, r1)
+	}
 
-		if err != ERROR_SUCCESS {
-			return err
-		} else {
-			return syscall.EINVAL
-		}
+	return nil
+}
+
+//__kernel_entry NTSTATUS NtQuerySystemInformation(
+//IN SYSTEM_INFORMATION_CLASS SystemInformationClass,
+//OUT PVOID                   SystemInformation,
+//IN ULONG                    SystemInformationLength,
+//OUT PULONG ReturnLength     OPTIONAL
+//);
+func NtQuerySystemInformation(systemInformationClass int32,
+	systemInformation windows.Pointer, systemInformationLength uint32, returnLength *uint32) error {
+
+	if ntQuerySystemInformation == nil {
+		return fmt.Errorf("ntQuerySystemInformation==nil")
+	}
+
+	r1, _, _ := ntQuerySystemInformation.Call(uintptr(systemInformationClass),
+		uintptr(unsafe.Pointer(systemInformation)), uintptr(systemInformationLength),
+		uintptr(unsafe.Pointer(returnLength)))
+
+	if status := NTStatus(r1); status.Success() == false {
+		// If the buffer is too small, the status is STATUS_INFO_LENGTH_MISMATCH,
+		// and returnLength is the required length.
+		return newNTStatusCallError(ntdll.Name, ntQuerySystemInformation.Name, r1)
 	}
 
 	return nil
@@ -107,28 +127,22 @@ func NtWow64ReadVirtualMemory64(processHandle Handle, baseAddress uint64,
 	}
 
 	var r1 uintptr
-	var err error
 
 	if ptrSize == 8 {
 		// 64-bit program, although the theory should not be the case
-		r1, _, err = ntWow64ReadVirtualMemory64.Call(uintptr(processHandle), uintptr(baseAddress),
+		r1, _, _ = ntWow64ReadVirtualMemory64.Call(uintptr(processHandle), uintptr(baseAddress),
 			uintptr(unsafe.Pointer(bufferData)), uintptr(bufferSize), uintptr(unsafe.Pointer(returnSize)))
 	} else {
 		// 32-bit program
-		r1, _, err = ntWow64ReadVirtualMemory64.Call(uintptr(processHandle),
+		r1, _, _ = ntWow64ReadVirtualMemory64.Call(uintptr(processHandle),
 			uintptr(baseAddress), uintptr(baseAddress>>32), uintptr(unsafe.Pointer(bufferData)),
 			uintptr(bufferSize), uintptr(bufferSize>>32),
 			uintptr(unsafe.Pointer(returnSize)))
 	}
 
-	if int(r1) < 0 {
+	if status := NTStatus(r1); status.Success() == false {
 		// If the read operation crosses an inaccessible area, it will fail.
-
-		if err != ERROR_SUCCESS {
-			return err
-		} else {
-			return syscall.EINVAL
-		}
+		return newNTStatusCallError(ntdll.Name, ntWow64ReadVirtualMemory64.Name, r1)
 	}
 
 	return nil
@@ -141,21 +155,13 @@ func ReadVirtualMemory(processHandle Handle, baseAddress uint,
 		return fmt.Errorf("ntReadVirtualMemory==nil")
 	}
 
-	var r1 uintptr
-	var err error
-
-	// 64-bit program, although the theory should not be the case
-	r1, _, err = ntReadVirtualMemory.Call(uintptr(processHandle), uintptr(baseAddress),
+	r1, _, _ := ntReadVirtualMemory.Call(uintptr(processHandle), uintptr(baseAddress),
 		uintptr(unsafe.Pointer(bufferData)), uintptr(bufferSize), uintptr(unsafe.Pointer(returnSize)))
 
-	if int(r1) < 0 {
-		// If the read operation crosses an inaccessible area, it will fail.
-
-		if err != ERROR_SUCCESS {
-			return err
-		} else {
-			return syscall.EINVAL
-		}
+	if status := NTStatus(r1); status.Success() == false {
+		// If the read operation crosses an inaccessible area, it will fail, the status is STATUS_PARTIAL_COPY
+		// if a part of it is read.
+		return newNTStatusCallError(ntdll.Name, ntReadVirtualMemory.Name, r1)
 	}
 
 	return nil
@@ -180,6 +186,25 @@ type OsVsersionInfow struct {
 	CSDVersion        [128]WCHAR
 }
 
+// RtlGetVersion fills the extended fields if the size is the size of OSVERSIONINFOEXW.
+//typedef struct _OSVERSIONINFOEXW {
+//  ...
+//  WCHAR  szCSDVersion[128];
+//  USHORT wServicePackMajor;
+//  USHORT wServicePackMinor;
+//  USHORT wSuiteMask;
+//  UCHAR  wProductType;
+//  UCHAR  wReserved;
+//} OSVERSIONINFOEXW, *POSVERSIONINFOEXW, *LPOSVERSIONINFOEXW, RTL_OSVERSIONINFOEXW, *PRTL_OSVERSIONINFOEXW;
+type OsVersionInfoExw struct {
+	OsVsersionInfow
+	ServicePackMajor Word
+	ServicePackMinor Word
+	SuiteMask        Word
+	ProductType      uint8 // VER_NT_*
+	Reserved         uint8
+}
+
 func (v *OsVsersionInfow) GetCSDVersion() string {
 	return windows.UTF16ToString(v.CSDVersion[:])
 }
@@ -258,13 +283,165 @@ func RtlGetVersion() (*OsVsersionInfow, error) {
 	version := new(OsVsersionInfow)
 	version.OSVersionInfoSize = ULong(unsafe.Sizeof(OsVsersionInfow{}))
 
-	r1, _, e1 := ntRtlGetVersion.Call(uintptr(unsafe.Pointer(version)))
-	if r1 != STATUS_SUCCESS {
-		if e1 != ERROR_SUCCESS {
-			return nil, e1
-		} else {
-			return nil, syscall.EINVAL
-		}
+	r1, _, _ := ntRtlGetVersion.Call(uintptr(unsafe.Pointer(version)))
+	if status := NTStatus(r1); status != STATUS_SUCCESS {
+		return nil, newNTStatusCallError(ntdll.Name, ntRtlGetVersion.Name, r1)
+	}
+	return version, nil
+}
+
+// RtlGetVersion with OSVERSIONINFOEXW, the product type is VER_NT_* of it.
+func RtlGetVersionEx() (*OsVersionInfoExw, error) {
+	if ntRtlGetVersion == nil {
+		return nil, fmt.Errorf("ntRtlGetVersion==nil")
+	}
+
+	version := new(OsVersionInfoExw)
+	version.OSVersionInfoSize = ULong(unsafe.Sizeof(OsVersionInfoExw{}))
+
+	r1, _, _ := ntRtlGetVersion.Call(uintptr(unsafe.Pointer(version)))
+	if status := NTStatus(r1); status != STATUS_SUCCESS {
+		return nil, newNTStatusCallError(ntdll.Name, ntRtlGetVersion.Name, r1)
 	}
 	return version, nil
 }
+
+// The win32 error of the status, NTStatus.Win32Error is the same for the statuses of ntstatus.txt.
+// https://docs.microsoft.com/en-us/windows/win32/api/winternl/nf-winternl-rtlntstatustodoserror
+func RtlNtStatusToDosError(status NTStatus) syscall.Errno {
+	if ntRtlNtStatusToDosError == nil {
+		return status.Win32Error()
+	}
+	r1, _, _ := ntRtlNtStatusToDosError.Call(uintptr(status))
+	return syscall.Errno(r1)
+}
+
+// The buffer is too small, the needed size is returned.
+func isBufferSizeStatus(err error) bool {
+	return errors.Is(err, STATUS_INFO_LENGTH_MISMATCH) || errors.Is(err, STATUS_BUFFER_TOO_SMALL) ||
+		errors.Is(err, STATUS_BUFFER_OVERFLOW)
+}
+
+func queryProcessInformation(process Handle, infoClass int32, info unsafe.Pointer, size uintptr) error {
+	err := NtQueryInformationProcess(process, infoClass, windows.Pointer(info), uint32(size), nil)
+	if err != nil {
+		return fmt.Errorf("NtQueryInformationProcess(%v), %w", infoClass, err)
+	}
+	return nil
+}
+
+// Query the UNICODE_STRING followed by its buffer, such as ProcessImageFileName.
+func queryProcessUnicodeString(process Handle, infoClass int32) (string, error) {
+	size := uint32(1024)
+	for {
+		// []uint64 aligns UNICODE_STRING
+		buf := make([]uint64, (size+7)/8)
+		size = uint32(len(buf) * 8)
+		needed := uint32(0)
+		err := NtQueryInformationProcess(process, infoClass, windows.Pointer(unsafe.Pointer(&buf[0])), size, &needed)
+		if err != nil {
+			if isBufferSizeStatus(err) && needed > size {
+				size = needed
+				continue
+			}
+			return "", fmt.Errorf("NtQueryInformationProcess(%v), %w", infoClass, err)
+		}
+
+		s := (*UNICODE_STRING)(unsafe.Pointer(&buf[0]))
+		if s.Length == 0 {
+			return "", nil
+		}
+		// The buffer points to the memory after UNICODE_STRING
+		base := uintptr(unsafe.Pointer(&buf[0]))
+		chars := (*[1 << 28]uint16)(unsafe.Pointer(&buf[0]))[: size/2 : size/2]
+		start := (uintptr(s.Buffer) - base) / 2
+		end := start + uintptr(s.Length)/2
+		if uintptr(s.Buffer) < base || end > uintptr(len(chars)) {
+			return "", fmt.Errorf("NtQueryInformationProcess(%v), the string is out of the buffer", infoClass)
+		}
+		return string(utf16.Decode(chars[start:end])), nil
+	}
+}
+
+// The NT path of the image (ProcessImageFileName), such as \Device\HarddiskVolume2\Windows\notepad.exe.
+// The process needs PROCESS_QUERY_LIMITED_INFORMATION.
+func QueryProcessImageFileName(process Handle) (string, error) {
+	return queryProcessUnicodeString(process, ProcessImageFileName)
+}
+
+// The win32 path of the image (ProcessImageFileNameWin32), such as C:\Windows\notepad.exe.
+// The process needs PROCESS_QUERY_LIMITED_INFORMATION.
+func QueryProcessImageFileNameWin32(process Handle) (string, error) {
+	return queryProcessUnicodeString(process, ProcessImageFileNameWin32)
+}
+
+// The command line (ProcessCommandLineInformation), since windows 8.1.
+// The process needs PROCESS_QUERY_LIMITED_INFORMATION, it is not read from the memory of the process.
+func QueryProcessCommandLine(process Handle) (string, error) {
+	return queryProcessUnicodeString(process, ProcessCommandLineInformation)
+}
+
+// The address of the 32-bit PEB of the WoW64 process (ProcessWow64Information), 0 if the process is not the WoW64 process.
+// The process needs PROCESS_QUERY_LIMITED_INFORMATION.
+func QueryProcessWow64Information(process Handle) (uintptr, error) {
+	peb := uintptr(0)
+	if err := queryProcessInformation(process, ProcessWow64Information, unsafe.Pointer(&peb), unsafe.Sizeof(peb)); err != nil {
+		return 0, err
+	}
+	return peb, nil
+}
+
+// The I/O counters (ProcessIoCounters), the process needs PROCESS_QUERY_LIMITED_INFORMATION.
+func QueryProcessIoCounters(process Handle) (*IO_COUNTERS, error) {
+	info := new(IO_COUNTERS)
+	if err := queryProcessInformation(process, ProcessIoCounters, unsafe.Pointer(info), unsafe.Sizeof(*info)); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// The memory counters (ProcessVmCounters), the process needs PROCESS_QUERY_LIMITED_INFORMATION.
+func QueryProcessVmCounters(process Handle) (*VM_COUNTERS_EX, error) {
+	info := new(VM_COUNTERS_EX)
+	if err := queryProcessInformation(process, ProcessVmCounters, unsafe.Pointer(info), unsafe.Sizeof(*info)); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// The times (ProcessTimes), the process needs PROCESS_QUERY_LIMITED_INFORMATION.
+func QueryProcessTimes(process Handle) (*KERNEL_USER_TIMES, error) {
+	info := new(KERNEL_USER_TIMES)
+	if err := queryProcessInformation(process, ProcessTimes, unsafe.Pointer(info), unsafe.Sizeof(*info)); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// The count of the handles (ProcessHandleCount), the process needs PROCESS_QUERY_INFORMATION.
+func QueryProcessHandleCount(process Handle) (uint32, error) {
+	count := uint32(0)
+	if err := queryProcessInformation(process, ProcessHandleCount, unsafe.Pointer(&count), unsafe.Sizeof(count)); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// The session id (ProcessSessionInformation), the process needs PROCESS_QUERY_LIMITED_INFORMATION.
+func QueryProcessSessionId(process Handle) (uint32, error) {
+	info := PROCESS_SESSION_INFORMATION{}
+	if err := queryProcessInformation(process, ProcessSessionInformation, unsafe.Pointer(&info), unsafe.Sizeof(info)); err != nil {
+		return 0, err
+	}
+	return info.SessionId, nil
+}
+
+// The protection (ProcessProtectionInformation), since windows 8.1.
+// The process needs PROCESS_QUERY_LIMITED_INFORMATION.
+func QueryProcessProtection(process Handle) (PS_PROTECTION, error) {
+	info := PS_PROTECTION{}
+	if err := queryProcessInformation(process, ProcessProtectionInformation, unsafe.Pointer(&info), unsafe.Sizeof(info)); err != nil {
+		return PS_PROTECTION{}, err
+	}
+	return info, nil
+}
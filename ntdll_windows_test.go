@@ -3,8 +3,14 @@ package gowindows
 import (
 	"bytes"
 	"crypto/rand"
+	"errors"
+	"os"
+	"path/filepath"
 	"runtime"
+	"strings"
+	"syscall"
 	"testing"
+	"time"
 	"unsafe"
 
 	"os/exec"
@@ -155,3 +161,94 @@ func TestRtlGetVersion(t *testing.T) {
 
 	//fmt.Printf("windows version :%v", version.GetString())
 }
+
+func TestQueryProcessInformation(t *testing.T) {
+	if os.Getenv("GOWINDOWS_PROCESS_HELPER") != "" {
+		t.Skip("helper process")
+	}
+	p := startSleepProcess(t, 0)
+	defer p.Close()
+	defer p.Kill()
+
+	exe, err := os.Executable()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name, err := QueryProcessImageFileName(p.Handle()); err != nil || strings.HasPrefix(name, `\Device\`) == false || strings.HasSuffix(strings.ToLower(name), strings.ToLower(filepath.Base(exe))) == false {
+		t.Errorf("%v %v", name, err)
+	}
+	if name, err := QueryProcessImageFileNameWin32(p.Handle()); err != nil || strings.EqualFold(name, exe) == false {
+		t.Errorf("%v!=%v %v", name, exe, err)
+	}
+	if cmdLine, err := QueryProcessCommandLine(p.Handle()); err != nil || strings.Contains(cmdLine, "TestProcessSpecHelperProcess") == false {
+		t.Errorf("%v %v", cmdLine, err)
+	}
+
+	if peb, err := QueryProcessWow64Information(p.Handle()); err != nil {
+		t.Error(err)
+	} else {
+		wow64, err := IsWow64Process(p.Handle())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if (peb != 0) != wow64 {
+			t.Errorf("0x%x %v", peb, wow64)
+		}
+	}
+
+	if io, err := QueryProcessIoCounters(Handle(windows.CurrentProcess())); err != nil || io.ReadOperationCount == 0 {
+		t.Errorf("%#v %v", io, err)
+	}
+	if vm, err := QueryProcessVmCounters(p.Handle()); err != nil || vm.WorkingSetSize == 0 || vm.PeakWorkingSetSize < vm.WorkingSetSize || vm.PrivateUsage == 0 {
+		t.Errorf("%#v %v", vm, err)
+	}
+	if times, err := QueryProcessTimes(p.Handle()); err != nil {
+		t.Error(err)
+	} else if created := filetimeToTime(times.CreateTime); time.Since(created) < 0 || time.Since(created) > time.Minute || times.ExitTime != 0 {
+		t.Errorf("%v %#v", created, times)
+	}
+	if count, err := QueryProcessHandleCount(p.Handle()); err != nil || count == 0 {
+		t.Errorf("%v %v", count, err)
+	}
+
+	processes, err := Processes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	entry, _ := NewProcessTree(processes).Get(p.Pid())
+	if id, err := QueryProcessSessionId(p.Handle()); err != nil || id != entry.SessionId {
+		t.Errorf("%v!=%v %v", id, entry.SessionId, err)
+	}
+
+	if protection, err := QueryProcessProtection(p.Handle()); err != nil || protection.Type() != PsProtectedTypeNone {
+		t.Errorf("%v %v", protection, err)
+	}
+}
+
+func TestRtlNtStatusToDosError(t *testing.T) {
+	for status, info := range ntStatusTable {
+		if info.win32 == ERROR_MR_MID_NOT_FOUND {
+			// It is not mapped or the exception code
+			continue
+		}
+		if e := RtlNtStatusToDosError(status); e != info.win32 {
+			t.Errorf("%v: %d!=%d", status, e, info.win32)
+		}
+	}
+	if e := RtlNtStatusToDosError(NTStatusFromWin32(ERROR_INVALID_PARAMETER)); e != ERROR_INVALID_PARAMETER {
+		t.Errorf("%d!=%d", e, ERROR_INVALID_PARAMETER)
+	}
+}
+
+func TestNtQueryInformationProcessStatus(t *testing.T) {
+	basic := PROCESS_BASIC_INFORMATION{}
+	// The length is too small
+	err := NtQueryInformationProcess(Handle(windows.CurrentProcess()), ProcessBasicInformation,
+		windows.Pointer(unsafe.Pointer(&basic)), 4, nil)
+	if errors.Is(err, STATUS_INFO_LENGTH_MISMATCH) == false {
+		t.Errorf("%v!=STATUS_INFO_LENGTH_MISMATCH", err)
+	}
+	if errors.Is(err, syscall.Errno(24)) == false {
+		t.Errorf("%v is not ERROR_BAD_LENGTH", err)
+	}
+}
@@ -0,0 +1,143 @@
+package gowindows
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+)
+
+//go:generate go run mkntstatus.go
+
+// The NTSTATUS of the native api, it is an error if it is not a success.
+// The names and the messages of the known values are in ntstatus_table.go, they do not depend on FormatMessage.
+// https://docs.microsoft.com/en-us/openspecs/windows_protocols/ms-erref/87fba13e-bf06-450e-83b1-9241dc81e781
+//
+//	err := NtQueryInformationProcess(...)
+//	if errors.Is(err, STATUS_INFO_LENGTH_MISMATCH) { ... }
+//	if errors.Is(err, windows.ERROR_ACCESS_DENIED) { ... } // the win32 error of the status
+type NTStatus uint32
+
+// The severity of NTSTATUS
+const (
+	STATUS_SEVERITY_SUCCESS       = 0x0
+	STATUS_SEVERITY_INFORMATIONAL = 0x1
+	STATUS_SEVERITY_WARNING       = 0x2
+	STATUS_SEVERITY_ERROR         = 0x3
+)
+
+// The facility of NTSTATUS
+const (
+	FACILITY_DEBUGGER        = 0x1
+	FACILITY_RPC_RUNTIME     = 0x2
+	FACILITY_RPC_STUBS       = 0x3
+	FACILITY_IO_ERROR_CODE   = 0x4
+	FACILITY_NTWIN32         = 0x7
+	FACILITY_NTSSPI          = 0x9
+	FACILITY_FWP_ERROR_CODE  = 0x22
+	FACILITY_NDIS_ERROR_CODE = 0x23
+)
+
+const ERROR_MR_MID_NOT_FOUND syscall.Errno = 317
+
+type ntStatusInfo struct {
+	name    string
+	win32   syscall.Errno
+	message string
+}
+
+// NTSTATUS_FROM_WIN32, the status of FACILITY_NTWIN32.
+func NTStatusFromWin32(e syscall.Errno) NTStatus {
+	if int32(e) <= 0 {
+		return NTStatus(e)
+	}
+	return NTStatus(e&0xFFFF) | FACILITY_NTWIN32<<16 | STATUS_SEVERITY_ERROR<<30
+}
+
+// STATUS_SEVERITY_*
+func (s NTStatus) Severity() uint32 {
+	return uint32(s) >> 30
+}
+
+// The customer bit, the status is not defined by microsoft.
+func (s NTStatus) Customer() bool {
+	return s&0x20000000 != 0
+}
+
+// FACILITY_*
+func (s NTStatus) Facility() uint32 {
+	return uint32(s) >> 16 & 0xFFF
+}
+
+func (s NTStatus) Code() uint32 {
+	return uint32(s) & 0xFFFF
+}
+
+// NT_SUCCESS, the success and the informational status.
+func (s NTStatus) Success() bool {
+	return int32(s) >= 0
+}
+
+// NT_ERROR, it is not the warning status.
+func (s NTStatus) IsError() bool {
+	return s.Severity() == STATUS_SEVERITY_ERROR
+}
+
+// The name of the status, such as STATUS_ACCESS_DENIED, or NTSTATUS(0xC0001234) if it is unknown.
+func (s NTStatus) String() string {
+	if info, ok := ntStatusTable[s]; ok {
+		return info.name
+	}
+	return fmt.Sprintf("NTSTATUS(0x%08X)", uint32(s))
+}
+
+// The message of the status, or empty if it is unknown.
+func (s NTStatus) Message() string {
+	return ntStatusTable[s].message
+}
+
+func (s NTStatus) Error() string {
+	info, ok := ntStatusTable[s]
+	if ok == false {
+		return fmt.Sprintf("NTSTATUS 0x%08X (facility 0x%X, code 0x%X)", uint32(s), s.Facility(), s.Code())
+	}
+	return fmt.Sprintf("%v (0x%08X): %v", info.name, uint32(s), info.message)
+}
+
+// The win32 error of the status as RtlNtStatusToDosError, ERROR_MR_MID_NOT_FOUND if it is unknown.
+// The status of FACILITY_NTWIN32 is the win32 error in the code.
+func (s NTStatus) Win32Error() syscall.Errno {
+	if info, ok := ntStatusTable[s]; ok {
+		return info.win32
+	}
+	if s.Facility() == FACILITY_NTWIN32 && s.Customer() == false {
+		return syscall.Errno(s.Code())
+	}
+	return ERROR_MR_MID_NOT_FOUND
+}
+
+// errors.Is, the status is the win32 error of it, and the os errors as syscall.Errno on windows.
+func (s NTStatus) Is(target error) bool {
+	if s.Success() {
+		return false
+	}
+	switch target {
+	case os.ErrPermission:
+		return s.Win32Error() == 5 // ERROR_ACCESS_DENIED
+	case os.ErrExist:
+		switch s.Win32Error() {
+		case 183, 145, 80: // ERROR_ALREADY_EXISTS, ERROR_DIR_NOT_EMPTY, ERROR_FILE_EXISTS
+			return true
+		}
+		return false
+	case os.ErrNotExist:
+		switch s.Win32Error() {
+		case 2, 3, 53: // ERROR_FILE_NOT_FOUND, ERROR_PATH_NOT_FOUND, ERROR_BAD_NETPATH
+			return true
+		}
+		return false
+	}
+	if e, ok := target.(syscall.Errno); ok {
+		return e != ERROR_MR_MID_NOT_FOUND && e == s.Win32Error()
+	}
+	return false
+}
@@ -0,0 +1,287 @@
+// Code generated by mkntstatus.go from ntstatus.txt; DO NOT EDIT.
+
+package gowindows
+
+const (
+	STATUS_SUCCESS                  NTStatus = 0x00000000
+	STATUS_ABANDONED                NTStatus = 0x00000080
+	STATUS_ALERTED                  NTStatus = 0x00000101
+	STATUS_TIMEOUT                  NTStatus = 0x00000102
+	STATUS_PENDING                  NTStatus = 0x00000103
+	STATUS_MORE_ENTRIES             NTStatus = 0x00000105
+	STATUS_NOT_ALL_ASSIGNED         NTStatus = 0x00000106
+	STATUS_OBJECT_NAME_EXISTS       NTStatus = 0x40000000
+	STATUS_GUARD_PAGE_VIOLATION     NTStatus = 0x80000001
+	STATUS_DATATYPE_MISALIGNMENT    NTStatus = 0x80000002
+	STATUS_BREAKPOINT               NTStatus = 0x80000003
+	STATUS_SINGLE_STEP              NTStatus = 0x80000004
+	STATUS_BUFFER_OVERFLOW          NTStatus = 0x80000005
+	STATUS_NO_MORE_FILES            NTStatus = 0x80000006
+	STATUS_PARTIAL_COPY             NTStatus = 0x8000000D
+	STATUS_DEVICE_BUSY              NTStatus = 0x80000011
+	STATUS_NO_MORE_ENTRIES          NTStatus = 0x8000001A
+	STATUS_UNSUCCESSFUL             NTStatus = 0xC0000001
+	STATUS_NOT_IMPLEMENTED          NTStatus = 0xC0000002
+	STATUS_INVALID_INFO_CLASS       NTStatus = 0xC0000003
+	STATUS_INFO_LENGTH_MISMATCH     NTStatus = 0xC0000004
+	STATUS_ACCESS_VIOLATION         NTStatus = 0xC0000005
+	STATUS_IN_PAGE_ERROR            NTStatus = 0xC0000006
+	STATUS_PAGEFILE_QUOTA           NTStatus = 0xC0000007
+	STATUS_INVALID_HANDLE           NTStatus = 0xC0000008
+	STATUS_INVALID_CID              NTStatus = 0xC000000B
+	STATUS_INVALID_PARAMETER        NTStatus = 0xC000000D
+	STATUS_NO_SUCH_DEVICE           NTStatus = 0xC000000E
+	STATUS_NO_SUCH_FILE             NTStatus = 0xC000000F
+	STATUS_INVALID_DEVICE_REQUEST   NTStatus = 0xC0000010
+	STATUS_END_OF_FILE              NTStatus = 0xC0000011
+	STATUS_NO_MEDIA_IN_DEVICE       NTStatus = 0xC0000013
+	STATUS_NO_MEMORY                NTStatus = 0xC0000017
+	STATUS_CONFLICTING_ADDRESSES    NTStatus = 0xC0000018
+	STATUS_UNABLE_TO_FREE_VM        NTStatus = 0xC000001A
+	STATUS_ILLEGAL_INSTRUCTION      NTStatus = 0xC000001D
+	STATUS_INVALID_VIEW_SIZE        NTStatus = 0xC000001F
+	STATUS_ALREADY_COMMITTED        NTStatus = 0xC0000021
+	STATUS_ACCESS_DENIED            NTStatus = 0xC0000022
+	STATUS_BUFFER_TOO_SMALL         NTStatus = 0xC0000023
+	STATUS_OBJECT_TYPE_MISMATCH     NTStatus = 0xC0000024
+	STATUS_NONCONTINUABLE_EXCEPTION NTStatus = 0xC0000025
+	STATUS_INVALID_DISPOSITION      NTStatus = 0xC0000026
+	STATUS_NOT_COMMITTED            NTStatus = 0xC000002D
+	STATUS_OBJECT_NAME_INVALID      NTStatus = 0xC0000033
+	STATUS_OBJECT_NAME_NOT_FOUND    NTStatus = 0xC0000034
+	STATUS_OBJECT_NAME_COLLISION    NTStatus = 0xC0000035
+	STATUS_PORT_DISCONNECTED        NTStatus = 0xC0000037
+	STATUS_OBJECT_PATH_INVALID      NTStatus = 0xC0000039
+	STATUS_OBJECT_PATH_NOT_FOUND    NTStatus = 0xC000003A
+	STATUS_OBJECT_PATH_SYNTAX_BAD   NTStatus = 0xC000003B
+	STATUS_DATA_ERROR               NTStatus = 0xC000003E
+	STATUS_CRC_ERROR                NTStatus = 0xC000003F
+	STATUS_SECTION_TOO_BIG          NTStatus = 0xC0000040
+	STATUS_INVALID_PORT_HANDLE      NTStatus = 0xC0000042
+	STATUS_SHARING_VIOLATION        NTStatus = 0xC0000043
+	STATUS_QUOTA_EXCEEDED           NTStatus = 0xC0000044
+	STATUS_MUTANT_NOT_OWNED         NTStatus = 0xC0000046
+	STATUS_SEMAPHORE_LIMIT_EXCEEDED NTStatus = 0xC0000047
+	STATUS_THREAD_IS_TERMINATING    NTStatus = 0xC000004B
+	STATUS_FILE_LOCK_CONFLICT       NTStatus = 0xC0000054
+	STATUS_LOCK_NOT_GRANTED         NTStatus = 0xC0000055
+	STATUS_DELETE_PENDING           NTStatus = 0xC0000056
+	STATUS_INVALID_OWNER            NTStatus = 0xC000005A
+	STATUS_PRIVILEGE_NOT_HELD       NTStatus = 0xC0000061
+	STATUS_NO_SUCH_USER             NTStatus = 0xC0000064
+	STATUS_WRONG_PASSWORD           NTStatus = 0xC000006A
+	STATUS_LOGON_FAILURE            NTStatus = 0xC000006D
+	STATUS_ACCOUNT_DISABLED         NTStatus = 0xC0000072
+	STATUS_NONE_MAPPED              NTStatus = 0xC0000073
+	STATUS_INVALID_ACL              NTStatus = 0xC0000077
+	STATUS_INVALID_SID              NTStatus = 0xC0000078
+	STATUS_INVALID_SECURITY_DESCR   NTStatus = 0xC0000079
+	STATUS_PROCEDURE_NOT_FOUND      NTStatus = 0xC000007A
+	STATUS_INVALID_IMAGE_FORMAT     NTStatus = 0xC000007B
+	STATUS_NO_TOKEN                 NTStatus = 0xC000007C
+	STATUS_DISK_FULL                NTStatus = 0xC000007F
+	STATUS_ARRAY_BOUNDS_EXCEEDED    NTStatus = 0xC000008C
+	STATUS_FLOAT_DIVIDE_BY_ZERO     NTStatus = 0xC000008E
+	STATUS_INTEGER_DIVIDE_BY_ZERO   NTStatus = 0xC0000094
+	STATUS_INTEGER_OVERFLOW         NTStatus = 0xC0000095
+	STATUS_PRIVILEGED_INSTRUCTION   NTStatus = 0xC0000096
+	STATUS_FILE_INVALID             NTStatus = 0xC0000098
+	STATUS_INSUFFICIENT_RESOURCES   NTStatus = 0xC000009A
+	STATUS_MEMORY_NOT_ALLOCATED     NTStatus = 0xC00000A0
+	STATUS_WORKING_SET_QUOTA        NTStatus = 0xC00000A1
+	STATUS_MEDIA_WRITE_PROTECTED    NTStatus = 0xC00000A2
+	STATUS_DEVICE_NOT_READY         NTStatus = 0xC00000A3
+	STATUS_BAD_IMPERSONATION_LEVEL  NTStatus = 0xC00000A5
+	STATUS_CANT_OPEN_ANONYMOUS      NTStatus = 0xC00000A6
+	STATUS_INSTANCE_NOT_AVAILABLE   NTStatus = 0xC00000AB
+	STATUS_PIPE_BUSY                NTStatus = 0xC00000AE
+	STATUS_ILLEGAL_FUNCTION         NTStatus = 0xC00000AF
+	STATUS_PIPE_DISCONNECTED        NTStatus = 0xC00000B0
+	STATUS_IO_TIMEOUT               NTStatus = 0xC00000B5
+	STATUS_FILE_IS_A_DIRECTORY      NTStatus = 0xC00000BA
+	STATUS_NOT_SUPPORTED            NTStatus = 0xC00000BB
+	STATUS_BAD_NETWORK_PATH         NTStatus = 0xC00000BE
+	STATUS_NETWORK_ACCESS_DENIED    NTStatus = 0xC00000CA
+	STATUS_BAD_DEVICE_TYPE          NTStatus = 0xC00000CB
+	STATUS_BAD_NETWORK_NAME         NTStatus = 0xC00000CC
+	STATUS_REQUEST_NOT_ACCEPTED     NTStatus = 0xC00000D0
+	STATUS_NOT_SAME_DEVICE          NTStatus = 0xC00000D4
+	STATUS_INTERNAL_ERROR           NTStatus = 0xC00000E5
+	STATUS_INVALID_USER_BUFFER      NTStatus = 0xC00000E8
+	STATUS_INVALID_PARAMETER_1      NTStatus = 0xC00000EF
+	STATUS_INVALID_PARAMETER_2      NTStatus = 0xC00000F0
+	STATUS_INVALID_PARAMETER_3      NTStatus = 0xC00000F1
+	STATUS_STACK_OVERFLOW           NTStatus = 0xC00000FD
+	STATUS_DIRECTORY_NOT_EMPTY      NTStatus = 0xC0000101
+	STATUS_NOT_A_DIRECTORY          NTStatus = 0xC0000103
+	STATUS_NAME_TOO_LONG            NTStatus = 0xC0000106
+	STATUS_PROCESS_IS_TERMINATING   NTStatus = 0xC000010A
+	STATUS_TOO_MANY_OPENED_FILES    NTStatus = 0xC000011F
+	STATUS_CANCELLED                NTStatus = 0xC0000120
+	STATUS_FILE_DELETED             NTStatus = 0xC0000123
+	STATUS_FILE_CLOSED              NTStatus = 0xC0000128
+	STATUS_COMMITMENT_LIMIT         NTStatus = 0xC000012D
+	STATUS_DLL_NOT_FOUND            NTStatus = 0xC0000135
+	STATUS_ORDINAL_NOT_FOUND        NTStatus = 0xC0000138
+	STATUS_ENTRYPOINT_NOT_FOUND     NTStatus = 0xC0000139
+	STATUS_CONTROL_C_EXIT           NTStatus = 0xC000013A
+	STATUS_DLL_INIT_FAILED          NTStatus = 0xC0000142
+	STATUS_INVALID_LEVEL            NTStatus = 0xC0000148
+	STATUS_PIPE_BROKEN              NTStatus = 0xC000014B
+	STATUS_INVALID_DEVICE_STATE     NTStatus = 0xC0000184
+	STATUS_CONNECTION_RESET         NTStatus = 0xC000020D
+	STATUS_NOT_FOUND                NTStatus = 0xC0000225
+	STATUS_HANDLE_NOT_CLOSABLE      NTStatus = 0xC0000235
+	STATUS_CONNECTION_REFUSED       NTStatus = 0xC0000236
+	STATUS_NETWORK_UNREACHABLE      NTStatus = 0xC000023C
+	STATUS_HOST_UNREACHABLE         NTStatus = 0xC000023D
+	STATUS_VOLUME_DISMOUNTED        NTStatus = 0xC000026E
+	STATUS_NOINTERFACE              NTStatus = 0xC00002B9
+	STATUS_HEAP_CORRUPTION          NTStatus = 0xC0000374
+	STATUS_STACK_BUFFER_OVERRUN     NTStatus = 0xC0000409
+	STATUS_ASSERTION_FAILURE        NTStatus = 0xC0000420
+	STATUS_INVALID_IMAGE_HASH       NTStatus = 0xC0000428
+	STATUS_PROCESS_IS_PROTECTED     NTStatus = 0xC0000712
+)
+
+var ntStatusTable = map[NTStatus]ntStatusInfo{
+	STATUS_SUCCESS:                  {"STATUS_SUCCESS", 0, "The operation completed successfully."},
+	STATUS_ABANDONED:                {"STATUS_ABANDONED", 317, "The mutex was abandoned by the thread which owned it."},
+	STATUS_ALERTED:                  {"STATUS_ALERTED", 317, "The delay completed because the thread was alerted."},
+	STATUS_TIMEOUT:                  {"STATUS_TIMEOUT", 1460, "The given timeout interval expired."},
+	STATUS_PENDING:                  {"STATUS_PENDING", 997, "The operation that was requested is pending completion."},
+	STATUS_MORE_ENTRIES:             {"STATUS_MORE_ENTRIES", 234, "Returned by enumeration APIs to indicate more information is available to successive calls."},
+	STATUS_NOT_ALL_ASSIGNED:         {"STATUS_NOT_ALL_ASSIGNED", 1300, "Indicates not all privileges or groups referenced are assigned to the caller."},
+	STATUS_OBJECT_NAME_EXISTS:       {"STATUS_OBJECT_NAME_EXISTS", 183, "An attempt was made to create an object and the object name already existed."},
+	STATUS_GUARD_PAGE_VIOLATION:     {"STATUS_GUARD_PAGE_VIOLATION", 317, "A page of memory that marks the end of a data structure, such as a stack or an array, has been accessed."},
+	STATUS_DATATYPE_MISALIGNMENT:    {"STATUS_DATATYPE_MISALIGNMENT", 317, "A datatype misalignment was detected in a load or store instruction."},
+	STATUS_BREAKPOINT:               {"STATUS_BREAKPOINT", 317, "A breakpoint has been reached."},
+	STATUS_SINGLE_STEP:              {"STATUS_SINGLE_STEP", 317, "A single step or trace operation has just been completed."},
+	STATUS_BUFFER_OVERFLOW:          {"STATUS_BUFFER_OVERFLOW", 234, "The data was too large to fit into the specified buffer."},
+	STATUS_NO_MORE_FILES:            {"STATUS_NO_MORE_FILES", 18, "No more files were found which match the file specification."},
+	STATUS_PARTIAL_COPY:             {"STATUS_PARTIAL_COPY", 299, "Because of protection conflicts, not all the requested bytes could be copied."},
+	STATUS_DEVICE_BUSY:              {"STATUS_DEVICE_BUSY", 170, "The device is currently busy."},
+	STATUS_NO_MORE_ENTRIES:          {"STATUS_NO_MORE_ENTRIES", 259, "No more entries are available from an enumeration operation."},
+	STATUS_UNSUCCESSFUL:             {"STATUS_UNSUCCESSFUL", 31, "The requested operation was unsuccessful."},
+	STATUS_NOT_IMPLEMENTED:          {"STATUS_NOT_IMPLEMENTED", 1, "The requested operation is not implemented."},
+	STATUS_INVALID_INFO_CLASS:       {"STATUS_INVALID_INFO_CLASS", 87, "The specified information class is not a valid information class for the specified object."},
+	STATUS_INFO_LENGTH_MISMATCH:     {"STATUS_INFO_LENGTH_MISMATCH", 24, "The specified information record length does not match the length that is required for the specified information class."},
+	STATUS_ACCESS_VIOLATION:         {"STATUS_ACCESS_VIOLATION", 998, "The instruction referenced memory that could not be accessed."},
+	STATUS_IN_PAGE_ERROR:            {"STATUS_IN_PAGE_ERROR", 999, "The required data was not placed into memory because of an I/O error."},
+	STATUS_PAGEFILE_QUOTA:           {"STATUS_PAGEFILE_QUOTA", 1454, "The page file quota for the process has been exhausted."},
+	STATUS_INVALID_HANDLE:           {"STATUS_INVALID_HANDLE", 6, "An invalid HANDLE was specified."},
+	STATUS_INVALID_CID:              {"STATUS_INVALID_CID", 87, "An invalid client ID was specified."},
+	STATUS_INVALID_PARAMETER:        {"STATUS_INVALID_PARAMETER", 87, "An invalid parameter was passed to a service or function."},
+	STATUS_NO_SUCH_DEVICE:           {"STATUS_NO_SUCH_DEVICE", 2, "A device that does not exist was specified."},
+	STATUS_NO_SUCH_FILE:             {"STATUS_NO_SUCH_FILE", 2, "The file does not exist."},
+	STATUS_INVALID_DEVICE_REQUEST:   {"STATUS_INVALID_DEVICE_REQUEST", 1, "The specified request is not a valid operation for the target device."},
+	STATUS_END_OF_FILE:              {"STATUS_END_OF_FILE", 38, "The end-of-file marker has been reached."},
+	STATUS_NO_MEDIA_IN_DEVICE:       {"STATUS_NO_MEDIA_IN_DEVICE", 21, "There is no disk in the drive."},
+	STATUS_NO_MEMORY:                {"STATUS_NO_MEMORY", 8, "Not enough virtual memory or paging file quota is available to complete the specified operation."},
+	STATUS_CONFLICTING_ADDRESSES:    {"STATUS_CONFLICTING_ADDRESSES", 487, "The specified address range conflicts with the address space."},
+	STATUS_UNABLE_TO_FREE_VM:        {"STATUS_UNABLE_TO_FREE_VM", 87, "The address range to free is not a valid virtual memory region."},
+	STATUS_ILLEGAL_INSTRUCTION:      {"STATUS_ILLEGAL_INSTRUCTION", 317, "An attempt was made to execute an illegal instruction."},
+	STATUS_INVALID_VIEW_SIZE:        {"STATUS_INVALID_VIEW_SIZE", 5, "The specified view size is not valid for the section."},
+	STATUS_ALREADY_COMMITTED:        {"STATUS_ALREADY_COMMITTED", 5, "The specified address range is already committed."},
+	STATUS_ACCESS_DENIED:            {"STATUS_ACCESS_DENIED", 5, "A process has requested access to an object but has not been granted those access rights."},
+	STATUS_BUFFER_TOO_SMALL:         {"STATUS_BUFFER_TOO_SMALL", 122, "The buffer is too small to contain the entry."},
+	STATUS_OBJECT_TYPE_MISMATCH:     {"STATUS_OBJECT_TYPE_MISMATCH", 6, "There is a mismatch between the type of object that is required by the requested operation and the type of object that is specified in the request."},
+	STATUS_NONCONTINUABLE_EXCEPTION: {"STATUS_NONCONTINUABLE_EXCEPTION", 317, "The thread attempted to continue execution after a noncontinuable exception."},
+	STATUS_INVALID_DISPOSITION:      {"STATUS_INVALID_DISPOSITION", 317, "An invalid exception disposition was returned by an exception handler."},
+	STATUS_NOT_COMMITTED:            {"STATUS_NOT_COMMITTED", 487, "An attempt was made to decommit uncommitted virtual memory."},
+	STATUS_OBJECT_NAME_INVALID:      {"STATUS_OBJECT_NAME_INVALID", 123, "The object name is invalid."},
+	STATUS_OBJECT_NAME_NOT_FOUND:    {"STATUS_OBJECT_NAME_NOT_FOUND", 2, "The object name is not found."},
+	STATUS_OBJECT_NAME_COLLISION:    {"STATUS_OBJECT_NAME_COLLISION", 183, "The object name already exists."},
+	STATUS_PORT_DISCONNECTED:        {"STATUS_PORT_DISCONNECTED", 6, "An attempt was made to send a message to a disconnected communication port."},
+	STATUS_OBJECT_PATH_INVALID:      {"STATUS_OBJECT_PATH_INVALID", 161, "The object path component was not a directory object."},
+	STATUS_OBJECT_PATH_NOT_FOUND:    {"STATUS_OBJECT_PATH_NOT_FOUND", 3, "The path does not exist."},
+	STATUS_OBJECT_PATH_SYNTAX_BAD:   {"STATUS_OBJECT_PATH_SYNTAX_BAD", 161, "The object path component was not valid."},
+	STATUS_DATA_ERROR:               {"STATUS_DATA_ERROR", 23, "An error in reading or writing data occurred."},
+	STATUS_CRC_ERROR:                {"STATUS_CRC_ERROR", 23, "A cyclic redundancy check (CRC) checksum error occurred."},
+	STATUS_SECTION_TOO_BIG:          {"STATUS_SECTION_TOO_BIG", 8, "The specified section is too big to map the file."},
+	STATUS_INVALID_PORT_HANDLE:      {"STATUS_INVALID_PORT_HANDLE", 6, "The handle of the port is invalid."},
+	STATUS_SHARING_VIOLATION:        {"STATUS_SHARING_VIOLATION", 32, "A file cannot be opened because the share access flags are incompatible."},
+	STATUS_QUOTA_EXCEEDED:           {"STATUS_QUOTA_EXCEEDED", 1816, "Insufficient quota exists to complete the operation."},
+	STATUS_MUTANT_NOT_OWNED:         {"STATUS_MUTANT_NOT_OWNED", 288, "An attempt to release a mutant object was made by a thread that was not the owner of the mutant object."},
+	STATUS_SEMAPHORE_LIMIT_EXCEEDED: {"STATUS_SEMAPHORE_LIMIT_EXCEEDED", 298, "An attempt was made to release a semaphore such that its maximum count would have been exceeded."},
+	STATUS_THREAD_IS_TERMINATING:    {"STATUS_THREAD_IS_TERMINATING", 5, "An attempt was made to suspend a thread that has begun termination."},
+	STATUS_FILE_LOCK_CONFLICT:       {"STATUS_FILE_LOCK_CONFLICT", 33, "A requested read/write cannot be granted due to a conflicting file lock."},
+	STATUS_LOCK_NOT_GRANTED:         {"STATUS_LOCK_NOT_GRANTED", 33, "A requested file lock cannot be granted due to other existing locks."},
+	STATUS_DELETE_PENDING:           {"STATUS_DELETE_PENDING", 5, "A non-close operation has been requested of a file object that has a delete pending."},
+	STATUS_INVALID_OWNER:            {"STATUS_INVALID_OWNER", 1307, "Indicates a particular security ID cannot be assigned as the owner of an object."},
+	STATUS_PRIVILEGE_NOT_HELD:       {"STATUS_PRIVILEGE_NOT_HELD", 1314, "A required privilege is not held by the client."},
+	STATUS_NO_SUCH_USER:             {"STATUS_NO_SUCH_USER", 1317, "The specified account does not exist."},
+	STATUS_WRONG_PASSWORD:           {"STATUS_WRONG_PASSWORD", 86, "When trying to update a password, this return status indicates that the value provided as the current password is not correct."},
+	STATUS_LOGON_FAILURE:            {"STATUS_LOGON_FAILURE", 1326, "The attempted logon is invalid."},
+	STATUS_ACCOUNT_DISABLED:         {"STATUS_ACCOUNT_DISABLED", 1331, "The referenced account is currently disabled and cannot be logged on to."},
+	STATUS_NONE_MAPPED:              {"STATUS_NONE_MAPPED", 1332, "None of the information to be translated has been translated."},
+	STATUS_INVALID_ACL:              {"STATUS_INVALID_ACL", 1336, "Indicates the ACL structure is not valid."},
+	STATUS_INVALID_SID:              {"STATUS_INVALID_SID", 1337, "Indicates the SID structure is not valid."},
+	STATUS_INVALID_SECURITY_DESCR:   {"STATUS_INVALID_SECURITY_DESCR", 1338, "Indicates the SECURITY_DESCRIPTOR structure is not valid."},
+	STATUS_PROCEDURE_NOT_FOUND:      {"STATUS_PROCEDURE_NOT_FOUND", 127, "Indicates the specified procedure address cannot be found in the DLL."},
+	STATUS_INVALID_IMAGE_FORMAT:     {"STATUS_INVALID_IMAGE_FORMAT", 193, "The image is either not designed to run on Windows or it contains an error."},
+	STATUS_NO_TOKEN:                 {"STATUS_NO_TOKEN", 1008, "An attempt was made to reference a token that does not exist."},
+	STATUS_DISK_FULL:                {"STATUS_DISK_FULL", 112, "An operation failed because the disk was full."},
+	STATUS_ARRAY_BOUNDS_EXCEEDED:    {"STATUS_ARRAY_BOUNDS_EXCEEDED", 317, "Array bounds exceeded."},
+	STATUS_FLOAT_DIVIDE_BY_ZERO:     {"STATUS_FLOAT_DIVIDE_BY_ZERO", 317, "Floating-point division by zero."},
+	STATUS_INTEGER_DIVIDE_BY_ZERO:   {"STATUS_INTEGER_DIVIDE_BY_ZERO", 317, "Integer division by zero."},
+	STATUS_INTEGER_OVERFLOW:         {"STATUS_INTEGER_OVERFLOW", 317, "Integer overflow."},
+	STATUS_PRIVILEGED_INSTRUCTION:   {"STATUS_PRIVILEGED_INSTRUCTION", 317, "Privileged instruction."},
+	STATUS_FILE_INVALID:             {"STATUS_FILE_INVALID", 1006, "The volume for a file has been externally altered such that the opened file is no longer valid."},
+	STATUS_INSUFFICIENT_RESOURCES:   {"STATUS_INSUFFICIENT_RESOURCES", 1450, "Insufficient system resources exist to complete the API."},
+	STATUS_MEMORY_NOT_ALLOCATED:     {"STATUS_MEMORY_NOT_ALLOCATED", 487, "An attempt was made to free virtual memory that is not allocated."},
+	STATUS_WORKING_SET_QUOTA:        {"STATUS_WORKING_SET_QUOTA", 1453, "The working set is not big enough to allow the requested pages to be locked."},
+	STATUS_MEDIA_WRITE_PROTECTED:    {"STATUS_MEDIA_WRITE_PROTECTED", 19, "The disk cannot be written to because it is write-protected."},
+	STATUS_DEVICE_NOT_READY:         {"STATUS_DEVICE_NOT_READY", 21, "The drive is not ready for use."},
+	STATUS_BAD_IMPERSONATION_LEVEL:  {"STATUS_BAD_IMPERSONATION_LEVEL", 1346, "A specified impersonation level is invalid."},
+	STATUS_CANT_OPEN_ANONYMOUS:      {"STATUS_CANT_OPEN_ANONYMOUS", 1347, "An attempt was made to open an anonymous-level token."},
+	STATUS_INSTANCE_NOT_AVAILABLE:   {"STATUS_INSTANCE_NOT_AVAILABLE", 231, "The maximum number of instances of the named pipe has been reached."},
+	STATUS_PIPE_BUSY:                {"STATUS_PIPE_BUSY", 231, "The specified pipe is set to complete operations and there are current I/O operations queued so that it cannot be changed to queue operations."},
+	STATUS_ILLEGAL_FUNCTION:         {"STATUS_ILLEGAL_FUNCTION", 1, "The specified FSCTL is not valid for the target device."},
+	STATUS_PIPE_DISCONNECTED:        {"STATUS_PIPE_DISCONNECTED", 233, "The specified named pipe is in the disconnected state."},
+	STATUS_IO_TIMEOUT:               {"STATUS_IO_TIMEOUT", 121, "The specified I/O operation was not completed before the time-out period expired."},
+	STATUS_FILE_IS_A_DIRECTORY:      {"STATUS_FILE_IS_A_DIRECTORY", 5, "The file that was specified as a target is a directory."},
+	STATUS_NOT_SUPPORTED:            {"STATUS_NOT_SUPPORTED", 50, "The request is not supported."},
+	STATUS_BAD_NETWORK_PATH:         {"STATUS_BAD_NETWORK_PATH", 53, "The network path cannot be located."},
+	STATUS_NETWORK_ACCESS_DENIED:    {"STATUS_NETWORK_ACCESS_DENIED", 65, "Network access is denied."},
+	STATUS_BAD_DEVICE_TYPE:          {"STATUS_BAD_DEVICE_TYPE", 66, "The specified device type is not valid."},
+	STATUS_BAD_NETWORK_NAME:         {"STATUS_BAD_NETWORK_NAME", 67, "The specified share name cannot be found on the remote server."},
+	STATUS_REQUEST_NOT_ACCEPTED:     {"STATUS_REQUEST_NOT_ACCEPTED", 71, "No more connections can be made to this remote computer at this time."},
+	STATUS_NOT_SAME_DEVICE:          {"STATUS_NOT_SAME_DEVICE", 17, "A file cannot be moved to a different device."},
+	STATUS_INTERNAL_ERROR:           {"STATUS_INTERNAL_ERROR", 1359, "An internal error occurred."},
+	STATUS_INVALID_USER_BUFFER:      {"STATUS_INVALID_USER_BUFFER", 1784, "An access to a user buffer failed."},
+	STATUS_INVALID_PARAMETER_1:      {"STATUS_INVALID_PARAMETER_1", 87, "An invalid parameter was passed to a service or function as the first argument."},
+	STATUS_INVALID_PARAMETER_2:      {"STATUS_INVALID_PARAMETER_2", 87, "An invalid parameter was passed to a service or function as the second argument."},
+	STATUS_INVALID_PARAMETER_3:      {"STATUS_INVALID_PARAMETER_3", 87, "An invalid parameter was passed to a service or function as the third argument."},
+	STATUS_STACK_OVERFLOW:           {"STATUS_STACK_OVERFLOW", 1001, "A new guard page for the stack cannot be created."},
+	STATUS_DIRECTORY_NOT_EMPTY:      {"STATUS_DIRECTORY_NOT_EMPTY", 145, "The directory is not empty."},
+	STATUS_NOT_A_DIRECTORY:          {"STATUS_NOT_A_DIRECTORY", 267, "A requested opened file is not a directory."},
+	STATUS_NAME_TOO_LONG:            {"STATUS_NAME_TOO_LONG", 206, "The name is too long."},
+	STATUS_PROCESS_IS_TERMINATING:   {"STATUS_PROCESS_IS_TERMINATING", 5, "An attempt was made to access an exiting process."},
+	STATUS_TOO_MANY_OPENED_FILES:    {"STATUS_TOO_MANY_OPENED_FILES", 4, "Too many files are opened on a remote server."},
+	STATUS_CANCELLED:                {"STATUS_CANCELLED", 995, "The I/O request was canceled."},
+	STATUS_FILE_DELETED:             {"STATUS_FILE_DELETED", 5, "An I/O request other than close was performed on a file after it was deleted."},
+	STATUS_FILE_CLOSED:              {"STATUS_FILE_CLOSED", 6, "An I/O request other than close and several other special case operations was attempted using a file object that had already been closed."},
+	STATUS_COMMITMENT_LIMIT:         {"STATUS_COMMITMENT_LIMIT", 1455, "The paging file is too small for this operation to complete."},
+	STATUS_DLL_NOT_FOUND:            {"STATUS_DLL_NOT_FOUND", 126, "The code execution cannot proceed because the DLL was not found."},
+	STATUS_ORDINAL_NOT_FOUND:        {"STATUS_ORDINAL_NOT_FOUND", 182, "The ordinal could not be located in the DLL."},
+	STATUS_ENTRYPOINT_NOT_FOUND:     {"STATUS_ENTRYPOINT_NOT_FOUND", 127, "The procedure entry point could not be located in the DLL."},
+	STATUS_CONTROL_C_EXIT:           {"STATUS_CONTROL_C_EXIT", 317, "The application terminated as a result of a CTRL+C."},
+	STATUS_DLL_INIT_FAILED:          {"STATUS_DLL_INIT_FAILED", 1114, "The initialization routine of the DLL failed."},
+	STATUS_INVALID_LEVEL:            {"STATUS_INVALID_LEVEL", 124, "An invalid level was passed into the specified system call."},
+	STATUS_PIPE_BROKEN:              {"STATUS_PIPE_BROKEN", 109, "The pipe operation has failed because the other end of the pipe has been closed."},
+	STATUS_INVALID_DEVICE_STATE:     {"STATUS_INVALID_DEVICE_STATE", 22, "The device is not in a valid state to perform this request."},
+	STATUS_CONNECTION_RESET:         {"STATUS_CONNECTION_RESET", 64, "The transport connection was reset."},
+	STATUS_NOT_FOUND:                {"STATUS_NOT_FOUND", 1168, "The object was not found."},
+	STATUS_HANDLE_NOT_CLOSABLE:      {"STATUS_HANDLE_NOT_CLOSABLE", 6, "NtClose was called on a handle that was protected from close."},
+	STATUS_CONNECTION_REFUSED:       {"STATUS_CONNECTION_REFUSED", 1225, "The transport connection attempt was refused by the remote system."},
+	STATUS_NETWORK_UNREACHABLE:      {"STATUS_NETWORK_UNREACHABLE", 1231, "The remote network is not reachable by the transport."},
+	STATUS_HOST_UNREACHABLE:         {"STATUS_HOST_UNREACHABLE", 1232, "The remote system is not reachable by the transport."},
+	STATUS_VOLUME_DISMOUNTED:        {"STATUS_VOLUME_DISMOUNTED", 21, "An operation was attempted to a volume after it was dismounted."},
+	STATUS_NOINTERFACE:              {"STATUS_NOINTERFACE", 632, "The requested interface is not supported."},
+	STATUS_HEAP_CORRUPTION:          {"STATUS_HEAP_CORRUPTION", 317, "A heap has been corrupted."},
+	STATUS_STACK_BUFFER_OVERRUN:     {"STATUS_STACK_BUFFER_OVERRUN", 317, "The system detected an overrun of a stack-based buffer in this application."},
+	STATUS_ASSERTION_FAILURE:        {"STATUS_ASSERTION_FAILURE", 317, "An assertion failure has occurred."},
+	STATUS_INVALID_IMAGE_HASH:       {"STATUS_INVALID_IMAGE_HASH", 577, "The hash for the image cannot be found in the system catalogs."},
+	STATUS_PROCESS_IS_PROTECTED:     {"STATUS_PROCESS_IS_PROTECTED", 317, "An attempt has been made to open a protected process."},
+}
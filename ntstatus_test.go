@@ -0,0 +1,145 @@
+package gowindows
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"syscall"
+	"testing"
+)
+
+func TestNTStatusDecode(t *testing.T) {
+	data := []struct {
+		status   NTStatus
+		severity uint32
+		facility uint32
+		code     uint32
+		success  bool
+		isError  bool
+	}{
+		{STATUS_SUCCESS, STATUS_SEVERITY_SUCCESS, 0, 0, true, false},
+		{STATUS_PENDING, STATUS_SEVERITY_SUCCESS, 0, 0x103, true, false},
+		{STATUS_OBJECT_NAME_EXISTS, STATUS_SEVERITY_INFORMATIONAL, 0, 0, true, false},
+		{STATUS_PARTIAL_COPY, STATUS_SEVERITY_WARNING, 0, 0xD, false, false},
+		{STATUS_ACCESS_DENIED, STATUS_SEVERITY_ERROR, 0, 0x22, false, true},
+		{0xC0070005, STATUS_SEVERITY_ERROR, FACILITY_NTWIN32, 5, false, true},
+		{0xC0220001, STATUS_SEVERITY_ERROR, FACILITY_FWP_ERROR_CODE, 1, false, true},
+	}
+	for _, v := range data {
+		if v.status.Severity() != v.severity || v.status.Facility() != v.facility || v.status.Code() != v.code {
+			t.Errorf("0x%08X: %v,0x%X,0x%X!=%v,0x%X,0x%X", uint32(v.status), v.status.Severity(), v.status.Facility(),
+				v.status.Code(), v.severity, v.facility, v.code)
+		}
+		if v.status.Success() != v.success || v.status.IsError() != v.isError {
+			t.Errorf("0x%08X: %v,%v!=%v,%v", uint32(v.status), v.status.Success(), v.status.IsError(), v.success, v.isError)
+		}
+	}
+	if NTStatus(0xE0000001).Customer() == false || STATUS_ACCESS_DENIED.Customer() {
+		t.Errorf("Customer")
+	}
+}
+
+func TestNTStatusString(t *testing.T) {
+	if s := STATUS_INFO_LENGTH_MISMATCH.String(); s != "STATUS_INFO_LENGTH_MISMATCH" {
+		t.Errorf("%v!=STATUS_INFO_LENGTH_MISMATCH", s)
+	}
+	if s := STATUS_PARTIAL_COPY.String(); s != "STATUS_PARTIAL_COPY" {
+		t.Errorf("%v!=STATUS_PARTIAL_COPY", s)
+	}
+	if s := NTStatus(0xC0001234).String(); s != "NTSTATUS(0xC0001234)" {
+		t.Errorf("%v!=NTSTATUS(0xC0001234)", s)
+	}
+
+	var err error = STATUS_ACCESS_DENIED
+	if s := err.Error(); strings.HasPrefix(s, "STATUS_ACCESS_DENIED (0xC0000022): ") == false || strings.HasSuffix(s, STATUS_ACCESS_DENIED.Message()) == false {
+		t.Errorf("%v", s)
+	}
+	if s := NTStatus(0xC0221234).Error(); s != "NTSTATUS 0xC0221234 (facility 0x22, code 0x1234)" {
+		t.Errorf("%v", s)
+	}
+	if NTStatus(0xC0001234).Message() != "" {
+		t.Errorf("Message")
+	}
+}
+
+func TestNTStatusTable(t *testing.T) {
+	for status, info := range ntStatusTable {
+		if strings.HasPrefix(info.name, "STATUS_") == false || info.message == "" {
+			t.Errorf("0x%08X: %v %v", uint32(status), info.name, info.message)
+		}
+		// The statuses of the other facilities are decoded by Facility and Code.
+		if status.Facility() != 0 || status.Customer() {
+			t.Errorf("%v: the facility is 0x%X", info.name, status.Facility())
+		}
+	}
+	if len(ntStatusTable) < 100 {
+		t.Errorf("%v", len(ntStatusTable))
+	}
+}
+
+func TestNTStatusWin32Error(t *testing.T) {
+	data := []struct {
+		status NTStatus
+		want   syscall.Errno
+	}{
+		{STATUS_SUCCESS, ERROR_SUCCESS},
+		{STATUS_ACCESS_DENIED, 5},
+		{STATUS_INFO_LENGTH_MISMATCH, 24},
+		{STATUS_PARTIAL_COPY, 299},
+		{STATUS_BUFFER_TOO_SMALL, 122},
+		{STATUS_OBJECT_NAME_NOT_FOUND, 2},
+		{STATUS_PENDING, ERROR_IO_PENDING},
+		{STATUS_BREAKPOINT, ERROR_MR_MID_NOT_FOUND},
+		{0xC0070057, ERROR_INVALID_PARAMETER},
+		{0xE0070057, ERROR_MR_MID_NOT_FOUND},
+		{0xC0001234, ERROR_MR_MID_NOT_FOUND},
+	}
+	for _, v := range data {
+		if e := v.status.Win32Error(); e != v.want {
+			t.Errorf("%v: %d!=%d", v.status, e, v.want)
+		}
+	}
+
+	if s := NTStatusFromWin32(ERROR_INVALID_PARAMETER); s != 0xC0070057 {
+		t.Errorf("0x%08X!=0xC0070057", uint32(s))
+	}
+	if s := NTStatusFromWin32(ERROR_SUCCESS); s != STATUS_SUCCESS {
+		t.Errorf("%v!=STATUS_SUCCESS", s)
+	}
+	if e := NTStatusFromWin32(ERROR_INVALID_PARAMETER).Win32Error(); e != ERROR_INVALID_PARAMETER {
+		t.Errorf("%d!=%d", e, ERROR_INVALID_PARAMETER)
+	}
+}
+
+func TestNTStatusIs(t *testing.T) {
+	err := fmt.Errorf("NtQueryInformationProcess(0), %w", STATUS_ACCESS_DENIED)
+	data := []struct {
+		err    error
+		target error
+		want   bool
+	}{
+		{err, STATUS_ACCESS_DENIED, true},
+		{err, STATUS_INFO_LENGTH_MISMATCH, false},
+		{err, syscall.Errno(5), true},
+		{err, syscall.Errno(6), false},
+		{err, os.ErrPermission, true},
+		{err, os.ErrNotExist, false},
+		{STATUS_OBJECT_NAME_NOT_FOUND, os.ErrNotExist, true},
+		{STATUS_OBJECT_PATH_NOT_FOUND, os.ErrNotExist, true},
+		{STATUS_OBJECT_NAME_COLLISION, os.ErrExist, true},
+		{STATUS_BREAKPOINT, ERROR_MR_MID_NOT_FOUND, false},
+		{STATUS_SUCCESS, ERROR_SUCCESS, false},
+		{STATUS_PARTIAL_COPY, syscall.Errno(299), true},
+	}
+	for i, v := range data {
+		if r := errors.Is(v.err, v.target); r != v.want {
+			t.Errorf("%v: errors.Is(%v, %v) %v!=%v", i, v.err, v.target, r, v.want)
+		}
+	}
+
+	var status NTStatus
+	if errors.As(err, &status) == false || status != STATUS_ACCESS_DENIED {
+		t.Errorf("%v!=STATUS_ACCESS_DENIED", status)
+	}
+}
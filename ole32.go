@@ -10,20 +10,6 @@ import (
 	"encoding/binary"
 )
 
-/*
-// CLSID == GUID
-// The requirements of windows must be registered by the system, otherwise it will report an error and cannot be converted
-func GUIDFromString(s string) (guid GUID, err error) {
-	var a *uint16
-	a, err = windows.UTF16PtrFromString(s)
-	if err != nil {
-		return
-	}
-	r1, _, e1 := cLSIDFromString.Call(uintptr(unsafe.Pointer(a)), uintptr(unsafe.Pointer(&guid)))
-
-}
-*/
-
 func GUIDFormString(s string) (guid GUID, err error) {
 	s = strings.Trim(s, "{}")
 	ss := strings.Split(s, "-")
@@ -62,3 +48,10 @@ func GUIDFormString(s string) (guid GUID, err error) {
 	binary.BigEndian.PutUint64(guid.Data4[:], i)
 	return
 }
+
+// Format as xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx, without braces
+func GUIDToString(guid GUID) string {
+	return fmt.Sprintf("%08x-%04x-%04x-%04x-%012x",
+		guid.Data1, guid.Data2, guid.Data3,
+		binary.BigEndian.Uint16(guid.Data4[:2]), guid.Data4[2:])
+}
@@ -1,3 +1,5 @@
+// +build windows
+
 package gowindows
 
 import (
@@ -35,3 +37,20 @@ func TestGUIDFormString2(t *testing.T) {
 		t.Fatal(s)
 	}
 }
+
+func TestGUIDToString(t *testing.T) {
+	for _, s := range []string{
+		"3f2504e0-4f89-11d3-9a0c-0305e82c3301",
+		"ffffffff-ffff-ffff-ffff-ffffffffffff",
+		"00000000-0000-0000-0000-000000000000",
+	} {
+		guid, err := GUIDFormString("{" + s + "}")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if v := GUIDToString(guid); v != s {
+			t.Errorf("%v!=%v", v, s)
+		}
+	}
+}
@@ -3,43 +3,43 @@ package gowindows
 import (
 	"unsafe"
 
-	"syscall"
-
 	"golang.org/x/sys/windows"
 )
 
 var (
 	ole32           = windows.NewLazyDLL("ole32.dll")
 	stringFromGUID2 = ole32.NewProc("StringFromGUID2")
-	//cLSIDFromString = ole32.NewProc("CLSIDFromString")
+	cLSIDFromString = ole32.NewProc("CLSIDFromString")
 )
 
+// Format as {XXXXXXXX-XXXX-XXXX-XXXX-XXXXXXXXXXXX}
+// https://docs.microsoft.com/en-us/windows/win32/api/combaseapi/nf-combaseapi-stringfromguid2
 func StringFromGUID2(guid *GUID) (string, error) {
 	size := 40
 	b := make([]uint16, size)
 
-	r1, _, e1 := stringFromGUID2.Call(uintptr(unsafe.Pointer(guid)), uintptr(unsafe.Pointer(&b[0])), uintptr(size))
+	// The count of the characters including the terminating null, 0 if the buffer is too small.
+	r1, _, _ := stringFromGUID2.Call(uintptr(unsafe.Pointer(guid)), uintptr(unsafe.Pointer(&b[0])), uintptr(size))
 	if r1 == 0 {
-		if e1 != ERROR_SUCCESS {
-			return "", e1
-		} else {
-			return "", syscall.EINVAL
-		}
+		return "", newCallError(ole32.Name, stringFromGUID2.Name, r1, ERROR_SUCCESS, E_NOT_SUFFICIENT_BUFFER)
 	}
 
 	return windows.UTF16ToString(b[:r1]), nil
 }
 
-/*
 // CLSID == GUID
-// The requirements of windows must be registered by the system, otherwise it will report an error and cannot be converted
-func GUIDFromString(s string) (guid GUID, err error) {
-	var a *uint16
-	a, err = windows.UTF16PtrFromString(s)
+// The string is {XXXXXXXX-XXXX-XXXX-XXXX-XXXXXXXXXXXX} or the ProgID, the ProgID must be registered by the system.
+// The error is the HRESULT, CO_E_CLASSSTRING if the string is invalid, REGDB_E_CLASSNOTREG if the ProgID is not registered.
+// https://docs.microsoft.com/en-us/windows/win32/api/combaseapi/nf-combaseapi-clsidfromstring
+func GUIDFromString(s string) (GUID, error) {
+	a, err := windows.UTF16PtrFromString(s)
 	if err != nil {
-		return
+		return GUID{}, err
 	}
-	r1, _, e1 := cLSIDFromString.Call(uintptr(unsafe.Pointer(a)), uintptr(unsafe.Pointer(&guid)))
-
+	guid := GUID{}
+	r1, _, _ := cLSIDFromString.Call(uintptr(unsafe.Pointer(a)), uintptr(unsafe.Pointer(&guid)))
+	if hr := HRESULT(r1); hr.IsFailed() {
+		return GUID{}, newHRESULTCallError(ole32.Name, cLSIDFromString.Name, r1)
+	}
+	return guid, nil
 }
-*/
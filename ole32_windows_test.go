@@ -1,6 +1,7 @@
 package gowindows
 
 import (
+	"errors"
 	"testing"
 )
 
@@ -30,3 +31,18 @@ func TestStringFromGUID2(t *testing.T) {
 		t.Fatalf("%v!={0000007B-01C8-0315-0102-030405060708}", s)
 	}
 }
+
+func TestGUIDFromString(t *testing.T) {
+	guid, err := GUIDFromString("{3F2504E0-4F89-11D3-9A0C-0305E82C3301}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := GUIDToString(guid); s != "3f2504e0-4f89-11d3-9a0c-0305e82c3301" {
+		t.Errorf("%v!=3f2504e0-4f89-11d3-9a0c-0305e82c3301", s)
+	}
+
+	_, err = GUIDFromString("{3F2504E0-4F89-11D3-9A0C}")
+	if errors.Is(err, CO_E_CLASSSTRING) == false {
+		t.Errorf("%v!=CO_E_CLASSSTRING", err)
+	}
+}
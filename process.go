@@ -116,3 +116,274 @@ type UNICODE_STRING64 struct {
 	// Pointer to a 16-bit Unicode string ending in null. For more information, see [Character Set Used by Fonts] (https://msdn.microsoft.com/library/windows/desktop/dd183415).
 	Buffer uint64
 }
+
+// The PEB of the 32-bit process, it is the same as PEB under 32-bit.
+// It is read by RemoteMemory, the layout is the same as C without the alignment.
+type PEB32 struct {
+	Reserved1              [2]byte
+	BeingDebugged          byte
+	Reserved2              [1]byte
+	Reserved3              [2]uint32
+	Ldr                    uint32
+	ProcessParameters      uint32
+	Reserved4              [3]uint32
+	AtlThunkSListPtr       uint32
+	Reserved5              uint32
+	Reserved6              uint32
+	Reserved7              uint32
+	Reserved8              uint32
+	AtlThunkSListPtr32     uint32
+	Reserved9              [45]uint32
+	Reserved10             [96]byte
+	PostProcessInitRoutine uint32
+	Reserved11             [128]byte
+	Reserved12             [1]uint32
+	SessionId              uint32
+}
+
+type RTL_USER_PROCESS_PARAMETERS32 struct {
+	Reserved1     [16]byte
+	Reserved2     [10]uint32
+	ImagePathName UNICODE_STRING32
+	CommandLine   UNICODE_STRING32
+}
+
+type UNICODE_STRING32 struct {
+	Length        uint16 // The byte length of the buffer, excluding the terminator "NULL"
+	MaximumLength uint16
+	Buffer        uint32
+}
+
+// The full RTL_USER_PROCESS_PARAMETERS, including the undocumented fields.
+// The fields after LoaderThreads are added by windows 10, they are not read.
+// Length is the size of the structure in the process, the old windows has less fields.
+// The layouts are the same as C on any architecture, so they are read by RemoteMemory directly.
+// http://terminus.rewolf.pl/terminus/structures/ntdll/_RTL_USER_PROCESS_PARAMETERS_combined.html
+// typedef struct _RTL_USER_PROCESS_PARAMETERS {
+// ULONG                   MaximumLength;
+// ULONG                   Length;
+// ULONG                   Flags;
+// ULONG                   DebugFlags;
+// HANDLE                  ConsoleHandle;
+// ULONG                   ConsoleFlags;
+// HANDLE                  StandardInput;
+// HANDLE                  StandardOutput;
+// HANDLE                  StandardError;
+// CURDIR                  CurrentDirectory;
+// UNICODE_STRING          DllPath;
+// UNICODE_STRING          ImagePathName;
+// UNICODE_STRING          CommandLine;
+// PVOID                   Environment;
+// ULONG                   StartingX;
+// ULONG                   StartingY;
+// ULONG                   CountX;
+// ULONG                   CountY;
+// ULONG                   CountCharsX;
+// ULONG                   CountCharsY;
+// ULONG                   FillAttribute;
+// ULONG                   WindowFlags;
+// ULONG                   ShowWindowFlags;
+// UNICODE_STRING          WindowTitle;
+// UNICODE_STRING          DesktopInfo;
+// UNICODE_STRING          ShellInfo;
+// UNICODE_STRING          RuntimeData;
+// RTL_DRIVE_LETTER_CURDIR CurrentDirectories[32];
+// ULONG_PTR               EnvironmentSize;
+// ULONG_PTR               EnvironmentVersion;
+// PVOID                   PackageDependencyData;
+// ULONG                   ProcessGroupId;
+// ULONG                   LoaderThreads;
+// ...
+// } RTL_USER_PROCESS_PARAMETERS, *PRTL_USER_PROCESS_PARAMETERS;
+type RTL_USER_PROCESS_PARAMETERS_FULL32 struct {
+	MaximumLength         uint32
+	Length                uint32
+	Flags                 uint32
+	DebugFlags            uint32
+	ConsoleHandle         uint32
+	ConsoleFlags          uint32
+	StandardInput         uint32
+	StandardOutput        uint32
+	StandardError         uint32
+	CurrentDirectory      CURDIR32
+	DllPath               UNICODE_STRING32
+	ImagePathName         UNICODE_STRING32
+	CommandLine           UNICODE_STRING32
+	Environment           uint32
+	StartingX             uint32
+	StartingY             uint32
+	CountX                uint32
+	CountY                uint32
+	CountCharsX           uint32
+	CountCharsY           uint32
+	FillAttribute         uint32
+	WindowFlags           uint32
+	ShowWindowFlags       uint32
+	WindowTitle           UNICODE_STRING32
+	DesktopInfo           UNICODE_STRING32
+	ShellInfo             UNICODE_STRING32
+	RuntimeData           UNICODE_STRING32
+	CurrentDirectories    [32]RTL_DRIVE_LETTER_CURDIR32
+	EnvironmentSize       uint32 // The byte size of Environment, since windows vista
+	EnvironmentVersion    uint32
+	PackageDependencyData uint32
+	ProcessGroupId        uint32
+	LoaderThreads         uint32
+}
+type RTL_USER_PROCESS_PARAMETERS_FULL64 struct {
+	MaximumLength         uint32
+	Length                uint32
+	Flags                 uint32
+	DebugFlags            uint32
+	ConsoleHandle         uint64
+	ConsoleFlags          uint32
+	_                     uint32
+	StandardInput         uint64
+	StandardOutput        uint64
+	StandardError         uint64
+	CurrentDirectory      CURDIR64
+	DllPath               UNICODE_STRING64
+	ImagePathName         UNICODE_STRING64
+	CommandLine           UNICODE_STRING64
+	Environment           uint64
+	StartingX             uint32
+	StartingY             uint32
+	CountX                uint32
+	CountY                uint32
+	CountCharsX           uint32
+	CountCharsY           uint32
+	FillAttribute         uint32
+	WindowFlags           uint32
+	ShowWindowFlags       uint32
+	_                     uint32
+	WindowTitle           UNICODE_STRING64
+	DesktopInfo           UNICODE_STRING64
+	ShellInfo             UNICODE_STRING64
+	RuntimeData           UNICODE_STRING64
+	CurrentDirectories    [32]RTL_DRIVE_LETTER_CURDIR64
+	EnvironmentSize       uint64 // The byte size of Environment, since windows vista
+	EnvironmentVersion    uint64
+	PackageDependencyData uint64
+	ProcessGroupId        uint32
+	LoaderThreads         uint32
+}
+
+// typedef struct _CURDIR {
+// UNICODE_STRING DosPath;
+// HANDLE         Handle;
+// } CURDIR, *PCURDIR;
+type CURDIR32 struct {
+	DosPath UNICODE_STRING32
+	Handle  uint32
+}
+type CURDIR64 struct {
+	DosPath UNICODE_STRING64
+	Handle  uint64
+}
+
+// typedef struct _RTL_DRIVE_LETTER_CURDIR {
+// USHORT Flags;
+// USHORT Length;
+// ULONG  TimeStamp;
+// STRING DosPath;
+// } RTL_DRIVE_LETTER_CURDIR, *PRTL_DRIVE_LETTER_CURDIR;
+type RTL_DRIVE_LETTER_CURDIR32 struct {
+	Flags     uint16
+	Length    uint16
+	TimeStamp uint32
+	DosPath   UNICODE_STRING32
+}
+type RTL_DRIVE_LETTER_CURDIR64 struct {
+	Flags     uint16
+	Length    uint16
+	TimeStamp uint32
+	DosPath   UNICODE_STRING64
+}
+
+// The loader data of PEB.Ldr, the fields after the lists are not read.
+// http://terminus.rewolf.pl/terminus/structures/ntdll/_PEB_LDR_DATA_combined.html
+// typedef struct _PEB_LDR_DATA {
+// ULONG      Length;
+// BOOLEAN    Initialized;
+// HANDLE     SsHandle;
+// LIST_ENTRY InLoadOrderModuleList;
+// LIST_ENTRY InMemoryOrderModuleList;
+// LIST_ENTRY InInitializationOrderModuleList;
+// ...
+// } PEB_LDR_DATA, *PPEB_LDR_DATA;
+type PEB_LDR_DATA32 struct {
+	Length                          uint32
+	Initialized                     byte
+	_                               [3]byte
+	SsHandle                        uint32
+	InLoadOrderModuleList           LIST_ENTRY32
+	InMemoryOrderModuleList         LIST_ENTRY32
+	InInitializationOrderModuleList LIST_ENTRY32
+}
+type PEB_LDR_DATA64 struct {
+	Length                          uint32
+	Initialized                     byte
+	_                               [3]byte
+	SsHandle                        uint64
+	InLoadOrderModuleList           LIST_ENTRY64
+	InMemoryOrderModuleList         LIST_ENTRY64
+	InInitializationOrderModuleList LIST_ENTRY64
+}
+
+// The module in the lists of PEB_LDR_DATA, the fields after TlsIndex are not read.
+// The links point to the same links of the next entry, InLoadOrderLinks is at the start of the entry.
+// http://terminus.rewolf.pl/terminus/structures/ntdll/_LDR_DATA_TABLE_ENTRY_combined.html
+// typedef struct _LDR_DATA_TABLE_ENTRY {
+// LIST_ENTRY     InLoadOrderLinks;
+// LIST_ENTRY     InMemoryOrderLinks;
+// LIST_ENTRY     InInitializationOrderLinks;
+// PVOID          DllBase;
+// PVOID          EntryPoint;
+// ULONG          SizeOfImage;
+// UNICODE_STRING FullDllName;
+// UNICODE_STRING BaseDllName;
+// ULONG          Flags;
+// USHORT         ObsoleteLoadCount;
+// USHORT         TlsIndex;
+// ...
+// } LDR_DATA_TABLE_ENTRY, *PLDR_DATA_TABLE_ENTRY;
+type LDR_DATA_TABLE_ENTRY32 struct {
+	InLoadOrderLinks           LIST_ENTRY32
+	InMemoryOrderLinks         LIST_ENTRY32
+	InInitializationOrderLinks LIST_ENTRY32
+	DllBase                    uint32
+	EntryPoint                 uint32
+	SizeOfImage                uint32
+	FullDllName                UNICODE_STRING32
+	BaseDllName                UNICODE_STRING32
+	Flags                      uint32
+	ObsoleteLoadCount          uint16
+	TlsIndex                   uint16
+}
+type LDR_DATA_TABLE_ENTRY64 struct {
+	InLoadOrderLinks           LIST_ENTRY64
+	InMemoryOrderLinks         LIST_ENTRY64
+	InInitializationOrderLinks LIST_ENTRY64
+	DllBase                    uint64
+	EntryPoint                 uint64
+	SizeOfImage                uint32
+	_                          uint32
+	FullDllName                UNICODE_STRING64
+	BaseDllName                UNICODE_STRING64
+	Flags                      uint32
+	ObsoleteLoadCount          uint16
+	TlsIndex                   uint16
+}
+
+// typedef struct _LIST_ENTRY {
+// struct _LIST_ENTRY *Flink;
+// struct _LIST_ENTRY *Blink;
+// } LIST_ENTRY, *PLIST_ENTRY;
+type LIST_ENTRY32 struct {
+	Flink uint32
+	Blink uint32
+}
+type LIST_ENTRY64 struct {
+	Flink uint64
+	Blink uint64
+}
@@ -0,0 +1,11 @@
+package gowindows
+
+import (
+	"golang.org/x/sys/unix"
+)
+
+// Whether the process is running, the process of another user is treated as running.
+func isProcessAlive(pid uint32) bool {
+	err := unix.Kill(int(pid), 0)
+	return err == nil || err == unix.EPERM
+}
@@ -1,8 +1,10 @@
 package gowindows
 
 import (
+	"io/ioutil"
 	"os"
 	"os/exec"
+	"strings"
 	"testing"
 
 	"golang.org/x/sys/windows"
@@ -90,6 +92,45 @@ func TestGetProcessParametersWid(t *testing.T) {
 	}
 }
 
+func TestGetProcessInfo(t *testing.T) {
+	if os.Getenv("GOWINDOWS_PROCESS_HELPER") != "" {
+		t.Skip("helper process")
+	}
+	dir, err := ioutil.TempDir("", "gowindows")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	spec := NewProcessSpec(os.Args[0], "-test.run=^TestProcessSpecHelperProcess$", "-test.count=1").
+		Setenv("GOWINDOWS_PROCESS_HELPER", "sleep").
+		Setenv("GOWINDOWS_PROCESS_VALUE", "测试=1").
+		Dir(dir)
+	p, err := spec.Start()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer p.Close()
+	defer p.Kill()
+
+	info, err := GetProcessInfoWPid(p.Pid())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.EqualFold(strings.TrimSuffix(info.CurrentDirectory, `\`), dir) == false {
+		t.Errorf("%v!=%v", info.CurrentDirectory, dir)
+	}
+	if v, ok := info.Getenv("gowindows_process_value"); ok == false || v != "测试=1" {
+		t.Errorf("%v %v", v, ok)
+	}
+	if v, ok := info.Environment["GOWINDOWS_PROCESS_HELPER"]; ok == false || v != "sleep" {
+		t.Errorf("%v %v", v, ok)
+	}
+	if info.CommandLine != spec.CommandLine() {
+		t.Errorf("%v!=%v", info.CommandLine, spec.CommandLine())
+	}
+}
+
 func TestMyCreateProcess(t *testing.T) {
 
 	info, err := MyCreateProcess(`C:\WINDOWS\system32\notepad.exe`, false, `d:\\aaa.txt`)
@@ -133,3 +174,46 @@ func TestMyCreateProcess2(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestGetProcessModules(t *testing.T) {
+	modules, err := GetProcessModules(Handle(windows.CurrentProcess()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(modules) < 2 {
+		t.Fatalf("%v", modules)
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var base windows.Handle
+	if err := windows.GetModuleHandleEx(0, nil, &base); err != nil {
+		t.Fatal(err)
+	}
+	if strings.EqualFold(modules[0].FullDllName, exe) == false || modules[0].BaseAddress != uint64(base) || modules[0].SizeOfImage == 0 {
+		t.Errorf("%#v", modules[0])
+	}
+	if strings.EqualFold(modules[1].BaseDllName, "ntdll.dll") == false {
+		t.Errorf("%#v", modules[1])
+	}
+
+	is64system, err := Is64System()
+	if err != nil {
+		t.Fatal(err)
+	}
+	wow64Modules, err := GetWow64ProcessModules(Handle(windows.CurrentProcess()))
+	if ptrSize == 8 || is64system == false {
+		if err != ErrNotWow64Process {
+			t.Errorf("%v!=%v", err, ErrNotWow64Process)
+		}
+		return
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	if wow64Modules[0].BaseAddress != modules[0].BaseAddress {
+		t.Errorf("%#v!=%#v", wow64Modules[0], modules[0])
+	}
+}
@@ -0,0 +1,296 @@
+package gowindows
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"unicode/utf16"
+)
+
+// https://docs.microsoft.com/en-us/windows/win32/procthread/process-creation-flags
+const (
+	DEBUG_PROCESS                    = 0x00000001
+	DEBUG_ONLY_THIS_PROCESS          = 0x00000002
+	CREATE_SUSPENDED                 = 0x00000004
+	DETACHED_PROCESS                 = 0x00000008
+	CREATE_NEW_CONSOLE               = 0x00000010
+	CREATE_NEW_PROCESS_GROUP         = 0x00000200
+	CREATE_UNICODE_ENVIRONMENT       = 0x00000400
+	CREATE_PROTECTED_PROCESS         = 0x00040000
+	EXTENDED_STARTUPINFO_PRESENT     = 0x00080000
+	INHERIT_PARENT_AFFINITY          = 0x00010000
+	CREATE_BREAKAWAY_FROM_JOB        = 0x01000000
+	CREATE_PRESERVE_CODE_AUTHZ_LEVEL = 0x02000000
+	CREATE_DEFAULT_ERROR_MODE        = 0x04000000
+	CREATE_NO_WINDOW                 = 0x08000000
+
+	NORMAL_PRIORITY_CLASS       = 0x00000020
+	IDLE_PRIORITY_CLASS         = 0x00000040
+	HIGH_PRIORITY_CLASS         = 0x00000080
+	REALTIME_PRIORITY_CLASS     = 0x00000100
+	BELOW_NORMAL_PRIORITY_CLASS = 0x00004000
+	ABOVE_NORMAL_PRIORITY_CLASS = 0x00008000
+)
+
+// https://docs.microsoft.com/en-us/windows/win32/api/processthreadsapi/nf-processthreadsapi-updateprocthreadattribute
+const (
+	PROC_THREAD_ATTRIBUTE_PARENT_PROCESS = 0x00020000
+	PROC_THREAD_ATTRIBUTE_HANDLE_LIST    = 0x00020002
+)
+
+// The exit code of GetExitCodeProcess while the process is running
+const STILL_ACTIVE = 259
+
+// The process is not exited
+var ErrProcessRunning = errors.New("the process is running")
+
+// Builder of the new process, Start creates it.
+// The zero values are the same as CreateProcess: the environment and the current directory of the current process,
+// no handles are inherited, the process is not in a job.
+//
+//	p, err := NewProcessSpec(`C:\Windows\System32\cmd.exe`, "/c", "echo", "hi").
+//		Setenv("LANG", "C").
+//		Dir(`C:\`).
+//		Stdout(h).
+//		Flags(CREATE_NO_WINDOW).
+//		Start()
+type ProcessSpec struct {
+	path    string
+	args    []string
+	cmdLine string
+
+	env    []string
+	hasEnv bool
+	dir    string
+
+	stdin, stdout, stderr Handle
+	inherit               []Handle
+
+	flags      uint32
+	hideWindow bool
+	parent     Handle
+	job        Handle
+	token      Handle
+}
+
+// The path of the program, and the arguments except the program name
+// The command line is built by EscapeArg, the program name is path.
+func NewProcessSpec(path string, args ...string) *ProcessSpec {
+	return &ProcessSpec{path: path, args: args}
+}
+
+// Use the command line as is instead of the escaped arguments, it includes the program name.
+func (s *ProcessSpec) CmdLine(cmdLine string) *ProcessSpec {
+	s.cmdLine = cmdLine
+	return s
+}
+
+// Replace the environment by env, the items are "key=value".
+// The nil env is the environment of the current process, the empty env is no variables.
+func (s *ProcessSpec) Env(env []string) *ProcessSpec {
+	s.env = append([]string(nil), env...)
+	s.hasEnv = env != nil
+	return s
+}
+
+// Set the variable, the environment of the current process is copied if Env is not called.
+func (s *ProcessSpec) Setenv(key, value string) *ProcessSpec {
+	if s.hasEnv == false {
+		s.env = os.Environ()
+		s.hasEnv = true
+	}
+	s.env = append(s.env, key+"="+value)
+	return s
+}
+
+func (s *ProcessSpec) Dir(dir string) *ProcessSpec {
+	s.dir = dir
+	return s
+}
+
+// The standard handles, they are inherited by the process.
+// The standard handles which are not set are none if any of them is set.
+func (s *ProcessSpec) Stdin(h Handle) *ProcessSpec {
+	s.stdin = h
+	return s
+}
+
+func (s *ProcessSpec) Stdout(h Handle) *ProcessSpec {
+	s.stdout = h
+	return s
+}
+
+func (s *ProcessSpec) Stderr(h Handle) *ProcessSpec {
+	s.stderr = h
+	return s
+}
+
+// Inherit the handles, only the standard handles and these handles are inherited (PROC_THREAD_ATTRIBUTE_HANDLE_LIST).
+// The handles must be inheritable.
+func (s *ProcessSpec) Inherit(handles ...Handle) *ProcessSpec {
+	s.inherit = append(s.inherit, handles...)
+	return s
+}
+
+// Add the creation flags, such as CREATE_NO_WINDOW, CREATE_NEW_PROCESS_GROUP and the priority class.
+// CREATE_UNICODE_ENVIRONMENT and EXTENDED_STARTUPINFO_PRESENT are always set.
+func (s *ProcessSpec) Flags(flags uint32) *ProcessSpec {
+	s.flags |= flags
+	return s
+}
+
+// Hide the window of the process, the same as SW_HIDE.
+func (s *ProcessSpec) HideWindow() *ProcessSpec {
+	s.hideWindow = true
+	return s
+}
+
+// Create the process as the child of another process (PROC_THREAD_ATTRIBUTE_PARENT_PROCESS)
+// The handle needs PROCESS_CREATE_PROCESS, and PROCESS_DUP_HANDLE if any handles are inherited,
+// the inherited handles must be the handles of the parent process, see DuplicateHandle.
+func (s *ProcessSpec) Parent(process Handle) *ProcessSpec {
+	s.parent = process
+	return s
+}
+
+// Assign the process to the job before it runs, see JobObject.Handle
+// The process is created suspended and resumed after it is assigned.
+func (s *ProcessSpec) Job(job Handle) *ProcessSpec {
+	s.job = job
+	return s
+}
+
+// Create the process as the user of the primary token (CreateProcessAsUser)
+// The environment of the current process is used if Env is not called, see CreateEnvironmentBlock.
+func (s *ProcessSpec) Token(token Handle) *ProcessSpec {
+	s.token = token
+	return s
+}
+
+// The command line passed to CreateProcess
+func (s *ProcessSpec) CommandLine() string {
+	if s.cmdLine != "" {
+		return s.cmdLine
+	}
+	return makeCmdLine(append([]string{s.path}, s.args...))
+}
+
+// The attribute of UpdateProcThreadAttribute, the value is the handle or the handle list.
+type procThreadAttribute struct {
+	attribute uintptr
+	handles   []Handle
+}
+
+// The handles inherited by the process, the standard handles first, without the duplicates.
+func (s *ProcessSpec) inheritedHandles() ([]Handle, error) {
+	for _, h := range s.inherit {
+		if h == 0 || h == InvalidHandle {
+			return nil, fmt.Errorf("invalid inherited handle 0x%x", uintptr(h))
+		}
+	}
+
+	var handles []Handle
+	seen := make(map[Handle]bool)
+	for _, h := range append([]Handle{s.stdin, s.stdout, s.stderr}, s.inherit...) {
+		if h == 0 || h == InvalidHandle || seen[h] {
+			continue
+		}
+		seen[h] = true
+		handles = append(handles, h)
+	}
+	return handles, nil
+}
+
+// Whether the standard handles are passed by STARTF_USESTDHANDLES
+func (s *ProcessSpec) useStdHandles() bool {
+	return s.stdin != 0 || s.stdout != 0 || s.stderr != 0
+}
+
+// The attributes of the process, nil if the attribute list is not needed.
+func (s *ProcessSpec) attributes() ([]procThreadAttribute, error) {
+	handles, err := s.inheritedHandles()
+	if err != nil {
+		return nil, err
+	}
+
+	var attributes []procThreadAttribute
+	if s.parent != 0 {
+		attributes = append(attributes, procThreadAttribute{PROC_THREAD_ATTRIBUTE_PARENT_PROCESS, []Handle{s.parent}})
+	}
+	if len(handles) != 0 {
+		attributes = append(attributes, procThreadAttribute{PROC_THREAD_ATTRIBUTE_HANDLE_LIST, handles})
+	}
+	return attributes, nil
+}
+
+// The creation flags passed to CreateProcess
+func (s *ProcessSpec) creationFlags() uint32 {
+	flags := s.flags | CREATE_UNICODE_ENVIRONMENT | EXTENDED_STARTUPINFO_PRESENT
+	if s.job != 0 {
+		flags |= CREATE_SUSPENDED
+	}
+	return flags
+}
+
+// Build the environment block of CreateProcess
+// The variables are sorted by the names case-insensitively, the same as windows requires,
+// the later variable replaces the earlier one with the same name.
+// The names may start with =, such as the current directories of the drives (=C:=C:\dir).
+// The block is the UTF-16 "key=value\0" of each variable, and an extra \0 at the end.
+func makeEnvBlock(env []string) ([]uint16, error) {
+	type variable struct {
+		key   []uint16
+		entry string
+	}
+	index := make(map[string]int)
+	var vars []variable
+	for _, v := range env {
+		if strings.IndexByte(v, 0) >= 0 {
+			return nil, fmt.Errorf("the environment variable contains NUL, %q", v)
+		}
+		n := strings.IndexByte(v, '=')
+		if n == 0 {
+			n = strings.IndexByte(v[1:], '=') + 1
+		}
+		if n <= 0 {
+			return nil, fmt.Errorf("invalid environment variable %q", v)
+		}
+
+		key := strings.ToUpper(v[:n])
+		if i, ok := index[key]; ok {
+			vars[i].entry = v
+			continue
+		}
+		index[key] = len(vars)
+		vars = append(vars, variable{utf16.Encode([]rune(key)), v})
+	}
+
+	sort.SliceStable(vars, func(i, j int) bool {
+		return compareUTF16(vars[i].key, vars[j].key) < 0
+	})
+
+	var block []uint16
+	for _, v := range vars {
+		block = append(block, utf16.Encode([]rune(v.entry))...)
+		block = append(block, 0)
+	}
+	if len(block) == 0 {
+		block = append(block, 0)
+	}
+	return append(block, 0), nil
+}
+
+// Compare by the code units
+func compareUTF16(a, b []uint16) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			if a[i] < b[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return len(a) - len(b)
+}
@@ -0,0 +1,89 @@
+package gowindows
+
+import (
+	"reflect"
+	"testing"
+	"unicode/utf16"
+)
+
+func TestMakeEnvBlock(t *testing.T) {
+	data := []struct {
+		env   []string
+		block string
+	}{
+		{nil, "\x00\x00"},
+		{[]string{"b=2", "A=1", "=C:=C:\\dir", "_x=3"}, "=C:=C:\\dir\x00A=1\x00b=2\x00_x=3\x00\x00"},
+		{[]string{"Path=a", "x=", "PATH=b"}, "PATH=b\x00x=\x00\x00"},
+		{[]string{"ab=1", "a=2", "A_=3"}, "a=2\x00ab=1\x00A_=3\x00\x00"},
+		{[]string{"é=1", "z=2"}, "z=2\x00é=1\x00\x00"},
+	}
+	for _, v := range data {
+		block, err := makeEnvBlock(v.env)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s := string(utf16.Decode(block)); s != v.block {
+			t.Errorf("%q: %q!=%q", v.env, s, v.block)
+		}
+	}
+
+	for _, env := range [][]string{{"a"}, {"="}, {"=C:"}, {"a=b\x00c"}} {
+		if _, err := makeEnvBlock(env); err == nil {
+			t.Errorf("%q: no error", env)
+		}
+	}
+}
+
+func TestProcessSpec_attributes(t *testing.T) {
+	s := NewProcessSpec(`C:\a b\c.exe`, "x y", `"`)
+	if cmdLine := s.CommandLine(); cmdLine != `"C:\a b\c.exe" "x y" \"` {
+		t.Errorf("%v", cmdLine)
+	}
+	if cmdLine := s.CmdLine("c.exe /?").CommandLine(); cmdLine != "c.exe /?" {
+		t.Errorf("%v", cmdLine)
+	}
+
+	attributes, err := s.attributes()
+	if err != nil || attributes != nil {
+		t.Errorf("%v %v", attributes, err)
+	}
+	if s.useStdHandles() || s.creationFlags() != CREATE_UNICODE_ENVIRONMENT|EXTENDED_STARTUPINFO_PRESENT {
+		t.Errorf("0x%x", s.creationFlags())
+	}
+
+	s.Stdout(8).Stderr(8).Inherit(12, 8, 16, 12).Parent(100).Job(200).Flags(CREATE_NO_WINDOW)
+	attributes, err = s.attributes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []procThreadAttribute{
+		{PROC_THREAD_ATTRIBUTE_PARENT_PROCESS, []Handle{100}},
+		{PROC_THREAD_ATTRIBUTE_HANDLE_LIST, []Handle{8, 12, 16}},
+	}
+	if reflect.DeepEqual(attributes, expected) == false {
+		t.Errorf("%v!=%v", attributes, expected)
+	}
+	if s.useStdHandles() == false {
+		t.Errorf("useStdHandles false")
+	}
+	if flags := s.creationFlags(); flags != CREATE_UNICODE_ENVIRONMENT|EXTENDED_STARTUPINFO_PRESENT|CREATE_NO_WINDOW|CREATE_SUSPENDED {
+		t.Errorf("0x%x", flags)
+	}
+
+	if _, err := NewProcessSpec("a").Inherit(InvalidHandle).attributes(); err == nil {
+		t.Errorf("no error")
+	}
+}
+
+func TestProcessSpec_env(t *testing.T) {
+	s := NewProcessSpec("a").Env([]string{"A=1"}).Setenv("B", "2")
+	if reflect.DeepEqual(s.env, []string{"A=1", "B=2"}) == false {
+		t.Errorf("%v", s.env)
+	}
+	if s := NewProcessSpec("a").Env([]string{}); s.hasEnv == false {
+		t.Errorf("the empty env is not set")
+	}
+	if s := NewProcessSpec("a").Setenv("B", "2"); len(s.env) == 0 || s.env[len(s.env)-1] != "B=2" {
+		t.Errorf("%v", s.env)
+	}
+}
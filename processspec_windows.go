@@ -0,0 +1,274 @@
+package gowindows
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"syscall"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+var (
+	initializeProcThreadAttributeList = kernel32.NewProc("InitializeProcThreadAttributeList")
+	updateProcThreadAttribute         = kernel32.NewProc("UpdateProcThreadAttribute")
+	deleteProcThreadAttributeList     = kernel32.NewProc("DeleteProcThreadAttributeList")
+	createProcessAsUserW              = modadvapi32.NewProc("CreateProcessAsUserW")
+)
+
+// https://docs.microsoft.com/en-us/windows/win32/api/winbase/ns-winbase-startupinfoexw
+// typedef struct _STARTUPINFOEXW {
+// STARTUPINFOW                 StartupInfo;
+// LPPROC_THREAD_ATTRIBUTE_LIST lpAttributeList;
+// } STARTUPINFOEXW, *LPSTARTUPINFOEXW;
+type STARTUPINFOEX struct {
+	windows.StartupInfo
+	AttributeList *byte
+}
+
+// BOOL InitializeProcThreadAttributeList(
+// LPPROC_THREAD_ATTRIBUTE_LIST lpAttributeList,
+// DWORD                        dwAttributeCount,
+// DWORD                        dwFlags,
+// PSIZE_T                      lpSize
+// );
+func InitializeProcThreadAttributeList(attributeList *byte, attributeCount uint32, flags uint32, size *uintptr) error {
+	r1, _, e1 := initializeProcThreadAttributeList.Call(uintptr(unsafe.Pointer(attributeList)), uintptr(attributeCount), uintptr(flags), uintptr(unsafe.Pointer(size)))
+	if r1 == 0 {
+		return newLastCallError(kernel32.Name, initializeProcThreadAttributeList.Name, r1, e1)
+	}
+	return nil
+}
+
+// BOOL UpdateProcThreadAttribute(
+// LPPROC_THREAD_ATTRIBUTE_LIST lpAttributeList,
+// DWORD                        dwFlags,
+// DWORD_PTR                    Attribute,
+// PVOID                        lpValue,
+// SIZE_T                       cbSize,
+// PVOID                        lpPreviousValue,
+// PSIZE_T                      lpReturnSize
+// );
+func UpdateProcThreadAttribute(attributeList *byte, flags uint32, attribute uintptr, value unsafe.Pointer, size uintptr, previousValue unsafe.Pointer, returnSize *uintptr) error {
+	r1, _, e1 := updateProcThreadAttribute.Call(uintptr(unsafe.Pointer(attributeList)), uintptr(flags), attribute, uintptr(value), size, uintptr(previousValue), uintptr(unsafe.Pointer(returnSize)))
+	if r1 == 0 {
+		return newLastCallError(kernel32.Name, updateProcThreadAttribute.Name, r1, e1)
+	}
+	return nil
+}
+
+// void DeleteProcThreadAttributeList(
+// LPPROC_THREAD_ATTRIBUTE_LIST lpAttributeList
+// );
+func DeleteProcThreadAttributeList(attributeList *byte) {
+	deleteProcThreadAttributeList.Call(uintptr(unsafe.Pointer(attributeList)))
+}
+
+// BOOL CreateProcessAsUserW(
+// HANDLE                hToken,
+// LPCWSTR               lpApplicationName,
+// LPWSTR                lpCommandLine,
+// LPSECURITY_ATTRIBUTES lpProcessAttributes,
+// LPSECURITY_ATTRIBUTES lpThreadAttributes,
+// BOOL                  bInheritHandles,
+// DWORD                 dwCreationFlags,
+// LPVOID                lpEnvironment,
+// LPCWSTR               lpCurrentDirectory,
+// LPSTARTUPINFOW        lpStartupInfo,
+// LPPROCESS_INFORMATION lpProcessInformation
+// );
+func CreateProcessAsUser(token Handle, appName *uint16, commandLine *uint16, procSecurity *windows.SecurityAttributes, threadSecurity *windows.SecurityAttributes,
+	inheritHandles bool, creationFlags uint32, env *uint16, currentDir *uint16, startupInfo *windows.StartupInfo, outProcInfo *windows.ProcessInformation) error {
+	inherit := 0
+	if inheritHandles {
+		inherit = 1
+	}
+	r1, _, e1 := createProcessAsUserW.Call(uintptr(token), uintptr(unsafe.Pointer(appName)), uintptr(unsafe.Pointer(commandLine)),
+		uintptr(unsafe.Pointer(procSecurity)), uintptr(unsafe.Pointer(threadSecurity)), uintptr(inherit), uintptr(creationFlags),
+		uintptr(unsafe.Pointer(env)), uintptr(unsafe.Pointer(currentDir)), uintptr(unsafe.Pointer(startupInfo)), uintptr(unsafe.Pointer(outProcInfo)))
+	if r1 == 0 {
+		return newLastCallError(modadvapi32.Name, createProcessAsUserW.Name, r1, e1)
+	}
+	return nil
+}
+
+// Build the attribute list, the values must be alive until the list is deleted.
+func newProcThreadAttributeList(attributes []procThreadAttribute) ([]byte, error) {
+	size := uintptr(0)
+	err := InitializeProcThreadAttributeList(nil, uint32(len(attributes)), 0, &size)
+	if errors.Is(err, windows.ERROR_INSUFFICIENT_BUFFER) == false {
+		if err == nil {
+			err = syscall.EINVAL
+		}
+		return nil, fmt.Errorf("InitializeProcThreadAttributeList, %w", err)
+	}
+
+	list := make([]byte, size)
+	if err := InitializeProcThreadAttributeList(&list[0], uint32(len(attributes)), 0, &size); err != nil {
+		return nil, fmt.Errorf("InitializeProcThreadAttributeList, %w", err)
+	}
+	for _, v := range attributes {
+		err := UpdateProcThreadAttribute(&list[0], 0, v.attribute, unsafe.Pointer(&v.handles[0]), uintptr(len(v.handles))*unsafe.Sizeof(v.handles[0]), nil, nil)
+		if err != nil {
+			DeleteProcThreadAttributeList(&list[0])
+			return nil, fmt.Errorf("UpdateProcThreadAttribute(0x%x), %w", v.attribute, err)
+		}
+	}
+	return list, nil
+}
+
+// Create the process
+// The returned Process must be closed, it is also closed by the finalizer.
+func (s *ProcessSpec) Start() (*Process, error) {
+	var appName *uint16
+	var err error
+	if s.path != "" {
+		if appName, err = windows.UTF16PtrFromString(s.path); err != nil {
+			return nil, fmt.Errorf("windows.UTF16PtrFromString, %w", err)
+		}
+	}
+	// CreateProcessW may modify the command line
+	cmdLine, err := windows.UTF16FromString(s.CommandLine())
+	if err != nil {
+		return nil, fmt.Errorf("windows.UTF16FromString, %w", err)
+	}
+	var dir *uint16
+	if s.dir != "" {
+		if dir, err = windows.UTF16PtrFromString(s.dir); err != nil {
+			return nil, fmt.Errorf("windows.UTF16PtrFromString, %w", err)
+		}
+	}
+	var env []uint16
+	if s.hasEnv {
+		if env, err = makeEnvBlock(s.env); err != nil {
+			return nil, err
+		}
+	}
+
+	attributes, err := s.attributes()
+	if err != nil {
+		return nil, err
+	}
+	si := new(STARTUPINFOEX)
+	si.Cb = uint32(unsafe.Sizeof(*si))
+	if len(attributes) != 0 {
+		list, err := newProcThreadAttributeList(attributes)
+		if err != nil {
+			return nil, err
+		}
+		defer DeleteProcThreadAttributeList(&list[0])
+		si.AttributeList = &list[0]
+	}
+	if s.useStdHandles() {
+		si.Flags |= windows.STARTF_USESTDHANDLES
+		si.StdInput = windows.Handle(s.stdin)
+		si.StdOutput = windows.Handle(s.stdout)
+		si.StdErr = windows.Handle(s.stderr)
+	}
+	if s.hideWindow {
+		si.Flags |= windows.STARTF_USESHOWWINDOW
+		si.ShowWindow = windows.SW_HIDE
+	}
+
+	inheritHandles := false
+	for _, v := range attributes {
+		if v.attribute == PROC_THREAD_ATTRIBUTE_HANDLE_LIST {
+			inheritHandles = true
+		}
+	}
+	var envPtr *uint16
+	if env != nil {
+		envPtr = &env[0]
+	}
+
+	info := new(windows.ProcessInformation)
+	if s.token != 0 {
+		err = CreateProcessAsUser(s.token, appName, &cmdLine[0], nil, nil, inheritHandles, s.creationFlags(), envPtr, dir, &si.StartupInfo, info)
+		if err != nil {
+			return nil, fmt.Errorf("CreateProcessAsUser, %w", err)
+		}
+	} else {
+		err = windows.CreateProcess(appName, &cmdLine[0], nil, nil, inheritHandles, s.creationFlags(), envPtr, dir, &si.StartupInfo, info)
+		if err != nil {
+			return nil, fmt.Errorf("windows.CreateProcess, %w", err)
+		}
+	}
+	runtime.KeepAlive(attributes)
+	defer windows.CloseHandle(info.Thread)
+
+	if s.job != 0 {
+		if err := windows.AssignProcessToJobObject(windows.Handle(s.job), info.Process); err != nil {
+			windows.TerminateProcess(info.Process, 1)
+			windows.CloseHandle(info.Process)
+			return nil, fmt.Errorf("AssignProcessToJobObject, %w", err)
+		}
+		if s.flags&CREATE_SUSPENDED == 0 {
+			if _, err := windows.ResumeThread(info.Thread); err != nil {
+				windows.TerminateProcess(info.Process, 1)
+				windows.CloseHandle(info.Process)
+				return nil, fmt.Errorf("ResumeThread, %w", err)
+			}
+		}
+	}
+
+	p := &Process{syncObject: syncObject{handle: info.Process, kind: syncKindProcess}, pid: info.ProcessId, threadId: info.ThreadId}
+	runtime.SetFinalizer(p, (*Process).Close)
+	return p, nil
+}
+
+// The process created by ProcessSpec
+// Wait waits until it exits, it can be waited with the other objects by WaitMultiple.
+type Process struct {
+	syncObject
+	pid      uint32
+	threadId uint32
+}
+
+func (p *Process) Pid() uint32 {
+	return p.pid
+}
+
+// The id of the main thread
+func (p *Process) ThreadId() uint32 {
+	return p.threadId
+}
+
+// The handle of the process, it is valid until Close.
+func (p *Process) Handle() Handle {
+	return Handle(p.handle)
+}
+
+// ErrProcessRunning is returned if the process is not exited.
+func (p *Process) ExitCode() (uint32, error) {
+	code := uint32(0)
+	if err := windows.GetExitCodeProcess(p.handle, &code); err != nil {
+		return 0, fmt.Errorf("GetExitCodeProcess, %w", err)
+	}
+	if code == STILL_ACTIVE {
+		// The exit code of the process may be STILL_ACTIVE
+		if event, err := windows.WaitForSingleObject(p.handle, 0); err == nil && event == WAIT_TIMEOUT {
+			return 0, ErrProcessRunning
+		}
+	}
+	return code, nil
+}
+
+// Terminate the process, the exit code is 1.
+func (p *Process) Kill() error {
+	if err := windows.TerminateProcess(p.handle, 1); err != nil {
+		return fmt.Errorf("TerminateProcess, %w", err)
+	}
+	return nil
+}
+
+// Close the handle, the process is not terminated.
+func (p *Process) Close() error {
+	if p.handle == 0 {
+		return syscall.EINVAL
+	}
+	runtime.SetFinalizer(p, nil)
+	err := windows.CloseHandle(p.handle)
+	p.handle = 0
+	return err
+}
@@ -0,0 +1,130 @@
+package gowindows
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/sys/windows"
+)
+
+// Write the variable and the current directory to stdout, exit with 3
+func TestProcessSpecHelperProcess(t *testing.T) {
+	if os.Getenv("GOWINDOWS_PROCESS_HELPER") == "" {
+		t.Skip("helper process")
+	}
+	if os.Getenv("GOWINDOWS_PROCESS_HELPER") == "sleep" {
+		time.Sleep(time.Minute)
+	}
+	dir, _ := os.Getwd()
+	os.Stdout.WriteString(os.Getenv("GOWINDOWS_PROCESS_VALUE") + "|" + dir)
+	os.Exit(3)
+}
+
+func TestProcessSpec_Start(t *testing.T) {
+	if os.Getenv("GOWINDOWS_PROCESS_HELPER") != "" {
+		t.Skip("helper process")
+	}
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	dir := os.Getenv("SystemRoot")
+	p, err := NewProcessSpec(os.Args[0], "-test.run=^TestProcessSpecHelperProcess$", "-test.count=1").
+		Env([]string{"SystemRoot=" + dir, "GOWINDOWS_PROCESS_HELPER=1"}).
+		Setenv("GOWINDOWS_PROCESS_VALUE", "a b").
+		Dir(dir).
+		Stdout(Handle(w.Fd())).
+		Flags(CREATE_NO_WINDOW).
+		Start()
+	w.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer p.Close()
+
+	out, err := ioutil.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Wait(timeoutContext(10 * time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	if s := string(out); strings.EqualFold(s, "a b|"+dir) == false {
+		t.Errorf("%v!=a b|%v", s, dir)
+	}
+	if code, err := p.ExitCode(); err != nil || code != 3 {
+		t.Errorf("%v %v!=3", code, err)
+	}
+}
+
+func startSleepProcess(t *testing.T, job Handle) *Process {
+	p, err := NewProcessSpec(os.Args[0], "-test.run=^TestProcessSpecHelperProcess$", "-test.count=1").
+		Setenv("GOWINDOWS_PROCESS_HELPER", "sleep").
+		Job(job).
+		Start()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func TestProcessSpec_Kill(t *testing.T) {
+	if os.Getenv("GOWINDOWS_PROCESS_HELPER") != "" {
+		t.Skip("helper process")
+	}
+
+	p := startSleepProcess(t, 0)
+	defer p.Close()
+
+	if _, err := p.ExitCode(); err != ErrProcessRunning {
+		t.Errorf("%v!=%v", err, ErrProcessRunning)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if err := p.Wait(ctx); err != context.DeadlineExceeded {
+		t.Errorf("%v!=%v", err, context.DeadlineExceeded)
+	}
+
+	if err := p.Kill(); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Wait(timeoutContext(10 * time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	if code, err := p.ExitCode(); err != nil || code != 1 {
+		t.Errorf("%v %v!=1", code, err)
+	}
+}
+
+// The process is terminated with the job
+func TestProcessSpec_job(t *testing.T) {
+	if os.Getenv("GOWINDOWS_PROCESS_HELPER") != "" {
+		t.Skip("helper process")
+	}
+
+	job, err := windows.CreateJobObject(nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer windows.CloseHandle(job)
+
+	p := startSleepProcess(t, Handle(job))
+	defer p.Close()
+
+	if err := windows.TerminateJobObject(job, 5); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := WaitMultiple(timeoutContext(10*time.Second), false, p); err != nil {
+		t.Fatal(err)
+	}
+	if code, err := p.ExitCode(); err != nil || code != 5 {
+		t.Errorf("%v %v!=5", code, err)
+	}
+}
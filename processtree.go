@@ -0,0 +1,184 @@
+package gowindows
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// The process in the snapshot of Processes
+type ProcessEntry struct {
+	Pid       uint32
+	ParentPid uint32
+	// The file name of the image, such as svchost.exe, it is empty for the idle process.
+	ImageName string
+	SessionId uint32
+	Threads   uint32
+	// The zero time if it is unknown, such as the idle process.
+	CreationTime time.Time
+}
+
+// The process is created before p, it is the order of the children and the roots.
+// The process id orders the processes created at the same time.
+func (p *ProcessEntry) createdBefore(other *ProcessEntry) bool {
+	if p.CreationTime.Equal(other.CreationTime) {
+		return p.Pid < other.Pid
+	}
+	return p.CreationTime.Before(other.CreationTime)
+}
+
+// The processes of the snapshot organized by the parents
+// The process id of the exited process may be reused by the new process, the parent id of the process
+// is not its parent if the process of that id is created after it, so the process is a root.
+// The parent and the child are often created at the same time, such as cmd.exe and conhost.exe,
+// the link that makes a cycle between them is dropped.
+//
+//	processes, _ := Processes()
+//	tree := NewProcessTree(processes)
+//	shells := FilterProcesses(tree.Descendants(pid), ByImageName("cmd.exe"))
+type ProcessTree struct {
+	processes map[uint32]*ProcessEntry
+	parents   map[uint32]uint32
+	children  map[uint32][]uint32
+	roots     []uint32
+}
+
+// Build the tree of the snapshot, the process ids must be unique in it.
+// The children and the roots are ordered by the creation time.
+func NewProcessTree(processes []ProcessEntry) *ProcessTree {
+	t := &ProcessTree{
+		processes: make(map[uint32]*ProcessEntry, len(processes)),
+		parents:   make(map[uint32]uint32),
+		children:  make(map[uint32][]uint32),
+	}
+	sorted := make([]*ProcessEntry, len(processes))
+	for i := range processes {
+		p := processes[i]
+		t.processes[p.Pid] = &p
+		sorted[i] = &p
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].createdBefore(sorted[j])
+	})
+
+	for _, p := range sorted {
+		parent, ok := t.processes[p.ParentPid]
+		if ok && parent.CreationTime.After(p.CreationTime) == false && t.isAncestor(p.Pid, parent.Pid) == false {
+			t.parents[p.Pid] = parent.Pid
+			t.children[parent.Pid] = append(t.children[parent.Pid], p.Pid)
+		} else {
+			t.roots = append(t.roots, p.Pid)
+		}
+	}
+	return t
+}
+
+// Whether ancestor is pid or an ancestor of pid in the links made so far
+func (t *ProcessTree) isAncestor(ancestor, pid uint32) bool {
+	for {
+		if pid == ancestor {
+			return true
+		}
+		parent, ok := t.parents[pid]
+		if ok == false {
+			return false
+		}
+		pid = parent
+	}
+}
+
+func (t *ProcessTree) entries(pids []uint32) []ProcessEntry {
+	if len(pids) == 0 {
+		return nil
+	}
+	result := make([]ProcessEntry, len(pids))
+	for i, pid := range pids {
+		result[i] = *t.processes[pid]
+	}
+	return result
+}
+
+// The processes without the parent in the snapshot, the parent is exited or the id is reused.
+func (t *ProcessTree) Roots() []ProcessEntry {
+	return t.entries(t.roots)
+}
+
+func (t *ProcessTree) Get(pid uint32) (ProcessEntry, bool) {
+	p, ok := t.processes[pid]
+	if ok == false {
+		return ProcessEntry{}, false
+	}
+	return *p, true
+}
+
+// The parent of the process, false if the process is a root.
+func (t *ProcessTree) Parent(pid uint32) (ProcessEntry, bool) {
+	parent, ok := t.parents[pid]
+	if ok == false {
+		return ProcessEntry{}, false
+	}
+	return *t.processes[parent], true
+}
+
+// The children of the process
+func (t *ProcessTree) Children(pid uint32) []ProcessEntry {
+	return t.entries(t.children[pid])
+}
+
+// The children of the process and their descendants, depth first.
+func (t *ProcessTree) Descendants(pid uint32) []ProcessEntry {
+	var pids []uint32
+	var walk func(pid uint32)
+	walk = func(pid uint32) {
+		for _, child := range t.children[pid] {
+			pids = append(pids, child)
+			walk(child)
+		}
+	}
+	walk(pid)
+	return t.entries(pids)
+}
+
+// The processes which match the filter
+func FilterProcesses(processes []ProcessEntry, filter func(p *ProcessEntry) bool) []ProcessEntry {
+	var result []ProcessEntry
+	for i := range processes {
+		if filter(&processes[i]) {
+			result = append(result, processes[i])
+		}
+	}
+	return result
+}
+
+// The filter of the image name, it is case insensitive.
+func ByImageName(name string) func(p *ProcessEntry) bool {
+	return func(p *ProcessEntry) bool {
+		return strings.EqualFold(p.ImageName, name)
+	}
+}
+
+// The filter of the children of the process, or the descendants if recursive is true.
+func (t *ProcessTree) ChildrenOf(pid uint32, recursive bool) func(p *ProcessEntry) bool {
+	return func(p *ProcessEntry) bool {
+		for parent, ok := t.parents[p.Pid]; ok; parent, ok = t.parents[parent] {
+			if parent == pid {
+				return true
+			}
+			if recursive == false {
+				return false
+			}
+		}
+		return false
+	}
+}
+
+// The time of FILETIME, the 100-nanosecond intervals since January 1, 1601 (UTC).
+// The zero FILETIME is the zero time.
+func filetimeToTime(ft int64) time.Time {
+	if ft == 0 {
+		return time.Time{}
+	}
+	// 1601-01-01 to 1970-01-01
+	const epoch = 116444736000000000
+	return time.Unix(0, (ft-epoch)*100)
+}
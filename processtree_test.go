@@ -0,0 +1,115 @@
+package gowindows
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func processPids(processes []ProcessEntry) []uint32 {
+	pids := make([]uint32, 0, len(processes))
+	for _, p := range processes {
+		pids = append(pids, p.Pid)
+	}
+	return pids
+}
+
+func TestProcessTree(t *testing.T) {
+	boot := time.Date(2020, 7, 1, 8, 0, 0, 0, time.UTC)
+	at := func(seconds int) time.Time {
+		return boot.Add(time.Duration(seconds) * time.Second)
+	}
+	processes := []ProcessEntry{
+		{Pid: 0, ParentPid: 0, ImageName: ""},
+		{Pid: 4, ParentPid: 0, ImageName: "System", CreationTime: at(0)},
+		{Pid: 400, ParentPid: 4, ImageName: "smss.exe", CreationTime: at(1)},
+		{Pid: 600, ParentPid: 500, ImageName: "wininit.exe", CreationTime: at(3)},
+		{Pid: 700, ParentPid: 600, ImageName: "services.exe", CreationTime: at(4)},
+		{Pid: 800, ParentPid: 700, ImageName: "svchost.exe", CreationTime: at(5)},
+		{Pid: 900, ParentPid: 700, ImageName: "SVCHOST.EXE", CreationTime: at(6)},
+		{Pid: 1000, ParentPid: 800, ImageName: "cmd.exe", CreationTime: at(10)},
+		// The parent 1200 exited, its id is reused by the later process
+		{Pid: 1100, ParentPid: 1200, ImageName: "orphan.exe", CreationTime: at(20)},
+		{Pid: 1200, ParentPid: 1000, ImageName: "cmd.exe", CreationTime: at(30)},
+		// Created at the same time, the parents of each other
+		{Pid: 1300, ParentPid: 1400, ImageName: "a.exe", CreationTime: at(40)},
+		{Pid: 1400, ParentPid: 1300, ImageName: "b.exe", CreationTime: at(40)},
+		// The child created at the same time as the parent, with a lower id
+		{Pid: 1550, ParentPid: 1600, ImageName: "conhost.exe", CreationTime: at(50)},
+		{Pid: 1600, ParentPid: 1700, ImageName: "cmd.exe", CreationTime: at(50)},
+	}
+	tree := NewProcessTree(processes)
+
+	if pids := processPids(tree.Roots()); reflect.DeepEqual(pids, []uint32{0, 600, 1100, 1400, 1600}) == false {
+		t.Errorf("%v", pids)
+	}
+	if pids := processPids(tree.Children(0)); reflect.DeepEqual(pids, []uint32{4}) == false {
+		t.Errorf("%v", pids)
+	}
+	if pids := processPids(tree.Children(700)); reflect.DeepEqual(pids, []uint32{800, 900}) == false {
+		t.Errorf("%v", pids)
+	}
+	if pids := processPids(tree.Children(1200)); len(pids) != 0 {
+		t.Errorf("%v", pids)
+	}
+	if pids := processPids(tree.Children(1400)); reflect.DeepEqual(pids, []uint32{1300}) == false {
+		t.Errorf("%v", pids)
+	}
+	if pids := processPids(tree.Children(1600)); reflect.DeepEqual(pids, []uint32{1550}) == false {
+		t.Errorf("%v", pids)
+	}
+	if pids := processPids(tree.Descendants(600)); reflect.DeepEqual(pids, []uint32{700, 800, 1000, 1200, 900}) == false {
+		t.Errorf("%v", pids)
+	}
+	if pids := processPids(tree.Descendants(12345)); len(pids) != 0 {
+		t.Errorf("%v", pids)
+	}
+
+	if p, ok := tree.Parent(1200); ok == false || p.Pid != 1000 {
+		t.Errorf("%v %v", p, ok)
+	}
+	if p, ok := tree.Parent(1550); ok == false || p.Pid != 1600 {
+		t.Errorf("%v %v", p, ok)
+	}
+	for _, pid := range []uint32{0, 600, 1100, 12345} {
+		if p, ok := tree.Parent(pid); ok {
+			t.Errorf("%v: %v", pid, p)
+		}
+	}
+	if p, ok := tree.Get(900); ok == false || p != processes[6] {
+		t.Errorf("%v %v", p, ok)
+	}
+	if _, ok := tree.Get(12345); ok {
+		t.Errorf("12345")
+	}
+
+	if pids := processPids(FilterProcesses(processes, ByImageName("svchost.exe"))); reflect.DeepEqual(pids, []uint32{800, 900}) == false {
+		t.Errorf("%v", pids)
+	}
+	if pids := processPids(FilterProcesses(processes, tree.ChildrenOf(700, false))); reflect.DeepEqual(pids, []uint32{800, 900}) == false {
+		t.Errorf("%v", pids)
+	}
+	if pids := processPids(FilterProcesses(processes, tree.ChildrenOf(700, true))); reflect.DeepEqual(pids, []uint32{800, 900, 1000, 1200}) == false {
+		t.Errorf("%v", pids)
+	}
+	shells := FilterProcesses(tree.Descendants(700), ByImageName("CMD.exe"))
+	if pids := processPids(shells); reflect.DeepEqual(pids, []uint32{1000, 1200}) == false {
+		t.Errorf("%v", pids)
+	}
+	if result := FilterProcesses(processes, ByImageName("none.exe")); result != nil {
+		t.Errorf("%v", result)
+	}
+}
+
+func TestFiletimeToTime(t *testing.T) {
+	if v := filetimeToTime(0); v.IsZero() == false {
+		t.Errorf("%v", v)
+	}
+	if v := filetimeToTime(116444736000000000); v.Equal(time.Unix(0, 0)) == false {
+		t.Errorf("%v", v)
+	}
+	// 2020-07-01 00:00:00.1234567 UTC
+	if v, want := filetimeToTime(132380352001234567), time.Date(2020, 7, 1, 0, 0, 0, 123456700, time.UTC); v.Equal(want) == false {
+		t.Errorf("%v!=%v", v, want)
+	}
+}
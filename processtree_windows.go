@@ -0,0 +1,64 @@
+package gowindows
+
+import (
+	"fmt"
+	"unicode/utf16"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// The snapshot of the processes (SystemProcessInformation)
+// The image name of the idle process (pid 0) is empty, the processes may exit after the snapshot.
+func Processes() ([]ProcessEntry, error) {
+	size := uint32(256 << 10)
+	var buf []uint64
+	for {
+		// []uint64 aligns the entries
+		buf = make([]uint64, size/8)
+		needed := uint32(0)
+		err := NtQuerySystemInformation(SystemProcessInformation, windows.Pointer(unsafe.Pointer(&buf[0])), size, &needed)
+		if err == nil {
+			break
+		}
+		if isBufferSizeStatus(err) == false || needed <= size {
+			return nil, fmt.Errorf("NtQuerySystemInformation, %w", err)
+		}
+		// The processes may be created before the next call
+		size = (needed + 64<<10) &^ 7
+	}
+
+	// The image names are in the buffer
+	base := uintptr(unsafe.Pointer(&buf[0]))
+	names := (*[1 << 28]uint16)(unsafe.Pointer(&buf[0]))[: size/2 : size/2]
+
+	var processes []ProcessEntry
+	for offset := uint32(0); ; {
+		info := (*SYSTEM_PROCESS_INFORMATION)(unsafe.Pointer(&names[offset/2]))
+		p := ProcessEntry{
+			Pid:          uint32(info.UniqueProcessId),
+			ParentPid:    uint32(info.InheritedFromUniqueProcessId),
+			SessionId:    info.SessionId,
+			Threads:      info.NumberOfThreads,
+			CreationTime: filetimeToTime(info.CreateTime),
+		}
+		if info.ImageName.Length != 0 {
+			start := (uintptr(info.ImageName.Buffer) - base) / 2
+			end := start + uintptr(info.ImageName.Length)/2
+			if uintptr(info.ImageName.Buffer) < base || end > uintptr(len(names)) {
+				return nil, fmt.Errorf("the image name of the process %v is out of the buffer", p.Pid)
+			}
+			p.ImageName = string(utf16.Decode(names[start:end]))
+		}
+		processes = append(processes, p)
+
+		if info.NextEntryOffset == 0 {
+			break
+		}
+		offset += info.NextEntryOffset
+		if offset+uint32(unsafe.Sizeof(*info)) > size {
+			return nil, fmt.Errorf("the process is out of the buffer")
+		}
+	}
+	return processes, nil
+}
@@ -0,0 +1,56 @@
+package gowindows
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestProcesses(t *testing.T) {
+	if os.Getenv("GOWINDOWS_PROCESS_HELPER") != "" {
+		t.Skip("helper process")
+	}
+	child := startSleepProcess(t, 0)
+	defer child.Close()
+	defer child.Kill()
+
+	processes, err := Processes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tree := NewProcessTree(processes)
+
+	self, ok := tree.Get(uint32(os.Getpid()))
+	if ok == false {
+		t.Fatalf("%v is not found", os.Getpid())
+	}
+	exe, err := os.Executable()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.EqualFold(self.ImageName, filepath.Base(exe)) == false {
+		t.Errorf("%v!=%v", self.ImageName, filepath.Base(exe))
+	}
+	if self.ParentPid != uint32(os.Getppid()) || self.Threads == 0 {
+		t.Errorf("%#v", self)
+	}
+	if d := time.Since(self.CreationTime); d < 0 || d > time.Hour {
+		t.Errorf("%v", self.CreationTime)
+	}
+
+	// The exited children of the other tests may be in the snapshot
+	children := FilterProcesses(tree.Children(self.Pid), func(p *ProcessEntry) bool {
+		return p.Pid == child.Pid()
+	})
+	if len(children) != 1 || children[0].SessionId != self.SessionId {
+		t.Errorf("%v", tree.Children(self.Pid))
+	}
+	if result := FilterProcesses(processes, ByImageName(self.ImageName)); len(result) < 2 {
+		t.Errorf("%v", result)
+	}
+	if idle, ok := tree.Get(0); ok == false || idle.ImageName != "" {
+		t.Errorf("%#v", idle)
+	}
+}
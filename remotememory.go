@@ -0,0 +1,489 @@
+package gowindows
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf16"
+	"unsafe"
+)
+
+// The memory of a process, the addresses are in that process.
+// The pointer size is the size of the structures read by it, such as PEB32 or PEB64,
+// so the 64-bit program reads the 32-bit PEB of the WoW64 process with the pointer size 4.
+type RemoteMemory interface {
+	// Read len(buf) bytes at addr, it fails if any of them can not be read.
+	ReadAt(addr uint64, buf []byte) error
+	// 4 or 8
+	PointerSize() int
+}
+
+// The address is not mapped in MemoryImage
+var ErrMemoryUnreadable = errors.New("the memory can not be read")
+
+// PEB.Ldr is NULL, such as the new process which is created suspended
+var ErrLoaderNotInitialized = errors.New("the loader data is not initialized")
+
+// The process is not the 32-bit process on the 64-bit system
+var ErrNotWow64Process = errors.New("the process is not the WoW64 process")
+
+// RemoteMemory in the memory, such as the memory image of the tests.
+type MemoryImage struct {
+	pointerSize int
+	regions     []memoryRegion
+}
+
+type memoryRegion struct {
+	addr uint64
+	data []byte
+}
+
+func NewMemoryImage(pointerSize int) *MemoryImage {
+	return &MemoryImage{pointerSize: pointerSize}
+}
+
+// Map a copy of data at addr, it overwrites the previous data at the same addresses.
+func (m *MemoryImage) Write(addr uint64, data []byte) {
+	m.regions = append(m.regions, memoryRegion{addr, append([]byte(nil), data...)})
+}
+
+// Map the struct at addr, the struct is encoded by encoding/binary.
+func (m *MemoryImage) WriteStruct(addr uint64, v interface{}) error {
+	var buf bytes.Buffer
+	if err := binary.Write(&buf, binary.LittleEndian, v); err != nil {
+		return err
+	}
+	m.Write(addr, buf.Bytes())
+	return nil
+}
+
+func (m *MemoryImage) ReadAt(addr uint64, buf []byte) error {
+	end := addr + uint64(len(buf))
+	if end < addr {
+		return fmt.Errorf("%w, 0x%x", ErrMemoryUnreadable, addr)
+	}
+
+	covered := 0
+	mapped := make([]bool, len(buf))
+	for _, r := range m.regions {
+		rEnd := r.addr + uint64(len(r.data))
+		if r.addr >= end || rEnd <= addr {
+			continue
+		}
+		start, stop := addr, end
+		if r.addr > start {
+			start = r.addr
+		}
+		if rEnd < stop {
+			stop = rEnd
+		}
+		copy(buf[start-addr:stop-addr], r.data[start-r.addr:])
+		for i := start - addr; i < stop-addr; i++ {
+			if mapped[i] == false {
+				mapped[i] = true
+				covered++
+			}
+		}
+	}
+	if covered != len(buf) {
+		return fmt.Errorf("%w, 0x%x", ErrMemoryUnreadable, addr)
+	}
+	return nil
+}
+
+func (m *MemoryImage) PointerSize() int {
+	return m.pointerSize
+}
+
+// Read the struct at addr, the struct is decoded by encoding/binary, so it must not have the padding of the alignment.
+func readStruct(m RemoteMemory, addr uint64, v interface{}) error {
+	buf := make([]byte, binary.Size(v))
+	if err := m.ReadAt(addr, buf); err != nil {
+		return err
+	}
+	return binary.Read(bytes.NewReader(buf), binary.LittleEndian, v)
+}
+
+// Read the UTF-16 string of the byte length at addr
+func readUTF16(m RemoteMemory, addr uint64, length int) (string, error) {
+	if length <= 0 {
+		return "", nil
+	}
+	buf := make([]byte, length&^1)
+	if err := m.ReadAt(addr, buf); err != nil {
+		return "", err
+	}
+	s := make([]uint16, len(buf)/2)
+	for i := range s {
+		s[i] = binary.LittleEndian.Uint16(buf[i*2:])
+	}
+	return string(utf16.Decode(s)), nil
+}
+
+// UNICODE_STRING of either pointer size
+type remoteUnicodeString struct {
+	Length uint16
+	Buffer uint64
+}
+
+func (s remoteUnicodeString) read(m RemoteMemory) (string, error) {
+	return readUTF16(m, s.Buffer, int(s.Length))
+}
+
+// RTL_USER_PROCESS_PARAMETERS of either pointer size
+type remoteProcessParameters struct {
+	currentDirectory remoteUnicodeString
+	dllPath          remoteUnicodeString
+	imagePathName    remoteUnicodeString
+	commandLine      remoteUnicodeString
+	windowTitle      remoteUnicodeString
+	desktopInfo      remoteUnicodeString
+	shellInfo        remoteUnicodeString
+	environment      uint64
+	environmentSize  uint64
+}
+
+// Read RTL_USER_PROCESS_PARAMETERS of the PEB at pebAddress
+func readProcessParameters(m RemoteMemory, pebAddress uint64) (*remoteProcessParameters, error) {
+	str32 := func(s UNICODE_STRING32) remoteUnicodeString {
+		return remoteUnicodeString{s.Length, uint64(s.Buffer)}
+	}
+	str64 := func(s UNICODE_STRING64) remoteUnicodeString {
+		return remoteUnicodeString{s.Length, s.Buffer}
+	}
+
+	switch m.PointerSize() {
+	case 4:
+		peb := PEB32{}
+		if err := readStruct(m, pebAddress, &peb); err != nil {
+			return nil, fmt.Errorf("PEB, %w", err)
+		}
+		params := RTL_USER_PROCESS_PARAMETERS_FULL32{}
+		if err := readVersionedStruct(m, uint64(peb.ProcessParameters), &params); err != nil {
+			return nil, fmt.Errorf("RTL_USER_PROCESS_PARAMETERS, %w", err)
+		}
+		return &remoteProcessParameters{
+			currentDirectory: str32(params.CurrentDirectory.DosPath),
+			dllPath:          str32(params.DllPath),
+			imagePathName:    str32(params.ImagePathName),
+			commandLine:      str32(params.CommandLine),
+			windowTitle:      str32(params.WindowTitle),
+			desktopInfo:      str32(params.DesktopInfo),
+			shellInfo:        str32(params.ShellInfo),
+			environment:      uint64(params.Environment),
+			environmentSize:  uint64(params.EnvironmentSize),
+		}, nil
+	case 8:
+		peb := PEB64{}
+		if err := readStruct(m, pebAddress, &peb); err != nil {
+			return nil, fmt.Errorf("PEB, %w", err)
+		}
+		params := RTL_USER_PROCESS_PARAMETERS_FULL64{}
+		if err := readVersionedStruct(m, peb.ProcessParameters, &params); err != nil {
+			return nil, fmt.Errorf("RTL_USER_PROCESS_PARAMETERS, %w", err)
+		}
+		return &remoteProcessParameters{
+			currentDirectory: str64(params.CurrentDirectory.DosPath),
+			dllPath:          str64(params.DllPath),
+			imagePathName:    str64(params.ImagePathName),
+			commandLine:      str64(params.CommandLine),
+			windowTitle:      str64(params.WindowTitle),
+			desktopInfo:      str64(params.DesktopInfo),
+			shellInfo:        str64(params.ShellInfo),
+			environment:      params.Environment,
+			environmentSize:  params.EnvironmentSize,
+		}, nil
+	}
+	return nil, fmt.Errorf("invalid pointer size %v", m.PointerSize())
+}
+
+// Read the struct which starts with the ULONG MaximumLength and the ULONG Length, such as RTL_USER_PROCESS_PARAMETERS.
+// Only Length bytes are read if it is less than the struct, the other fields are zero.
+func readVersionedStruct(m RemoteMemory, addr uint64, v interface{}) error {
+	size := binary.Size(v)
+	header := make([]byte, 8)
+	if err := m.ReadAt(addr, header); err != nil {
+		return err
+	}
+	if length := int(binary.LittleEndian.Uint32(header[4:])); length >= len(header) && length < size {
+		size = length
+	}
+
+	buf := make([]byte, binary.Size(v))
+	if err := m.ReadAt(addr, buf[:size]); err != nil {
+		return err
+	}
+	return binary.Read(bytes.NewReader(buf), binary.LittleEndian, v)
+}
+
+// Read ImagePathName and CommandLine of RTL_USER_PROCESS_PARAMETERS, the PEB is at pebAddress.
+func ReadProcessParameters(m RemoteMemory, pebAddress uint64) (imagePathName string, commandLine string, err error) {
+	params, err := readProcessParameters(m, pebAddress)
+	if err != nil {
+		return "", "", err
+	}
+	if imagePathName, err = params.imagePathName.read(m); err != nil {
+		return "", "", fmt.Errorf("ImagePathName, %w", err)
+	}
+	if commandLine, err = params.commandLine.read(m); err != nil {
+		return "", "", fmt.Errorf("CommandLine, %w", err)
+	}
+	return imagePathName, commandLine, nil
+}
+
+// The parameters of another process, see ReadProcessInfo.
+type ProcessInfo struct {
+	ImagePathName string
+	CommandLine   string
+	// The current directory ends with \, such as C:\Windows\.
+	CurrentDirectory string
+	// The search path of the DLLs, it is empty since windows 8.
+	DllPath     string
+	WindowTitle string
+	// The window station and the desktop, such as Winsta0\Default.
+	DesktopInfo string
+	ShellInfo   string
+	// The environment variables, the names are case sensitive as they are in the process.
+	// The names may start with =, such as the current directories of the drives (=C:).
+	Environment map[string]string
+}
+
+// The environment variable, the name is case insensitive as windows.
+func (p *ProcessInfo) Getenv(key string) (string, bool) {
+	if v, ok := p.Environment[key]; ok {
+		return v, true
+	}
+	for k, v := range p.Environment {
+		if strings.EqualFold(k, key) {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+// The limit of the environment block, in bytes.
+const maxEnvironmentSize = 16 << 20
+
+// Read RTL_USER_PROCESS_PARAMETERS and the environment block, the PEB is at pebAddress.
+// The process may change them while they are read, they are not locked.
+func ReadProcessInfo(m RemoteMemory, pebAddress uint64) (*ProcessInfo, error) {
+	params, err := readProcessParameters(m, pebAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	info := new(ProcessInfo)
+	fields := []struct {
+		name string
+		s    remoteUnicodeString
+		v    *string
+	}{
+		{"ImagePathName", params.imagePathName, &info.ImagePathName},
+		{"CommandLine", params.commandLine, &info.CommandLine},
+		{"CurrentDirectory", params.currentDirectory, &info.CurrentDirectory},
+		{"DllPath", params.dllPath, &info.DllPath},
+		{"WindowTitle", params.windowTitle, &info.WindowTitle},
+		{"DesktopInfo", params.desktopInfo, &info.DesktopInfo},
+		{"ShellInfo", params.shellInfo, &info.ShellInfo},
+	}
+	for _, v := range fields {
+		if *v.v, err = v.s.read(m); err != nil {
+			return nil, fmt.Errorf("%v, %w", v.name, err)
+		}
+	}
+
+	block, err := readEnvironmentBlock(m, params.environment, params.environmentSize)
+	if err != nil {
+		return nil, fmt.Errorf("Environment, %w", err)
+	}
+	info.Environment = parseEnvironmentBlock(block)
+	return info, nil
+}
+
+// Read the environment block at addr
+// The size is 0 before windows vista, the block is read by pages until the double NUL.
+func readEnvironmentBlock(m RemoteMemory, addr uint64, size uint64) ([]uint16, error) {
+	if addr == 0 {
+		return nil, nil
+	}
+	if size > maxEnvironmentSize {
+		return nil, fmt.Errorf("the environment block is too large, %v", size)
+	}
+
+	var buf []byte
+	if size != 0 {
+		buf = make([]byte, size&^1)
+		if err := m.ReadAt(addr, buf); err != nil {
+			return nil, err
+		}
+	} else {
+		const pageSize = 0x1000
+		for {
+			// The block may end at any page, the next page may be unreadable.
+			page := make([]byte, pageSize-(addr+uint64(len(buf)))%pageSize)
+			if err := m.ReadAt(addr+uint64(len(buf)), page); err != nil {
+				return nil, err
+			}
+			buf = append(buf, page...)
+			if endOfEnvironmentBlock(buf) >= 0 {
+				break
+			}
+			if len(buf) > maxEnvironmentSize {
+				return nil, fmt.Errorf("the environment block is too large")
+			}
+		}
+	}
+
+	block := make([]uint16, len(buf)/2)
+	for i := range block {
+		block[i] = binary.LittleEndian.Uint16(buf[i*2:])
+	}
+	return block, nil
+}
+
+// The byte offset after the double NUL of the UTF-16 block, or -1.
+func endOfEnvironmentBlock(buf []byte) int {
+	for i := 0; i+4 <= len(buf); i += 2 {
+		if buf[i] == 0 && buf[i+1] == 0 && buf[i+2] == 0 && buf[i+3] == 0 {
+			return i + 4
+		}
+	}
+	return -1
+}
+
+// Parse the environment block of "key=value\0" and an extra \0 at the end, the reverse of makeEnvBlock.
+// The block ends at the empty variable or the end of the slice, the variables without = are ignored.
+func parseEnvironmentBlock(block []uint16) map[string]string {
+	env := make(map[string]string)
+	for len(block) > 0 {
+		n := 0
+		for n < len(block) && block[n] != 0 {
+			n++
+		}
+		if n == 0 {
+			break
+		}
+		v := string(utf16.Decode(block[:n]))
+		if n < len(block) {
+			n++
+		}
+		block = block[n:]
+
+		// The names may start with =
+		i := strings.IndexByte(v[1:], '=') + 1
+		if i <= 0 {
+			continue
+		}
+		env[v[:i]] = v[i+1:]
+	}
+	return env
+}
+
+// The module loaded by the loader, see ReadProcessModules.
+type ProcessModule struct {
+	BaseAddress uint64
+	SizeOfImage uint32
+	// 0 if the module has no entry point, such as the exe of .NET
+	EntryPoint  uint64
+	FullDllName string
+	BaseDllName string
+}
+
+// The limit of the modules, the list is corrupted if it is longer.
+const maxProcessModules = 0x10000
+
+// The modules of InLoadOrderModuleList of PEB.Ldr, the PEB is at pebAddress.
+// The first module is the exe, the second is ntdll.dll.
+// The 64-bit PEB of the WoW64 process has only the 64-bit modules, such as wow64.dll,
+// the 32-bit modules are in the 32-bit PEB.
+// The list is not locked, it may fail if the process loads or unloads the modules at the same time.
+func ReadProcessModules(m RemoteMemory, pebAddress uint64) ([]ProcessModule, error) {
+	var head, first uint64
+	var readEntry func(addr uint64) (*remoteModuleEntry, error)
+	switch m.PointerSize() {
+	case 4:
+		peb := PEB32{}
+		if err := readStruct(m, pebAddress, &peb); err != nil {
+			return nil, fmt.Errorf("PEB, %w", err)
+		}
+		if peb.Ldr == 0 {
+			return nil, ErrLoaderNotInitialized
+		}
+		ldr := PEB_LDR_DATA32{}
+		if err := readStruct(m, uint64(peb.Ldr), &ldr); err != nil {
+			return nil, fmt.Errorf("PEB_LDR_DATA, %w", err)
+		}
+		head = uint64(peb.Ldr) + uint64(unsafe.Offsetof(ldr.InLoadOrderModuleList))
+		first = uint64(ldr.InLoadOrderModuleList.Flink)
+		readEntry = func(addr uint64) (*remoteModuleEntry, error) {
+			e := LDR_DATA_TABLE_ENTRY32{}
+			if err := readStruct(m, addr, &e); err != nil {
+				return nil, err
+			}
+			return &remoteModuleEntry{
+				next:        uint64(e.InLoadOrderLinks.Flink),
+				module:      ProcessModule{BaseAddress: uint64(e.DllBase), SizeOfImage: e.SizeOfImage, EntryPoint: uint64(e.EntryPoint)},
+				fullDllName: remoteUnicodeString{e.FullDllName.Length, uint64(e.FullDllName.Buffer)},
+				baseDllName: remoteUnicodeString{e.BaseDllName.Length, uint64(e.BaseDllName.Buffer)},
+			}, nil
+		}
+	case 8:
+		peb := PEB64{}
+		if err := readStruct(m, pebAddress, &peb); err != nil {
+			return nil, fmt.Errorf("PEB, %w", err)
+		}
+		if peb.Ldr == 0 {
+			return nil, ErrLoaderNotInitialized
+		}
+		ldr := PEB_LDR_DATA64{}
+		if err := readStruct(m, peb.Ldr, &ldr); err != nil {
+			return nil, fmt.Errorf("PEB_LDR_DATA, %w", err)
+		}
+		head = peb.Ldr + uint64(unsafe.Offsetof(ldr.InLoadOrderModuleList))
+		first = ldr.InLoadOrderModuleList.Flink
+		readEntry = func(addr uint64) (*remoteModuleEntry, error) {
+			e := LDR_DATA_TABLE_ENTRY64{}
+			if err := readStruct(m, addr, &e); err != nil {
+				return nil, err
+			}
+			return &remoteModuleEntry{
+				next:        e.InLoadOrderLinks.Flink,
+				module:      ProcessModule{BaseAddress: e.DllBase, SizeOfImage: e.SizeOfImage, EntryPoint: e.EntryPoint},
+				fullDllName: remoteUnicodeString{e.FullDllName.Length, e.FullDllName.Buffer},
+				baseDllName: remoteUnicodeString{e.BaseDllName.Length, e.BaseDllName.Buffer},
+			}, nil
+		}
+	default:
+		return nil, fmt.Errorf("invalid pointer size %v", m.PointerSize())
+	}
+
+	var modules []ProcessModule
+	for addr := first; addr != head; {
+		if addr == 0 || len(modules) >= maxProcessModules {
+			return nil, fmt.Errorf("the module list is corrupted at 0x%x", addr)
+		}
+		e, err := readEntry(addr)
+		if err != nil {
+			return nil, fmt.Errorf("LDR_DATA_TABLE_ENTRY(0x%x), %w", addr, err)
+		}
+		if e.module.FullDllName, err = e.fullDllName.read(m); err != nil {
+			return nil, fmt.Errorf("FullDllName, %w", err)
+		}
+		if e.module.BaseDllName, err = e.baseDllName.read(m); err != nil {
+			return nil, fmt.Errorf("BaseDllName, %w", err)
+		}
+		modules = append(modules, e.module)
+		addr = e.next
+	}
+	return modules, nil
+}
+
+// LDR_DATA_TABLE_ENTRY of either pointer size
+type remoteModuleEntry struct {
+	next        uint64
+	module      ProcessModule
+	fullDllName remoteUnicodeString
+	baseDllName remoteUnicodeString
+}
@@ -0,0 +1,351 @@
+package gowindows
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"reflect"
+	"testing"
+	"unicode/utf16"
+	"unsafe"
+)
+
+func TestMemoryImage(t *testing.T) {
+	m := NewMemoryImage(8)
+	m.Write(0x1000, []byte{1, 2, 3, 4})
+	m.Write(0x1004, []byte{5, 6})
+	m.Write(0x1001, []byte{9})
+
+	buf := make([]byte, 6)
+	if err := m.ReadAt(0x1000, buf); err != nil {
+		t.Fatal(err)
+	}
+	if string(buf) != "\x01\x09\x03\x04\x05\x06" {
+		t.Errorf("% x", buf)
+	}
+
+	for _, addr := range []uint64{0xFFF, 0x1002, ^uint64(0)} {
+		if err := m.ReadAt(addr, buf); errors.Is(err, ErrMemoryUnreadable) == false {
+			t.Errorf("0x%x: %v!=%v", addr, err, ErrMemoryUnreadable)
+		}
+	}
+	if m.PointerSize() != 8 {
+		t.Errorf("%v!=8", m.PointerSize())
+	}
+}
+
+func TestRemoteStructSize(t *testing.T) {
+	data := []struct {
+		v    interface{}
+		size int
+	}{
+		{PEB32{}, 0x1D8},
+		{PEB64{}, 0x2C8},
+		{RTL_USER_PROCESS_PARAMETERS32{}, 0x48},
+		{RTL_USER_PROCESS_PARAMETERS64{}, 0x80},
+		{RTL_USER_PROCESS_PARAMETERS_FULL32{}, 0x2A4},
+		{RTL_USER_PROCESS_PARAMETERS_FULL64{}, 0x410},
+		{UNICODE_STRING32{}, 8},
+		{UNICODE_STRING64{}, 16},
+	}
+	for _, v := range data {
+		if size := binary.Size(v.v); size != v.size {
+			t.Errorf("%T: 0x%X!=0x%X", v.v, size, v.size)
+		}
+	}
+}
+
+// Write the UTF-16 string at addr
+func writeUTF16(m *MemoryImage, addr uint64, s string) uint16 {
+	u := utf16.Encode([]rune(s))
+	buf := make([]byte, len(u)*2+2)
+	for i, v := range u {
+		binary.LittleEndian.PutUint16(buf[i*2:], v)
+	}
+	m.Write(addr, buf)
+	return uint16(len(u) * 2)
+}
+
+// The synthetic memory of the process, the PEB is at 0x7000.
+func newProcessImage(pointerSize int, imagePathName, commandLine string) (*MemoryImage, uint64) {
+	return newProcessInfoImage(pointerSize, &ProcessInfo{ImagePathName: imagePathName, CommandLine: commandLine}, nil, true)
+}
+
+// The synthetic memory of the process with the parameters and the environment block, the PEB is at 0x7000.
+// The parameters of windows xp are without EnvironmentSize if sized is false.
+func newProcessInfoImage(pointerSize int, info *ProcessInfo, env []uint16, sized bool) (*MemoryImage, uint64) {
+	m := NewMemoryImage(pointerSize)
+	const peb, params, strings, environment = 0x7000, 0x20000, 0x20800, 0x30F00
+
+	var lengths []uint16
+	for i, v := range []string{info.ImagePathName, info.CommandLine, info.CurrentDirectory, info.DllPath,
+		info.WindowTitle, info.DesktopInfo, info.ShellInfo} {
+		lengths = append(lengths, writeUTF16(m, strings+uint64(i)*0x800, v))
+	}
+	str32 := func(i int) UNICODE_STRING32 {
+		return UNICODE_STRING32{lengths[i], lengths[i] + 2, strings + uint32(i)*0x800}
+	}
+	str64 := func(i int) UNICODE_STRING64 {
+		return UNICODE_STRING64{Length: lengths[i], MaximumLength: lengths[i] + 2, Buffer: strings + uint64(i)*0x800}
+	}
+
+	// The pages of the environment block, the page after it is unreadable.
+	m.Write(0x30000, make([]byte, 0x2000))
+	envBuf := make([]byte, len(env)*2)
+	for i, v := range env {
+		binary.LittleEndian.PutUint16(envBuf[i*2:], v)
+	}
+	m.Write(environment, envBuf)
+	envSize := uint64(len(envBuf))
+
+	var buf bytes.Buffer
+	if pointerSize == 4 {
+		m.WriteStruct(peb, &PEB32{ProcessParameters: params})
+		p := RTL_USER_PROCESS_PARAMETERS_FULL32{
+			MaximumLength:    uint32(binary.Size(RTL_USER_PROCESS_PARAMETERS_FULL32{})),
+			Length:           uint32(binary.Size(RTL_USER_PROCESS_PARAMETERS_FULL32{})),
+			ImagePathName:    str32(0),
+			CommandLine:      str32(1),
+			CurrentDirectory: CURDIR32{DosPath: str32(2)},
+			DllPath:          str32(3),
+			WindowTitle:      str32(4),
+			DesktopInfo:      str32(5),
+			ShellInfo:        str32(6),
+			Environment:      environment,
+			EnvironmentSize:  uint32(envSize),
+		}
+		if sized == false {
+			p.Length = uint32(unsafe.Offsetof(p.EnvironmentSize))
+			p.EnvironmentSize = 0
+		}
+		binary.Write(&buf, binary.LittleEndian, &p)
+		m.Write(params, buf.Bytes()[:p.Length])
+	} else {
+		m.WriteStruct(peb, &PEB64{ProcessParameters: params})
+		p := RTL_USER_PROCESS_PARAMETERS_FULL64{
+			MaximumLength:    uint32(binary.Size(RTL_USER_PROCESS_PARAMETERS_FULL64{})),
+			Length:           uint32(binary.Size(RTL_USER_PROCESS_PARAMETERS_FULL64{})),
+			ImagePathName:    str64(0),
+			CommandLine:      str64(1),
+			CurrentDirectory: CURDIR64{DosPath: str64(2)},
+			DllPath:          str64(3),
+			WindowTitle:      str64(4),
+			DesktopInfo:      str64(5),
+			ShellInfo:        str64(6),
+			Environment:      environment,
+			EnvironmentSize:  envSize,
+		}
+		if sized == false {
+			p.Length = uint32(unsafe.Offsetof(p.EnvironmentSize))
+			p.EnvironmentSize = 0
+		}
+		binary.Write(&buf, binary.LittleEndian, &p)
+		m.Write(params, buf.Bytes()[:p.Length])
+	}
+	return m, peb
+}
+
+func TestReadProcessParameters(t *testing.T) {
+	for _, pointerSize := range []int{4, 8} {
+		m, peb := newProcessImage(pointerSize, `C:\测试\a.exe`, `"C:\测试\a.exe" -x`)
+		imagePathName, commandLine, err := ReadProcessParameters(m, peb)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if imagePathName != `C:\测试\a.exe` || commandLine != `"C:\测试\a.exe" -x` {
+			t.Errorf("%v: %v, %v", pointerSize, imagePathName, commandLine)
+		}
+
+		m, peb = newProcessImage(pointerSize, ``, ``)
+		if imagePathName, commandLine, err := ReadProcessParameters(m, peb); err != nil || imagePathName != "" || commandLine != "" {
+			t.Errorf("%v: %q %q %v", pointerSize, imagePathName, commandLine, err)
+		}
+
+		if _, _, err := ReadProcessParameters(m, 0x9000); errors.Is(err, ErrMemoryUnreadable) == false {
+			t.Errorf("%v: %v!=%v", pointerSize, err, ErrMemoryUnreadable)
+		}
+	}
+}
+
+func TestReadProcessInfo(t *testing.T) {
+	want := &ProcessInfo{
+		ImagePathName:    `C:\Windows\System32\notepad.exe`,
+		CommandLine:      `notepad.exe a.txt`,
+		CurrentDirectory: `C:\测试\`,
+		DllPath:          `C:\Windows\System32`,
+		WindowTitle:      `notepad`,
+		DesktopInfo:      `Winsta0\Default`,
+		ShellInfo:        `shell`,
+		Environment: map[string]string{
+			"=C:":  `C:\测试`,
+			"Path": `C:\Windows;C:\Windows\System32`,
+			"X":    "a=b",
+			"空":    "",
+		},
+	}
+	env, err := makeEnvBlock([]string{"Path=C:\\Windows;C:\\Windows\\System32", "X=a=b", "=C:=C:\\测试", "空="})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, pointerSize := range []int{4, 8} {
+		for _, sized := range []bool{true, false} {
+			m, peb := newProcessInfoImage(pointerSize, want, env, sized)
+			info, err := ReadProcessInfo(m, peb)
+			if err != nil {
+				t.Fatalf("%v %v: %v", pointerSize, sized, err)
+			}
+			if reflect.DeepEqual(info, want) == false {
+				t.Errorf("%v %v: %#v!=%#v", pointerSize, sized, info, want)
+			}
+			if v, ok := info.Getenv("PATH"); ok == false || v != want.Environment["Path"] {
+				t.Errorf("%v %v: %v %v", pointerSize, sized, v, ok)
+			}
+			if _, ok := info.Getenv("Y"); ok {
+				t.Errorf("%v %v: Y", pointerSize, sized)
+			}
+		}
+
+		// No variables
+		m, peb := newProcessInfoImage(pointerSize, &ProcessInfo{}, []uint16{0, 0}, false)
+		if info, err := ReadProcessInfo(m, peb); err != nil || len(info.Environment) != 0 {
+			t.Errorf("%v: %v %v", pointerSize, info, err)
+		}
+
+		// The block without the double NUL ends at the unreadable page
+		m, peb = newProcessInfoImage(pointerSize, &ProcessInfo{}, nil, false)
+		m.Write(0x30000, bytes.Repeat([]byte{'a', 0}, 0x1000))
+		if _, err := ReadProcessInfo(m, peb); errors.Is(err, ErrMemoryUnreadable) == false {
+			t.Errorf("%v: %v!=%v", pointerSize, err, ErrMemoryUnreadable)
+		}
+	}
+}
+
+func TestParseEnvironmentBlock(t *testing.T) {
+	data := []struct {
+		block []uint16
+		env   map[string]string
+	}{
+		{nil, map[string]string{}},
+		{utf16.Encode([]rune("\x00\x00")), map[string]string{}},
+		{utf16.Encode([]rune("A=1\x00B=\x00\x00C=3\x00\x00")), map[string]string{"A": "1", "B": ""}},
+		// Without the terminators
+		{utf16.Encode([]rune("A=1\x00B=2")), map[string]string{"A": "1", "B": "2"}},
+		{utf16.Encode([]rune("=C:=C:\\\x00=\x00==x\x00NOVALUE\x00A==\x00")), map[string]string{"=C:": `C:\`, "=": "x", "A": "="}},
+	}
+	for _, v := range data {
+		if env := parseEnvironmentBlock(v.block); reflect.DeepEqual(env, v.env) == false {
+			t.Errorf("%q: %v!=%v", string(utf16.Decode(v.block)), env, v.env)
+		}
+	}
+}
+
+// The synthetic memory of the process with the loader list, the PEB is at 0x7000.
+func newModulesImage(pointerSize int, modules []ProcessModule) (*MemoryImage, uint64) {
+	m, peb := newProcessImage(pointerSize, "", "")
+	const ldr = 0x40000
+	entry := func(i int) uint64 {
+		if i < 0 || i == len(modules) {
+			// The head of InLoadOrderModuleList
+			if pointerSize == 4 {
+				return ldr + 0xC
+			}
+			return ldr + 0x10
+		}
+		return 0x41000 + uint64(i)*0x1000
+	}
+
+	if pointerSize == 4 {
+		m.WriteStruct(peb, &PEB32{Ldr: ldr, ProcessParameters: 0x20000})
+		m.WriteStruct(ldr, &PEB_LDR_DATA32{
+			Length:                uint32(binary.Size(PEB_LDR_DATA32{})),
+			Initialized:           1,
+			InLoadOrderModuleList: LIST_ENTRY32{uint32(entry(0)), uint32(entry(len(modules) - 1))},
+		})
+	} else {
+		m.WriteStruct(peb, &PEB64{Ldr: ldr, ProcessParameters: 0x20000})
+		m.WriteStruct(ldr, &PEB_LDR_DATA64{
+			Length:                uint32(binary.Size(PEB_LDR_DATA64{})),
+			Initialized:           1,
+			InLoadOrderModuleList: LIST_ENTRY64{entry(0), entry(len(modules) - 1)},
+		})
+	}
+
+	for i, v := range modules {
+		addr := entry(i)
+		fullLength := writeUTF16(m, addr+0x200, v.FullDllName)
+		baseLength := writeUTF16(m, addr+0x600, v.BaseDllName)
+		if pointerSize == 4 {
+			m.WriteStruct(addr, &LDR_DATA_TABLE_ENTRY32{
+				InLoadOrderLinks: LIST_ENTRY32{uint32(entry(i + 1)), uint32(entry(i - 1))},
+				DllBase:          uint32(v.BaseAddress),
+				EntryPoint:       uint32(v.EntryPoint),
+				SizeOfImage:      v.SizeOfImage,
+				FullDllName:      UNICODE_STRING32{fullLength, fullLength + 2, uint32(addr + 0x200)},
+				BaseDllName:      UNICODE_STRING32{baseLength, baseLength + 2, uint32(addr + 0x600)},
+			})
+		} else {
+			m.WriteStruct(addr, &LDR_DATA_TABLE_ENTRY64{
+				InLoadOrderLinks: LIST_ENTRY64{entry(i + 1), entry(i - 1)},
+				DllBase:          v.BaseAddress,
+				EntryPoint:       v.EntryPoint,
+				SizeOfImage:      v.SizeOfImage,
+				FullDllName:      UNICODE_STRING64{Length: fullLength, MaximumLength: fullLength + 2, Buffer: addr + 0x200},
+				BaseDllName:      UNICODE_STRING64{Length: baseLength, MaximumLength: baseLength + 2, Buffer: addr + 0x600},
+			})
+		}
+	}
+	return m, peb
+}
+
+func TestReadProcessModules(t *testing.T) {
+	modules := []ProcessModule{
+		{0x400000, 0x5000, 0x401000, `C:\测试\a.exe`, `a.exe`},
+		{0x77000000, 0x190000, 0, `C:\Windows\SYSTEM32\ntdll.dll`, `ntdll.dll`},
+		{0x76000000, 0xD0000, 0x76010000, `C:\Windows\System32\KERNEL32.DLL`, `KERNEL32.DLL`},
+	}
+	for _, pointerSize := range []int{4, 8} {
+		m, peb := newModulesImage(pointerSize, modules)
+		result, err := ReadProcessModules(m, peb)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if reflect.DeepEqual(result, modules) == false {
+			t.Errorf("%v: %v!=%v", pointerSize, result, modules)
+		}
+
+		// The empty list
+		m, peb = newModulesImage(pointerSize, nil)
+		if result, err := ReadProcessModules(m, peb); err != nil || len(result) != 0 {
+			t.Errorf("%v: %v %v", pointerSize, result, err)
+		}
+
+		// The loop which does not return to the head
+		m, peb = newModulesImage(pointerSize, modules)
+		if pointerSize == 4 {
+			m.WriteStruct(0x43000, &LIST_ENTRY32{0x42000, 0x42000})
+		} else {
+			m.WriteStruct(0x43000, &LIST_ENTRY64{0x42000, 0x42000})
+		}
+		if _, err := ReadProcessModules(m, peb); err == nil {
+			t.Errorf("%v: loop", pointerSize)
+		}
+
+		// The unreadable entry
+		m, peb = newModulesImage(pointerSize, modules)
+		if pointerSize == 4 {
+			m.WriteStruct(0x42000, &LIST_ENTRY32{0x90000, 0x41000})
+		} else {
+			m.WriteStruct(0x42000, &LIST_ENTRY64{0x90000, 0x41000})
+		}
+		if _, err := ReadProcessModules(m, peb); errors.Is(err, ErrMemoryUnreadable) == false {
+			t.Errorf("%v: %v!=%v", pointerSize, err, ErrMemoryUnreadable)
+		}
+
+		// Before the loader is initialized
+		m, peb = newProcessImage(pointerSize, "", "")
+		if _, err := ReadProcessModules(m, peb); err != ErrLoaderNotInitialized {
+			t.Errorf("%v: %v!=%v", pointerSize, err, ErrLoaderNotInitialized)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package gowindows
+
+import (
+	"fmt"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// RemoteMemory read by ReadVirtualMemory
+type processMemory struct {
+	process     Handle
+	pointerSize int
+}
+
+// The memory of the process read by ReadVirtualMemory, the process needs PROCESS_VM_READ.
+// pointerSize is 4 to read the 32-bit structures of the WoW64 process, otherwise it is the pointer size of the current program.
+func NewProcessMemory(process Handle, pointerSize int) RemoteMemory {
+	return &processMemory{process: process, pointerSize: pointerSize}
+}
+
+// The memory of the current process
+func NewSelfMemory() RemoteMemory {
+	return &processMemory{process: Handle(windows.CurrentProcess()), pointerSize: int(ptrSize)}
+}
+
+func (m *processMemory) ReadAt(addr uint64, buf []byte) error {
+	if len(buf) == 0 {
+		return nil
+	}
+	if end := addr + uint64(len(buf)); end < addr || end-1 > uint64(^uintptr(0)) {
+		return fmt.Errorf("ReadVirtualMemory(0x%x), the address is out of the address space", addr)
+	}
+	err := ReadVirtualMemory(m.process, uint(addr), windows.Pointer(unsafe.Pointer(&buf[0])), uint(len(buf)), nil)
+	if err != nil {
+		return fmt.Errorf("ReadVirtualMemory(0x%x), %w", addr, err)
+	}
+	return nil
+}
+
+func (m *processMemory) PointerSize() int {
+	return m.pointerSize
+}
+
+// RemoteMemory read by NtWow64ReadVirtualMemory64
+type wow64Memory struct {
+	process Handle
+}
+
+// The 64-bit memory of the process read by the 32-bit program on the 64-bit system, the process needs PROCESS_VM_READ.
+func NewWow64Memory(process Handle) (RemoteMemory, error) {
+	if ntWow64ReadVirtualMemory64 == nil {
+		return nil, fmt.Errorf("ntWow64ReadVirtualMemory64==nil")
+	}
+	return &wow64Memory{process: process}, nil
+}
+
+func (m *wow64Memory) ReadAt(addr uint64, buf []byte) error {
+	if len(buf) == 0 {
+		return nil
+	}
+	err := NtWow64ReadVirtualMemory64(m.process, addr, windows.Pointer(unsafe.Pointer(&buf[0])), uint64(len(buf)), nil)
+	if err != nil {
+		return fmt.Errorf("NtWow64ReadVirtualMemory64(0x%x), %w", addr, err)
+	}
+	return nil
+}
+
+func (m *wow64Memory) PointerSize() int {
+	return 8
+}
+
+// The memory of the process and the address of its PEB
+// The PEB has the pointer size of the current program, except that the 32-bit program on the 64-bit system
+// reads the 64-bit PEB by NewWow64Memory.
+func OpenProcessMemory(process Handle) (RemoteMemory, uint64, error) {
+	is64system, err := Is64System()
+	if err != nil {
+		return nil, 0, fmt.Errorf("Is64System, %w", err)
+	}
+
+	if is64system == true && ptrSize == 4 {
+		pInfo := PROCESS_BASIC_INFORMATION64{}
+		err = NtWow64QueryInformationProcess64(process, ProcessBasicInformation,
+			windows.Pointer(unsafe.Pointer(&pInfo)), uint32(unsafe.Sizeof(pInfo)), nil)
+		if err != nil {
+			return nil, 0, fmt.Errorf("NtWow64QueryInformationProcess64, %w", err)
+		}
+		m, err := NewWow64Memory(process)
+		if err != nil {
+			return nil, 0, err
+		}
+		return m, pInfo.PebBaseAddress, nil
+	}
+
+	pInfo := PROCESS_BASIC_INFORMATION{}
+	err = NtQueryInformationProcess(process, ProcessBasicInformation,
+		windows.Pointer(unsafe.Pointer(&pInfo)), uint32(unsafe.Sizeof(pInfo)), nil)
+	if err != nil {
+		return nil, 0, fmt.Errorf("NtQueryInformationProcess, %w", err)
+	}
+	return NewProcessMemory(process, int(ptrSize)), uint64(pInfo.PebBaseAddress), nil
+}
+
+// The 32-bit memory of the WoW64 process and the address of its 32-bit PEB
+// The 32-bit PEB has the 32-bit modules and the parameters of the 32-bit program,
+// OpenProcessMemory returns the 64-bit PEB of the WoW64 process to the 64-bit program.
+// ErrNotWow64Process is returned if the process is not the WoW64 process.
+func OpenWow64ProcessMemory(process Handle) (RemoteMemory, uint64, error) {
+	if ptrSize == 8 {
+		peb, err := QueryProcessWow64Information(process)
+		if err != nil {
+			return nil, 0, err
+		}
+		if peb == 0 {
+			return nil, 0, ErrNotWow64Process
+		}
+		return NewProcessMemory(process, 4), uint64(peb), nil
+	}
+
+	wow64, err := IsWow64Process(process)
+	if err != nil {
+		return nil, 0, fmt.Errorf("IsWow64Process, %w", err)
+	}
+	if wow64 == false {
+		return nil, 0, ErrNotWow64Process
+	}
+	// The 32-bit program gets the 32-bit PEB of the WoW64 process
+	pInfo := PROCESS_BASIC_INFORMATION{}
+	err = NtQueryInformationProcess(process, ProcessBasicInformation,
+		windows.Pointer(unsafe.Pointer(&pInfo)), uint32(unsafe.Sizeof(pInfo)), nil)
+	if err != nil {
+		return nil, 0, fmt.Errorf("NtQueryInformationProcess, %w", err)
+	}
+	return NewProcessMemory(process, 4), uint64(pInfo.PebBaseAddress), nil
+}
@@ -0,0 +1,50 @@
+package gowindows
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+func TestNewSelfMemory(t *testing.T) {
+	data := []byte("remote memory")
+	buf := make([]byte, len(data))
+	m := NewSelfMemory()
+	if err := m.ReadAt(uint64(uintptr(unsafe.Pointer(&data[0]))), buf); err != nil {
+		t.Fatal(err)
+	}
+	if string(buf) != string(data) {
+		t.Errorf("%s!=%s", buf, data)
+	}
+	if m.PointerSize() != int(ptrSize) {
+		t.Errorf("%v!=%v", m.PointerSize(), ptrSize)
+	}
+	if err := m.ReadAt(0, buf); err == nil {
+		t.Errorf("read 0")
+	}
+}
+
+func TestOpenProcessMemory(t *testing.T) {
+	m, peb, err := OpenProcessMemory(Handle(windows.CurrentProcess()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	imagePathName, commandLine, err := ReadProcessParameters(m, peb)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.EqualFold(imagePathName, exe) == false {
+		t.Errorf("%v!=%v", imagePathName, exe)
+	}
+	if cmdLine := windows.UTF16PtrToString(windows.GetCommandLine()); commandLine != cmdLine {
+		t.Errorf("%v!=%v", commandLine, cmdLine)
+	}
+}
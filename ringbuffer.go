@@ -0,0 +1,403 @@
+package gowindows
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"sync/atomic"
+	"time"
+	"unsafe"
+)
+
+// Ring buffer in shared memory
+// Memory layout, all integers are little endian (the native byte order of windows and linux on x86/arm):
+//
+//	0  magic        uint32 "GWRB"
+//	4  version      uint32
+//	8  capacity     uint64 size of the data area
+//	16 head         uint64 write position, only modified by the writer
+//	24 tail         uint64 read position, modified by the reader, and by the writer when dropping the oldest record
+//	32 epoch        uint64 odd while the writer is writing a record
+//	40 dropped      uint64 number of records dropped by the writer
+//	48 writer       uint32 process id of the attached writer, 0 if there is no writer
+//	52 reserved
+//	64 data area
+//
+// head and tail increase monotonically, the offset in the data area is position % capacity.
+// Each record is an 8 byte header (uint32 length + uint32 reserved) and the data padded to 8 bytes.
+// A record is never split, if the rest of the data area is not enough, a wrap marker is written and
+// the record starts at the beginning of the data area.
+//
+// There is only one writer, the reader claims a record by CAS on tail after copying it,
+// so the writer can drop the oldest record at any time without waiting for the reader.
+const (
+	RingHeaderSize = 64
+
+	ringMagic   = 0x42525747 // "GWRB"
+	ringVersion = 1
+
+	ringOffsetMagic    = 0
+	ringOffsetVersion  = 4
+	ringOffsetCapacity = 8
+	ringOffsetHead     = 16
+	ringOffsetTail     = 24
+	ringOffsetEpoch    = 32
+	ringOffsetDropped  = 40
+	ringOffsetWriter   = 48
+
+	ringRecordHeaderSize = 8
+	ringWrapMarker       = 0xFFFFFFFF
+)
+
+var (
+	ErrRingNotInitialized = errors.New("ring buffer is not initialized")
+	ErrRingVersion        = errors.New("ring buffer version mismatch")
+	ErrRingCorrupted      = errors.New("ring buffer is corrupted")
+	ErrRingTooLarge       = errors.New("record is larger than half of the ring buffer")
+	ErrRingEmpty          = errors.New("ring buffer is empty")
+	ErrRingWriterExists   = errors.New("ring buffer writer exists")
+)
+
+// What the writer does when there is not enough space
+type RingPolicy int
+
+const (
+	// Drop the oldest records to make room, Write never blocks.
+	RingPolicyDropOldest RingPolicy = iota
+	// Wait for the reader
+	RingPolicyBlock
+)
+
+// The polling interval when waiting, there is no cross process notification in the ring buffer itself.
+var RingPollInterval = time.Millisecond
+
+type ring struct {
+	mem      []byte
+	data     []byte
+	capacity uint64
+}
+
+func ringAlign(n uint64) uint64 {
+	return (n + 7) &^ 7
+}
+
+func newRing(mem []byte) (*ring, error) {
+	if len(mem) < RingHeaderSize+ringRecordHeaderSize*2 {
+		return nil, fmt.Errorf("memory size %v is too small", len(mem))
+	}
+	if uintptr(unsafe.Pointer(&mem[0]))%8 != 0 {
+		return nil, fmt.Errorf("memory is not 8 byte aligned")
+	}
+	return &ring{mem: mem}, nil
+}
+
+func (r *ring) uint64(offset int) *uint64 {
+	return (*uint64)(unsafe.Pointer(&r.mem[offset]))
+}
+
+func (r *ring) uint32(offset int) *uint32 {
+	return (*uint32)(unsafe.Pointer(&r.mem[offset]))
+}
+
+// Validate the header, and fill capacity/data
+func (r *ring) attach() error {
+	if atomic.LoadUint32(r.uint32(ringOffsetMagic)) != ringMagic {
+		return ErrRingNotInitialized
+	}
+	if v := atomic.LoadUint32(r.uint32(ringOffsetVersion)); v != ringVersion {
+		return fmt.Errorf("%w, %v != %v", ErrRingVersion, v, ringVersion)
+	}
+
+	capacity := atomic.LoadUint64(r.uint64(ringOffsetCapacity))
+	if capacity == 0 || capacity%8 != 0 || capacity > uint64(len(r.mem)-RingHeaderSize) {
+		return fmt.Errorf("%w, invalid capacity %v", ErrRingCorrupted, capacity)
+	}
+
+	r.capacity = capacity
+	r.data = r.mem[RingHeaderSize : RingHeaderSize+int(capacity)]
+	return nil
+}
+
+// Whether head and tail are consistent
+func (r *ring) check(head, tail uint64) bool {
+	return tail <= head && head-tail <= r.capacity && head%8 == 0 && tail%8 == 0
+}
+
+func (r *ring) recordLength(pos uint64) uint32 {
+	return atomic.LoadUint32((*uint32)(unsafe.Pointer(&r.data[pos%r.capacity])))
+}
+
+// Size of the record (or wrap marker) at pos, 0 if invalid
+func (r *ring) recordSize(pos uint64, l uint32) uint64 {
+	rest := r.capacity - pos%r.capacity
+	if l == ringWrapMarker {
+		return rest
+	}
+	size := ringRecordHeaderSize + ringAlign(uint64(l))
+	if size > rest {
+		return 0
+	}
+	return size
+}
+
+// Number of records dropped by the writer since the ring buffer was initialized
+func (r *ring) Dropped() uint64 {
+	return atomic.LoadUint64(r.uint64(ringOffsetDropped))
+}
+
+// Capacity of the data area
+func (r *ring) Capacity() int {
+	return int(r.capacity)
+}
+
+// Number of bytes used by the records that have not been read
+func (r *ring) Len() int {
+	head := atomic.LoadUint64(r.uint64(ringOffsetHead))
+	tail := atomic.LoadUint64(r.uint64(ringOffsetTail))
+	if !r.check(head, tail) {
+		return 0
+	}
+	return int(head - tail)
+}
+
+// Writer of the ring buffer
+// Only one writer is allowed at the same time, it is not safe for concurrent use.
+type RingWriter struct {
+	ring
+	policy    RingPolicy
+	recovered bool
+}
+
+// Attach the writer to mem, mem is initialized if it does not contain a ring buffer.
+// ErrRingWriterExists is returned if the process of another writer is still running.
+// If the previous writer exited without Close, or crashed while writing a record,
+// Recovered() returns true, and the records are discarded if head/tail are inconsistent.
+func NewRingWriter(mem []byte, policy RingPolicy) (*RingWriter, error) {
+	r, err := newRing(mem)
+	if err != nil {
+		return nil, err
+	}
+	w := &RingWriter{ring: *r, policy: policy}
+
+	if err := w.attach(); err == ErrRingNotInitialized {
+		capacity := uint64(len(mem)-RingHeaderSize) &^ 7
+		for i := range mem[:RingHeaderSize] {
+			mem[i] = 0
+		}
+		binary.LittleEndian.PutUint32(mem[ringOffsetVersion:], ringVersion)
+		binary.LittleEndian.PutUint64(mem[ringOffsetCapacity:], capacity)
+		atomic.StoreUint32(w.uint32(ringOffsetMagic), ringMagic)
+		if err := w.attach(); err != nil {
+			return nil, err
+		}
+	} else if err != nil {
+		return nil, err
+	}
+
+	writerPtr := w.uint32(ringOffsetWriter)
+	pid := uint32(os.Getpid())
+	for {
+		owner := atomic.LoadUint32(writerPtr)
+		if owner != 0 && isProcessAlive(owner) {
+			return nil, ErrRingWriterExists
+		}
+		if atomic.CompareAndSwapUint32(writerPtr, owner, pid) {
+			w.recovered = owner != 0
+			break
+		}
+	}
+	if epoch := atomic.LoadUint64(w.uint64(ringOffsetEpoch)); epoch%2 != 0 {
+		w.recovered = true
+		atomic.StoreUint64(w.uint64(ringOffsetEpoch), epoch+1)
+	}
+
+	head := atomic.LoadUint64(w.uint64(ringOffsetHead))
+	tail := atomic.LoadUint64(w.uint64(ringOffsetTail))
+	if !w.check(head, tail) {
+		w.recovered = true
+		head = ringAlign(head)
+		atomic.StoreUint64(w.uint64(ringOffsetHead), head)
+		atomic.StoreUint64(w.uint64(ringOffsetTail), head)
+	}
+
+	return w, nil
+}
+
+// Whether the previous writer did not exit normally
+func (w *RingWriter) Recovered() bool {
+	return w.recovered
+}
+
+// Write a record, the same as WriteContext with context.Background()
+func (w *RingWriter) Write(p []byte) error {
+	return w.WriteContext(context.Background(), p)
+}
+
+// Write a record
+// With RingPolicyBlock, it waits until the reader makes room or ctx is done.
+func (w *RingWriter) WriteContext(ctx context.Context, p []byte) error {
+	// In the worst case the rest of the data area is size-8 bytes and it is skipped by the wrap marker,
+	// so the record can be written at any position only if 2*size-8 <= capacity.
+	size := ringRecordHeaderSize + ringAlign(uint64(len(p)))
+	if 2*size-ringRecordHeaderSize > w.capacity || uint64(len(p)) >= ringWrapMarker {
+		return ErrRingTooLarge
+	}
+
+	headPtr := w.uint64(ringOffsetHead)
+	tailPtr := w.uint64(ringOffsetTail)
+
+	for {
+		head := atomic.LoadUint64(headPtr)
+		tail := atomic.LoadUint64(tailPtr)
+		if !w.check(head, tail) {
+			return ErrRingCorrupted
+		}
+
+		need := size
+		rest := w.capacity - head%w.capacity
+		if rest < size {
+			need += rest
+		}
+
+		if w.capacity-(head-tail) >= need {
+			w.write(head, rest, size, p)
+			return nil
+		}
+
+		if w.policy == RingPolicyBlock {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(RingPollInterval):
+			}
+			continue
+		}
+
+		// Drop the oldest record, the reader may claim it at the same time.
+		l := w.recordLength(tail)
+		recordSize := w.recordSize(tail, l)
+		if recordSize == 0 {
+			if atomic.LoadUint64(tailPtr) != tail {
+				continue
+			}
+			return ErrRingCorrupted
+		}
+		if atomic.CompareAndSwapUint64(tailPtr, tail, tail+recordSize) && l != ringWrapMarker {
+			atomic.AddUint64(w.uint64(ringOffsetDropped), 1)
+		}
+	}
+}
+
+func (w *RingWriter) write(head, rest, size uint64, p []byte) {
+	epochPtr := w.uint64(ringOffsetEpoch)
+	atomic.AddUint64(epochPtr, 1)
+
+	newHead := head
+	if rest < size {
+		atomic.StoreUint32((*uint32)(unsafe.Pointer(&w.data[head%w.capacity])), ringWrapMarker)
+		newHead += rest
+	}
+
+	offset := newHead % w.capacity
+	binary.LittleEndian.PutUint32(w.data[offset+4:], 0)
+	copy(w.data[offset+ringRecordHeaderSize:], p)
+	atomic.StoreUint32((*uint32)(unsafe.Pointer(&w.data[offset])), uint32(len(p)))
+
+	atomic.StoreUint64(w.uint64(ringOffsetHead), newHead+size)
+	atomic.AddUint64(epochPtr, 1)
+}
+
+// Detach the writer, the records are kept for the reader.
+func (w *RingWriter) Close() error {
+	atomic.CompareAndSwapUint32(w.uint32(ringOffsetWriter), uint32(os.Getpid()), 0)
+	return nil
+}
+
+// Reader of the ring buffer
+type RingReader struct {
+	ring
+}
+
+// Attach the reader to the ring buffer initialized by NewRingWriter
+func NewRingReader(mem []byte) (*RingReader, error) {
+	r, err := newRing(mem)
+	if err != nil {
+		return nil, err
+	}
+	reader := &RingReader{ring: *r}
+	if err := reader.attach(); err != nil {
+		return nil, err
+	}
+	return reader, nil
+}
+
+// Whether a writer is attached and its process is running
+func (r *RingReader) WriterAttached() bool {
+	owner := atomic.LoadUint32(r.uint32(ringOffsetWriter))
+	return owner != 0 && isProcessAlive(owner)
+}
+
+// Read a record without waiting
+// ErrRingEmpty is returned if there is no record.
+func (r *RingReader) TryRead() ([]byte, error) {
+	headPtr := r.uint64(ringOffsetHead)
+	tailPtr := r.uint64(ringOffsetTail)
+
+	for {
+		tail := atomic.LoadUint64(tailPtr)
+		head := atomic.LoadUint64(headPtr)
+		if tail == head {
+			return nil, ErrRingEmpty
+		}
+		if !r.check(head, tail) {
+			return nil, ErrRingCorrupted
+		}
+
+		l := r.recordLength(tail)
+		size := r.recordSize(tail, l)
+		if size == 0 || tail+size > head {
+			// The writer may have overwritten the record after dropping it
+			if atomic.LoadUint64(tailPtr) != tail {
+				continue
+			}
+			return nil, ErrRingCorrupted
+		}
+
+		if l == ringWrapMarker {
+			atomic.CompareAndSwapUint64(tailPtr, tail, tail+size)
+			continue
+		}
+
+		offset := tail%r.capacity + ringRecordHeaderSize
+		p := make([]byte, l)
+		copy(p, r.data[offset:])
+
+		// Fails if the writer dropped the record while copying
+		if atomic.CompareAndSwapUint64(tailPtr, tail, tail+size) {
+			return p, nil
+		}
+	}
+}
+
+// Read a record, the same as ReadContext with context.Background()
+func (r *RingReader) Read() ([]byte, error) {
+	return r.ReadContext(context.Background())
+}
+
+// Read a record, wait until a record is available or ctx is done.
+func (r *RingReader) ReadContext(ctx context.Context) ([]byte, error) {
+	for {
+		p, err := r.TryRead()
+		if err != ErrRingEmpty {
+			return p, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(RingPollInterval):
+		}
+	}
+}
@@ -0,0 +1,292 @@
+package gowindows
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"testing"
+	"time"
+)
+
+func newTestRing(t *testing.T, size int, policy RingPolicy) ([]byte, *RingWriter, *RingReader) {
+	mem := make([]byte, RingHeaderSize+size)
+	w, err := NewRingWriter(mem, policy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := NewRingReader(mem)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return mem, w, r
+}
+
+// The process id of a process that has exited
+func exitedProcessId(t *testing.T) uint32 {
+	cmd := exec.Command(os.Args[0], "-test.run=^$")
+	if err := cmd.Run(); err != nil {
+		t.Fatal(err)
+	}
+	return uint32(cmd.Process.Pid)
+}
+
+func TestRing(t *testing.T) {
+	_, w, r := newTestRing(t, 256, RingPolicyBlock)
+
+	if _, err := r.TryRead(); err != ErrRingEmpty {
+		t.Fatalf("%v!=ErrRingEmpty", err)
+	}
+
+	records := [][]byte{{}, {1}, []byte("hello"), bytes.Repeat([]byte{7}, 64)}
+	for _, p := range records {
+		if err := w.Write(p); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if v := r.Len(); v != 8+16+16+72 {
+		t.Errorf("%v!=%v", v, 8+16+16+72)
+	}
+
+	for _, p := range records {
+		v, err := r.TryRead()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if bytes.Equal(v, p) == false {
+			t.Errorf("%v!=%v", v, p)
+		}
+	}
+	if _, err := r.TryRead(); err != ErrRingEmpty {
+		t.Fatalf("%v!=ErrRingEmpty", err)
+	}
+
+	if err := w.Write(make([]byte, 121)); err != ErrRingTooLarge {
+		t.Errorf("%v!=ErrRingTooLarge", err)
+	}
+	if err := w.Write(make([]byte, 120)); err != nil {
+		t.Errorf("%v", err)
+	}
+}
+
+func TestRing_wrap(t *testing.T) {
+	_, w, r := newTestRing(t, 200, RingPolicyBlock)
+
+	for i := 0; i < 1000; i++ {
+		p := bytes.Repeat([]byte{byte(i)}, i%50)
+		if err := w.Write(p); err != nil {
+			t.Fatal(err)
+		}
+		v, err := r.TryRead()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if bytes.Equal(v, p) == false {
+			t.Fatalf("%v: %v!=%v", i, v, p)
+		}
+	}
+}
+
+func TestRing_dropOldest(t *testing.T) {
+	_, w, r := newTestRing(t, 128, RingPolicyDropOldest)
+
+	// 16 bytes per record, 8 records fit
+	for i := 0; i < 20; i++ {
+		if err := w.Write([]byte{byte(i)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if v := r.Dropped(); v != 12 {
+		t.Errorf("%v!=12", v)
+	}
+
+	for i := 12; i < 20; i++ {
+		v, err := r.TryRead()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v[0] != byte(i) {
+			t.Errorf("%v!=%v", v[0], i)
+		}
+	}
+	if _, err := r.TryRead(); err != ErrRingEmpty {
+		t.Fatalf("%v!=ErrRingEmpty", err)
+	}
+}
+
+func TestRing_block(t *testing.T) {
+	_, w, r := newTestRing(t, 128, RingPolicyBlock)
+
+	for i := 0; i < 8; i++ {
+		if err := w.Write([]byte{byte(i)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := w.WriteContext(ctx, []byte{8}); err != context.DeadlineExceeded {
+		t.Fatalf("%v!=context.DeadlineExceeded", err)
+	}
+
+	if _, err := r.TryRead(); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Write([]byte{8}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// One producer and one consumer, the records must arrive in order without loss.
+func TestRing_concurrent(t *testing.T) {
+	_, w, r := newTestRing(t, 1024, RingPolicyBlock)
+
+	const count = 10000
+	go func() {
+		for i := 0; i < count; i++ {
+			p := make([]byte, 4+i%37)
+			binary.LittleEndian.PutUint32(p, uint32(i))
+			if err := w.Write(p); err != nil {
+				panic(err)
+			}
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	for i := 0; i < count; i++ {
+		p, err := r.ReadContext(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v := binary.LittleEndian.Uint32(p); v != uint32(i) || len(p) != 4+i%37 {
+			t.Fatalf("%v!=%v", v, i)
+		}
+	}
+}
+
+func TestRing_recovery(t *testing.T) {
+	mem, w, _ := newTestRing(t, 256, RingPolicyBlock)
+	if w.Recovered() {
+		t.Error("new ring is recovered")
+	}
+	if err := w.Write([]byte("a")); err != nil {
+		t.Fatal(err)
+	}
+	w.Close()
+
+	w, err := NewRingWriter(mem, RingPolicyBlock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w.Recovered() {
+		t.Error("closed ring is recovered")
+	}
+
+	// The writer is running
+	if _, err := NewRingWriter(mem, RingPolicyBlock); err != ErrRingWriterExists {
+		t.Errorf("%v!=ErrRingWriterExists", err)
+	}
+	if w.Recovered() {
+		t.Error("ring is recovered by the second writer")
+	}
+
+	// The writer exits without Close
+	binary.LittleEndian.PutUint32(mem[ringOffsetWriter:], exitedProcessId(t))
+	w, err = NewRingWriter(mem, RingPolicyBlock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w.Recovered() == false {
+		t.Error("ring is not recovered")
+	}
+	r, err := NewRingReader(mem)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v, err := r.TryRead(); err != nil || string(v) != "a" {
+		t.Errorf("%v %v", v, err)
+	}
+	w.Close()
+
+	// Crashed while writing, with broken head
+	binary.LittleEndian.PutUint64(mem[ringOffsetEpoch:], 3)
+	binary.LittleEndian.PutUint64(mem[ringOffsetHead:], 1<<40)
+	if _, err := r.TryRead(); errors.Is(err, ErrRingCorrupted) == false {
+		t.Errorf("%v!=ErrRingCorrupted", err)
+	}
+	w, err = NewRingWriter(mem, RingPolicyBlock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w.Recovered() == false {
+		t.Error("ring is not recovered")
+	}
+	if _, err := r.TryRead(); err != ErrRingEmpty {
+		t.Errorf("%v!=ErrRingEmpty", err)
+	}
+	if err := w.Write([]byte("b")); err != nil {
+		t.Fatal(err)
+	}
+	if v, err := r.TryRead(); err != nil || string(v) != "b" {
+		t.Errorf("%v %v", v, err)
+	}
+}
+
+func TestRing_header(t *testing.T) {
+	mem := make([]byte, 1024)
+	if _, err := NewRingReader(mem); err != ErrRingNotInitialized {
+		t.Errorf("%v!=ErrRingNotInitialized", err)
+	}
+	if _, err := NewRingWriter(mem[:RingHeaderSize], RingPolicyBlock); err == nil {
+		t.Error("small memory == nil")
+	}
+	if _, err := NewRingWriter(mem[1:], RingPolicyBlock); err == nil {
+		t.Error("unaligned memory == nil")
+	}
+
+	if _, err := NewRingWriter(mem, RingPolicyBlock); err != nil {
+		t.Fatal(err)
+	}
+	binary.LittleEndian.PutUint32(mem[ringOffsetVersion:], 2)
+	if _, err := NewRingReader(mem); errors.Is(err, ErrRingVersion) == false {
+		t.Errorf("%v!=ErrRingVersion", err)
+	}
+}
+
+func TestRing_mmap(t *testing.T) {
+	name := fmt.Sprintf("gowindows_test_ring_%v", time.Now().UnixNano())
+	m1, err := CreateMmap(name, 4096, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer m1.Close()
+	m2, err := OpenMmap(name, 4096, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer m2.Close()
+
+	w, err := NewRingWriter(m1.GetBytes(), RingPolicyDropOldest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+	r, err := NewRingReader(m2.GetBytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Capacity() != 4096-RingHeaderSize || r.WriterAttached() == false {
+		t.Errorf("%v %v", r.Capacity(), r.WriterAttached())
+	}
+
+	if err := w.Write([]byte("hello")); err != nil {
+		t.Fatal(err)
+	}
+	if v, err := r.Read(); err != nil || string(v) != "hello" {
+		t.Errorf("%v %v", v, err)
+	}
+}
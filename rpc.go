@@ -0,0 +1,927 @@
+package gowindows
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+	"unsafe"
+)
+
+// Request/response RPC between processes over the named memory share
+// It is designed for the service and the tray application of the same machine, without any socket.
+// The objects of the server named name:
+//
+//	name                  Mmap, the header, the request ring and the response ring of each client slot
+//	name.request          auto reset event, set by the client after writing a request
+//	name.request.lock     mutex, the clients write the request ring one by one
+//	name.server           mutex, held by the server while it is running
+//	name.client.<slot>    mutex, held by the client owning the slot while it is connected
+//	name.response.<slot>  auto reset event, set by the server after writing a response of the slot
+//
+// Memory layout of the Mmap, all integers are little endian:
+//
+//	0  magic         uint32 "GWRP"
+//	4  version       uint32
+//	8  slots         uint32 number of client slots
+//	16 request size  uint64 size of the request ring
+//	24 response size uint64 size of the response ring of each slot
+//	32 server        uint32 process id of the server
+//	64 request ring
+//	then for each slot, 64 bytes slot header (uint32 generation) and the response ring
+//
+// The generation of the slot is increased when a client claims the slot,
+// the responses of the requests sent by the previous client are discarded.
+// The mutex of the server crashed is abandoned, the next server takes over the name and reinitializes the Mmap.
+const (
+	RpcHeaderSize = 64
+
+	rpcMagic   = 0x50525747 // "GWRP"
+	rpcVersion = 1
+
+	rpcOffsetMagic        = 0
+	rpcOffsetVersion      = 4
+	rpcOffsetSlots        = 8
+	rpcOffsetRequestSize  = 16
+	rpcOffsetResponseSize = 24
+	rpcOffsetServer       = 32
+
+	rpcSlotHeaderSize = 64
+
+	rpcDefaultSlots        = 8
+	rpcDefaultRingSize     = 64 * 1024
+	rpcDefaultTimeout      = 30 * time.Second
+	rpcPollInterval        = 100 * time.Millisecond
+	rpcMessageHeaderSize   = 18
+	rpcMessageKindRequest  = 1
+	rpcMessageKindResponse = 2
+	rpcMessageFlagError    = 1
+	rpcMessageFlagNotFound = 2
+)
+
+var (
+	ErrRpcNotInitialized  = errors.New("rpc server is not initialized")
+	ErrRpcNoSlot          = errors.New("no free rpc client slot")
+	ErrRpcClosed          = errors.New("rpc is closed")
+	ErrRpcMethodNotFound  = errors.New("rpc method not found")
+	ErrRpcInvalidMessage  = errors.New("invalid rpc message")
+	ErrRpcServerListening = errors.New("rpc server is listening")
+	ErrRpcServerDown      = errors.New("rpc server is not running")
+)
+
+// Error returned by the handler, only the message is sent to the client.
+type RpcError struct {
+	Method  string
+	Message string
+}
+
+func (e *RpcError) Error() string {
+	return fmt.Sprintf("rpc %v, %v", e.Method, e.Message)
+}
+
+// Encoding of the params and the results, the server and the clients must use the same codec.
+type RpcCodec interface {
+	Marshal(v interface{}) ([]byte, error)
+	Unmarshal(data []byte, v interface{}) error
+}
+
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (jsonCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+type gobCodec struct{}
+
+func (gobCodec) Marshal(v interface{}) ([]byte, error) {
+	buf := bytes.Buffer{}
+	if err := gob.NewEncoder(&buf).Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (gobCodec) Unmarshal(data []byte, v interface{}) error {
+	return gob.NewDecoder(bytes.NewReader(data)).Decode(v)
+}
+
+var (
+	JSONCodec RpcCodec = jsonCodec{}
+	GobCodec  RpcCodec = gobCodec{}
+)
+
+// The zero value uses the defaults
+type RpcConfig struct {
+	// JSONCodec by default
+	Codec RpcCodec
+	// Server only, the number of clients connected at the same time, 8 by default.
+	Slots int
+	// Server only, size of the request ring and the response ring of each slot, 64K by default.
+	// A message must be smaller than half of the ring.
+	RequestSize  int
+	ResponseSize int
+	// SDDL of the objects, see CreateMmapWithSecurityDescriptor.
+	SecurityDescriptor string
+	// Client: the timeout of Call if ctx has no deadline; server: the timeout of writing a response.
+	// 30s by default.
+	Timeout time.Duration
+}
+
+func (c *RpcConfig) withDefaults() RpcConfig {
+	v := RpcConfig{}
+	if c != nil {
+		v = *c
+	}
+	if v.Codec == nil {
+		v.Codec = JSONCodec
+	}
+	if v.Slots <= 0 {
+		v.Slots = rpcDefaultSlots
+	}
+	if v.RequestSize <= 0 {
+		v.RequestSize = rpcDefaultRingSize
+	}
+	if v.ResponseSize <= 0 {
+		v.ResponseSize = rpcDefaultRingSize
+	}
+	v.RequestSize = int(ringAlign(uint64(v.RequestSize)))
+	v.ResponseSize = int(ringAlign(uint64(v.ResponseSize)))
+	if v.Timeout <= 0 {
+		v.Timeout = rpcDefaultTimeout
+	}
+	return v
+}
+
+// The message in the ring
+//
+//	0  kind       uint8
+//	1  flags      uint8
+//	2  slot       uint16
+//	4  generation uint32
+//	8  id         uint64
+//	16 method     uint16 length and the bytes, only the request has the method
+//	   payload    encoded by the codec, or the error message
+type rpcMessage struct {
+	kind       byte
+	flags      byte
+	slot       uint16
+	generation uint32
+	id         uint64
+	method     string
+	payload    []byte
+}
+
+func (m *rpcMessage) marshal() []byte {
+	b := make([]byte, rpcMessageHeaderSize+len(m.method)+len(m.payload))
+	b[0] = m.kind
+	b[1] = m.flags
+	binary.LittleEndian.PutUint16(b[2:], m.slot)
+	binary.LittleEndian.PutUint32(b[4:], m.generation)
+	binary.LittleEndian.PutUint64(b[8:], m.id)
+	binary.LittleEndian.PutUint16(b[16:], uint16(len(m.method)))
+	copy(b[rpcMessageHeaderSize:], m.method)
+	copy(b[rpcMessageHeaderSize+len(m.method):], m.payload)
+	return b
+}
+
+func unmarshalRpcMessage(b []byte) (*rpcMessage, error) {
+	if len(b) < rpcMessageHeaderSize {
+		return nil, ErrRpcInvalidMessage
+	}
+	l := int(binary.LittleEndian.Uint16(b[16:]))
+	if len(b) < rpcMessageHeaderSize+l {
+		return nil, ErrRpcInvalidMessage
+	}
+	return &rpcMessage{
+		kind:       b[0],
+		flags:      b[1],
+		slot:       binary.LittleEndian.Uint16(b[2:]),
+		generation: binary.LittleEndian.Uint32(b[4:]),
+		id:         binary.LittleEndian.Uint64(b[8:]),
+		method:     string(b[rpcMessageHeaderSize : rpcMessageHeaderSize+l]),
+		payload:    b[rpcMessageHeaderSize+l:],
+	}, nil
+}
+
+// Layout of the Mmap
+type rpcLayout struct {
+	slots        int
+	requestSize  int
+	responseSize int
+}
+
+func (l rpcLayout) size() int64 {
+	return int64(RpcHeaderSize+l.requestSize) + int64(l.slots)*int64(rpcSlotHeaderSize+l.responseSize)
+}
+
+func (l rpcLayout) request(mem []byte) []byte {
+	return mem[RpcHeaderSize : RpcHeaderSize+l.requestSize]
+}
+
+func (l rpcLayout) slotOffset(slot int) int {
+	return RpcHeaderSize + l.requestSize + slot*(rpcSlotHeaderSize+l.responseSize)
+}
+
+func (l rpcLayout) generation(mem []byte, slot int) *uint32 {
+	return (*uint32)(unsafe.Pointer(&mem[l.slotOffset(slot)]))
+}
+
+func (l rpcLayout) response(mem []byte, slot int) []byte {
+	offset := l.slotOffset(slot) + rpcSlotHeaderSize
+	return mem[offset : offset+l.responseSize]
+}
+
+func readRpcLayout(mem []byte) (rpcLayout, error) {
+	if len(mem) < RpcHeaderSize || atomic.LoadUint32((*uint32)(unsafe.Pointer(&mem[rpcOffsetMagic]))) != rpcMagic {
+		return rpcLayout{}, ErrRpcNotInitialized
+	}
+	if v := binary.LittleEndian.Uint32(mem[rpcOffsetVersion:]); v != rpcVersion {
+		return rpcLayout{}, fmt.Errorf("rpc version %v != %v", v, rpcVersion)
+	}
+	return rpcLayout{
+		slots:        int(binary.LittleEndian.Uint32(mem[rpcOffsetSlots:])),
+		requestSize:  int(binary.LittleEndian.Uint64(mem[rpcOffsetRequestSize:])),
+		responseSize: int(binary.LittleEndian.Uint64(mem[rpcOffsetResponseSize:])),
+	}, nil
+}
+
+// The context is also canceled when done is closed
+func withDone(ctx context.Context, done <-chan struct{}) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(ctx)
+	go func() {
+		select {
+		case <-done:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	return ctx, cancel
+}
+
+func rpcSlotName(name, kind string, slot int) string {
+	return name + "." + kind + "." + strconv.Itoa(slot)
+}
+
+// Handler of a method, decode decodes the params.
+// The result is encoded by the codec, the error is sent to the client as RpcError.
+type RpcHandler func(ctx context.Context, decode func(v interface{}) error) (interface{}, error)
+
+type RpcServer struct {
+	config   RpcConfig
+	layout   rpcLayout
+	m        *Mmap
+	request  *RingReader
+	requestL *NamedMutex
+	requestE *NamedEvent
+
+	writers   []*RingWriter
+	writersMu []sync.Mutex
+	responseE []*NamedEvent
+
+	handlersMu sync.RWMutex
+	handlers   map[string]RpcHandler
+
+	// The owner mutex is held by a goroutine until release, since the mutex of windows belongs to the thread.
+	owner     chan struct{}
+	ownerDone chan struct{}
+	// Whether the header is initialized by this server
+	listening bool
+	closeOnce sync.Once
+	// Close waits for Serve by the write lock
+	closeMu sync.RWMutex
+	done    chan struct{}
+	wg      sync.WaitGroup
+}
+
+// Create the server, only one server is allowed for the name.
+// The Mmap left by a crashed server is reinitialized, the clients of it get ErrRpcServerDown.
+func NewRpcServer(name string, config *RpcConfig) (_ *RpcServer, rerr error) {
+	if name == "" {
+		return nil, fmt.Errorf("name is empty")
+	}
+
+	c := config.withDefaults()
+	if c.Slots > 0xFFFF {
+		return nil, fmt.Errorf("too many slots %v", c.Slots)
+	}
+	l := rpcLayout{slots: c.Slots, requestSize: c.RequestSize, responseSize: c.ResponseSize}
+
+	s := &RpcServer{
+		config:    c,
+		layout:    l,
+		writers:   make([]*RingWriter, l.slots),
+		writersMu: make([]sync.Mutex, l.slots),
+		responseE: make([]*NamedEvent, l.slots),
+		handlers:  make(map[string]RpcHandler),
+		done:      make(chan struct{}),
+	}
+	defer func() {
+		if rerr != nil {
+			s.release()
+		}
+	}()
+
+	m, err := CreateMmap64(name, l.size(), true, c.SecurityDescriptor)
+	if err != nil {
+		return nil, fmt.Errorf("CreateMmap64, %w", err)
+	}
+	s.m = m
+	mem := m.GetBytes()
+
+	if err := s.own(name); err != nil {
+		return nil, err
+	}
+
+	atomic.StoreUint32((*uint32)(unsafe.Pointer(&mem[rpcOffsetMagic])), 0)
+	binary.LittleEndian.PutUint32(mem[rpcOffsetVersion:], rpcVersion)
+	binary.LittleEndian.PutUint32(mem[rpcOffsetSlots:], uint32(l.slots))
+	binary.LittleEndian.PutUint64(mem[rpcOffsetRequestSize:], uint64(l.requestSize))
+	binary.LittleEndian.PutUint64(mem[rpcOffsetResponseSize:], uint64(l.responseSize))
+	binary.LittleEndian.PutUint32(mem[rpcOffsetServer:], uint32(os.Getpid()))
+
+	if s.requestL, err = CreateNamedMutex(name+".request.lock", c.SecurityDescriptor); err != nil {
+		return nil, err
+	}
+	// A client of the crashed server may hold the mutex until its timeout
+	ctx, cancel := context.WithTimeout(context.Background(), c.Timeout)
+	err = s.resetRequest(ctx)
+	cancel()
+	if err != nil {
+		return nil, err
+	}
+	if s.request, err = NewRingReader(l.request(mem)); err != nil {
+		return nil, err
+	}
+
+	for i := 0; i < l.slots; i++ {
+		// The responses left by the crashed server are discarded
+		resetRing(l.response(mem, i))
+		if s.writers[i], err = NewRingWriter(l.response(mem, i), RingPolicyBlock); err != nil {
+			return nil, err
+		}
+		if s.responseE[i], err = CreateNamedEvent(rpcSlotName(name, "response", i), false, false, c.SecurityDescriptor); err != nil {
+			return nil, err
+		}
+	}
+
+	if s.requestE, err = CreateNamedEvent(name+".request", false, false, c.SecurityDescriptor); err != nil {
+		return nil, err
+	}
+
+	atomic.StoreUint32((*uint32)(unsafe.Pointer(&mem[rpcOffsetMagic])), rpcMagic)
+	s.listening = true
+	return s, nil
+}
+
+// Hold the owner mutex of the name, ErrRpcServerListening is returned if another server holds it.
+func (s *RpcServer) own(name string) error {
+	mu, err := CreateNamedMutex(name+".server", s.config.SecurityDescriptor)
+	if err != nil {
+		return err
+	}
+
+	result := make(chan error, 1)
+	owner := make(chan struct{})
+	ownerDone := make(chan struct{})
+	go func() {
+		defer close(ownerDone)
+		defer mu.Close()
+
+		// The mutex abandoned by the crashed server is acquired too
+		ok, err := mu.TryLock()
+		if ok == false {
+			if err == nil {
+				err = fmt.Errorf("%w, %v", ErrRpcServerListening, name)
+			}
+			result <- err
+			return
+		}
+		result <- nil
+		<-owner
+		mu.Unlock()
+	}()
+
+	if err := <-result; err != nil {
+		<-ownerDone
+		return err
+	}
+	s.owner = owner
+	s.ownerDone = ownerDone
+	return nil
+}
+
+// Reinitialize the ring, the records in it are discarded.
+func resetRing(mem []byte) {
+	atomic.StoreUint32((*uint32)(unsafe.Pointer(&mem[ringOffsetMagic])), 0)
+}
+
+// Reinitialize the request ring while holding the request mutex, the requests in it are discarded.
+func (s *RpcServer) resetRequest(ctx context.Context) error {
+	if err := s.requestL.Lock(ctx); err != nil && errors.Is(err, ErrAbandoned) == false {
+		return err
+	}
+	defer s.requestL.Unlock()
+
+	mem := s.layout.request(s.m.GetBytes())
+	resetRing(mem)
+	w, err := NewRingWriter(mem, RingPolicyBlock)
+	if err != nil {
+		return err
+	}
+	return w.Close()
+}
+
+// Register the handler of the method, it replaces the previous one.
+func (s *RpcServer) Handle(method string, handler RpcHandler) {
+	s.handlersMu.Lock()
+	defer s.handlersMu.Unlock()
+	if handler == nil {
+		delete(s.handlers, method)
+		return
+	}
+	s.handlers[method] = handler
+}
+
+// Serve the requests until ctx is done or the server is closed
+// Each request is handled in a new goroutine.
+// The request ring is reinitialized if it is corrupted, the requests in it are discarded.
+func (s *RpcServer) Serve(ctx context.Context) error {
+	s.closeMu.RLock()
+	defer s.closeMu.RUnlock()
+
+	waitCtx, cancel := withDone(ctx, s.done)
+	defer cancel()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-s.done:
+			return ErrRpcClosed
+		default:
+		}
+
+		p, err := s.request.TryRead()
+		if err == ErrRingEmpty {
+			if err := s.requestE.Wait(waitCtx); err != nil && waitCtx.Err() == nil {
+				return err
+			}
+			continue
+		}
+		if errors.Is(err, ErrRingCorrupted) {
+			if err := s.resetRequest(waitCtx); err != nil && waitCtx.Err() == nil {
+				return err
+			}
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		msg, err := unmarshalRpcMessage(p)
+		if err != nil || msg.kind != rpcMessageKindRequest || int(msg.slot) >= s.layout.slots {
+			continue
+		}
+
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.serve(ctx, msg)
+		}()
+	}
+}
+
+func (s *RpcServer) serve(ctx context.Context, req *rpcMessage) {
+	resp := rpcMessage{kind: rpcMessageKindResponse, slot: req.slot, generation: req.generation, id: req.id}
+
+	s.handlersMu.RLock()
+	handler := s.handlers[req.method]
+	s.handlersMu.RUnlock()
+
+	if handler == nil {
+		resp.flags = rpcMessageFlagNotFound
+	} else {
+		decode := func(v interface{}) error {
+			if len(req.payload) == 0 {
+				return nil
+			}
+			return s.config.Codec.Unmarshal(req.payload, v)
+		}
+
+		result, err := handler(ctx, decode)
+		if err == nil && result != nil {
+			resp.payload, err = s.config.Codec.Marshal(result)
+		}
+		if err != nil {
+			resp.flags = rpcMessageFlagError
+			resp.payload = []byte(err.Error())
+		}
+	}
+
+	s.respond(&resp)
+}
+
+// The response is discarded if the client does not read it in time.
+func (s *RpcServer) respond(resp *rpcMessage) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.config.Timeout)
+	defer cancel()
+
+	slot := int(resp.slot)
+	s.writersMu[slot].Lock()
+	err := s.writers[slot].WriteContext(ctx, resp.marshal())
+	s.writersMu[slot].Unlock()
+
+	if err == ErrRingTooLarge {
+		resp.flags = rpcMessageFlagError
+		resp.payload = []byte(fmt.Sprintf("response is too large, %v", err))
+		s.writersMu[slot].Lock()
+		err = s.writers[slot].WriteContext(ctx, resp.marshal())
+		s.writersMu[slot].Unlock()
+	}
+	if err == nil {
+		s.responseE[slot].Set()
+	}
+}
+
+func (s *RpcServer) release() {
+	for i, w := range s.writers {
+		if w != nil {
+			w.Close()
+		}
+		if s.responseE[i] != nil {
+			s.responseE[i].Close()
+		}
+	}
+	if s.requestE != nil {
+		s.requestE.Close()
+	}
+	if s.requestL != nil {
+		s.requestL.Close()
+	}
+	if s.m != nil {
+		// The clients find out the server is gone
+		if s.listening {
+			atomic.StoreUint32((*uint32)(unsafe.Pointer(&s.m.GetBytes()[rpcOffsetMagic])), 0)
+		}
+		s.m.Close()
+	}
+	// The next server can take over after the Mmap is released
+	if s.owner != nil {
+		close(s.owner)
+		<-s.ownerDone
+	}
+}
+
+// Stop Serve and wait for the running handlers
+func (s *RpcServer) Close() error {
+	s.closeOnce.Do(func() {
+		close(s.done)
+		s.closeMu.Lock()
+		s.wg.Wait()
+		s.release()
+		s.closeMu.Unlock()
+	})
+	return nil
+}
+
+type RpcClient struct {
+	// The first word is 64-bit aligned for the atomic operations of 32-bit
+	id uint64
+
+	config     RpcConfig
+	layout     rpcLayout
+	slot       int
+	generation uint32
+	server     uint32
+	m          *Mmap
+
+	requestMu sync.Mutex
+	requestL  *NamedMutex
+	requestE  *NamedEvent
+	response  *RingReader
+	responseE *NamedEvent
+
+	pendingMu sync.Mutex
+	pending   map[uint64]chan *rpcMessage
+
+	closeOnce sync.Once
+	// Close waits for Call by the write lock
+	closeMu sync.RWMutex
+	done    chan struct{}
+	wg      sync.WaitGroup
+}
+
+// Connect to the server, a free client slot is claimed until Close.
+func DialRpc(name string, config *RpcConfig) (_ *RpcClient, rerr error) {
+	if name == "" {
+		return nil, fmt.Errorf("name is empty")
+	}
+
+	c := &RpcClient{
+		config:  config.withDefaults(),
+		slot:    -1,
+		pending: make(map[uint64]chan *rpcMessage),
+		done:    make(chan struct{}),
+	}
+	defer func() {
+		if rerr != nil {
+			c.Close()
+		}
+	}()
+
+	header, err := OpenMmap64(name, 0, RpcHeaderSize, false)
+	if err != nil {
+		return nil, fmt.Errorf("OpenMmap64, %w", err)
+	}
+	c.layout, err = readRpcLayout(header.GetBytes())
+	header.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	if c.m, err = OpenMmap64(name, 0, c.layout.size(), true); err != nil {
+		return nil, fmt.Errorf("OpenMmap64, %w", err)
+	}
+	mem := c.m.GetBytes()
+	c.server = atomic.LoadUint32((*uint32)(unsafe.Pointer(&mem[rpcOffsetServer])))
+
+	if c.requestL, err = CreateNamedMutex(name+".request.lock", c.config.SecurityDescriptor); err != nil {
+		return nil, err
+	}
+	if c.requestE, err = CreateNamedEvent(name+".request", false, false, c.config.SecurityDescriptor); err != nil {
+		return nil, err
+	}
+
+	if err := c.claim(name); err != nil {
+		return nil, err
+	}
+	c.generation = atomic.AddUint32(c.layout.generation(mem, c.slot), 1)
+
+	if c.responseE, err = CreateNamedEvent(rpcSlotName(name, "response", c.slot), false, false, c.config.SecurityDescriptor); err != nil {
+		return nil, err
+	}
+	if c.response, err = NewRingReader(c.layout.response(mem, c.slot)); err != nil {
+		return nil, err
+	}
+	// The responses left by the previous client
+	for {
+		if _, err := c.response.TryRead(); err != nil {
+			break
+		}
+	}
+
+	c.wg.Add(1)
+	go c.receive()
+
+	return c, nil
+}
+
+// Hold the mutex of a free slot in a goroutine, since the mutex of windows belongs to the thread.
+func (c *RpcClient) claim(name string) error {
+	result := make(chan error, 1)
+
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+
+		for i := 0; i < c.layout.slots; i++ {
+			mu, err := CreateNamedMutex(rpcSlotName(name, "client", i), c.config.SecurityDescriptor)
+			if err != nil {
+				result <- err
+				return
+			}
+			// The slot abandoned by the crashed client is claimed too
+			ok, err := mu.TryLock()
+			if ok == false {
+				mu.Close()
+				if err != nil {
+					result <- err
+					return
+				}
+				continue
+			}
+
+			c.slot = i
+			result <- nil
+			<-c.done
+			mu.Unlock()
+			mu.Close()
+			return
+		}
+		result <- ErrRpcNoSlot
+	}()
+
+	return <-result
+}
+
+// Whether the server the client connected to is still running
+// ErrRpcNotInitialized is returned if the server is closed, ErrRpcServerDown if it crashed or is replaced.
+func (c *RpcClient) checkServer() error {
+	mem := c.m.GetBytes()
+	if _, err := readRpcLayout(mem); err != nil {
+		return err
+	}
+	server := atomic.LoadUint32((*uint32)(unsafe.Pointer(&mem[rpcOffsetServer])))
+	if server != c.server || isProcessAlive(server) == false {
+		return ErrRpcServerDown
+	}
+	return nil
+}
+
+// Run f while holding the request mutex
+func (c *RpcClient) lockRequest(ctx context.Context, f func() error) error {
+	c.requestMu.Lock()
+	defer c.requestMu.Unlock()
+
+	lockCtx, cancel := withDone(ctx, c.done)
+	defer cancel()
+	// The ring is consistent even if the previous owner crashed while writing
+	if err := c.requestL.Lock(lockCtx); err != nil && errors.Is(err, ErrAbandoned) == false {
+		if ctx.Err() == nil && lockCtx.Err() != nil {
+			return ErrRpcClosed
+		}
+		return err
+	}
+	defer c.requestL.Unlock()
+
+	return f()
+}
+
+// Dispatch the responses to the pending calls
+func (c *RpcClient) receive() {
+	defer c.wg.Done()
+
+	ctx, cancel := withDone(context.Background(), c.done)
+	defer cancel()
+
+	for {
+		select {
+		case <-c.done:
+			return
+		default:
+		}
+
+		p, err := c.response.TryRead()
+		if err == ErrRingEmpty {
+			c.responseE.Wait(ctx)
+			continue
+		}
+		if err != nil {
+			// The ring is reset by the server
+			time.Sleep(rpcPollInterval)
+			continue
+		}
+
+		msg, err := unmarshalRpcMessage(p)
+		if err != nil || msg.kind != rpcMessageKindResponse || msg.generation != c.generation {
+			continue
+		}
+
+		c.pendingMu.Lock()
+		ch := c.pending[msg.id]
+		delete(c.pending, msg.id)
+		c.pendingMu.Unlock()
+
+		if ch != nil {
+			ch <- msg
+		}
+	}
+}
+
+// Call the method of the server
+// params is encoded by the codec, nil means no params; the result is decoded into reply if it is not nil.
+// Without the deadline of ctx, the timeout of the config is used.
+// ErrRpcServerDown is returned if the server crashed or is replaced before the response.
+func (c *RpcClient) Call(ctx context.Context, method string, params interface{}, reply interface{}) error {
+	if len(method) > 0xFFFF {
+		return fmt.Errorf("method is too long")
+	}
+
+	c.closeMu.RLock()
+	defer c.closeMu.RUnlock()
+	select {
+	case <-c.done:
+		return ErrRpcClosed
+	default:
+	}
+	if _, ok := ctx.Deadline(); ok == false {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.config.Timeout)
+		defer cancel()
+	}
+
+	req := rpcMessage{
+		kind:       rpcMessageKindRequest,
+		slot:       uint16(c.slot),
+		generation: c.generation,
+		id:         atomic.AddUint64(&c.id, 1),
+		method:     method,
+	}
+	if params != nil {
+		var err error
+		if req.payload, err = c.config.Codec.Marshal(params); err != nil {
+			return fmt.Errorf("marshal params, %w", err)
+		}
+	}
+
+	ch := make(chan *rpcMessage, 1)
+	c.pendingMu.Lock()
+	c.pending[req.id] = ch
+	c.pendingMu.Unlock()
+	defer func() {
+		c.pendingMu.Lock()
+		delete(c.pending, req.id)
+		c.pendingMu.Unlock()
+	}()
+
+	err := c.lockRequest(ctx, func() error {
+		if err := c.checkServer(); err != nil {
+			return err
+		}
+		// The ring allows one writer, the clients attach to it one by one.
+		w, err := NewRingWriter(c.layout.request(c.m.GetBytes()), RingPolicyBlock)
+		if err != nil {
+			return err
+		}
+		defer w.Close()
+		return w.WriteContext(ctx, req.marshal())
+	})
+	if err != nil {
+		return err
+	}
+	if err := c.requestE.Set(); err != nil {
+		return err
+	}
+
+	// The server is checked while waiting, the response never comes if it crashed.
+	ticker := time.NewTicker(rpcPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-c.done:
+			return ErrRpcClosed
+		case <-ticker.C:
+			if err := c.checkServer(); err != nil {
+				return err
+			}
+		case resp := <-ch:
+			switch {
+			case resp.flags&rpcMessageFlagNotFound != 0:
+				return fmt.Errorf("%w, %v", ErrRpcMethodNotFound, method)
+			case resp.flags&rpcMessageFlagError != 0:
+				return &RpcError{Method: method, Message: string(resp.payload)}
+			case reply != nil && len(resp.payload) != 0:
+				if err := c.config.Codec.Unmarshal(resp.payload, reply); err != nil {
+					return fmt.Errorf("unmarshal reply, %w", err)
+				}
+			}
+			return nil
+		}
+	}
+}
+
+// The claimed slot
+func (c *RpcClient) Slot() int {
+	return c.slot
+}
+
+func (c *RpcClient) release() {
+	if c.responseE != nil {
+		c.responseE.Close()
+	}
+	if c.requestE != nil {
+		c.requestE.Close()
+	}
+	if c.requestL != nil {
+		c.requestL.Close()
+	}
+	if c.m != nil {
+		c.m.Close()
+	}
+}
+
+// Release the slot, the pending calls return ErrRpcClosed.
+func (c *RpcClient) Close() error {
+	c.closeOnce.Do(func() {
+		close(c.done)
+		c.closeMu.Lock()
+		c.wg.Wait()
+		c.release()
+		c.closeMu.Unlock()
+	})
+	return nil
+}
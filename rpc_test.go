@@ -0,0 +1,335 @@
+package gowindows
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"sync"
+	"testing"
+	"time"
+)
+
+type rpcTestArgs struct {
+	A, B int
+}
+
+func startRpcTestServer(t *testing.T, name string, config *RpcConfig) (*RpcServer, func()) {
+	s, err := NewRpcServer(name, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s.Handle("add", func(ctx context.Context, decode func(v interface{}) error) (interface{}, error) {
+		args := rpcTestArgs{}
+		if err := decode(&args); err != nil {
+			return nil, err
+		}
+		return args.A + args.B, nil
+	})
+	s.Handle("fail", func(ctx context.Context, decode func(v interface{}) error) (interface{}, error) {
+		return nil, fmt.Errorf("failed")
+	})
+	s.Handle("sleep", func(ctx context.Context, decode func(v interface{}) error) (interface{}, error) {
+		time.Sleep(200 * time.Millisecond)
+		return nil, nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- s.Serve(ctx)
+	}()
+
+	return s, func() {
+		cancel()
+		<-done
+		if err := s.Close(); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestRpcMessage(t *testing.T) {
+	m := rpcMessage{kind: rpcMessageKindRequest, flags: 3, slot: 7, generation: 9, id: 1 << 40, method: "add", payload: []byte("{}")}
+	v, err := unmarshalRpcMessage(m.marshal())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(*v) != fmt.Sprint(m) {
+		t.Errorf("%v!=%v", *v, m)
+	}
+
+	if _, err := unmarshalRpcMessage(m.marshal()[:rpcMessageHeaderSize+1]); err != ErrRpcInvalidMessage {
+		t.Errorf("%v!=%v", err, ErrRpcInvalidMessage)
+	}
+}
+
+func TestRpc(t *testing.T) {
+	for _, codec := range []RpcCodec{JSONCodec, GobCodec} {
+		config := &RpcConfig{Codec: codec, Slots: 2, RequestSize: 4096, ResponseSize: 4096}
+		_, stop := startRpcTestServer(t, "gowindows_test_rpc", config)
+
+		c, err := DialRpc("gowindows_test_rpc", config)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		sum := 0
+		if err := c.Call(context.Background(), "add", rpcTestArgs{1, 2}, &sum); err != nil {
+			t.Error(err)
+		} else if sum != 3 {
+			t.Errorf("%v!=3", sum)
+		}
+
+		err = c.Call(context.Background(), "fail", nil, nil)
+		if e, ok := err.(*RpcError); ok == false || e.Method != "fail" || e.Message != "failed" {
+			t.Errorf("%#v", err)
+		}
+
+		if err := c.Call(context.Background(), "none", nil, nil); errors.Is(err, ErrRpcMethodNotFound) == false {
+			t.Errorf("%v!=%v", err, ErrRpcMethodNotFound)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		if err := c.Call(ctx, "sleep", nil, nil); err != context.DeadlineExceeded {
+			t.Errorf("%v!=%v", err, context.DeadlineExceeded)
+		}
+		cancel()
+
+		// The late response of sleep is discarded
+		if err := c.Call(context.Background(), "add", rpcTestArgs{3, 4}, &sum); err != nil {
+			t.Error(err)
+		} else if sum != 7 {
+			t.Errorf("%v!=7", sum)
+		}
+
+		// The message must be smaller than half of the ring
+		if err := c.Call(context.Background(), "add", make([]byte, 4096), nil); err != ErrRingTooLarge {
+			t.Errorf("%v!=%v", err, ErrRingTooLarge)
+		}
+
+		c.Close()
+		stop()
+	}
+}
+
+func TestRpc_clients(t *testing.T) {
+	config := &RpcConfig{Slots: 2}
+	s, stop := startRpcTestServer(t, "gowindows_test_rpc_clients", config)
+	defer stop()
+
+	if _, err := NewRpcServer("gowindows_test_rpc_clients", config); errors.Is(err, ErrRpcServerListening) == false {
+		t.Errorf("%v!=%v", err, ErrRpcServerListening)
+	}
+
+	c1, err := DialRpc("gowindows_test_rpc_clients", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c2, err := DialRpc("gowindows_test_rpc_clients", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c1.Slot() == c2.Slot() {
+		t.Errorf("slot %v==%v", c1.Slot(), c2.Slot())
+	}
+
+	if _, err := DialRpc("gowindows_test_rpc_clients", nil); err != ErrRpcNoSlot {
+		t.Errorf("%v!=%v", err, ErrRpcNoSlot)
+	}
+
+	wg := sync.WaitGroup{}
+	for _, c := range []*RpcClient{c1, c2} {
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func(c *RpcClient, i int) {
+				defer wg.Done()
+				sum := 0
+				if err := c.Call(context.Background(), "add", rpcTestArgs{i, c.Slot()}, &sum); err != nil {
+					t.Error(err)
+				} else if sum != i+c.Slot() {
+					t.Errorf("%v!=%v", sum, i+c.Slot())
+				}
+			}(c, i)
+		}
+	}
+	wg.Wait()
+
+	// The slot is reused after Close
+	c1.Close()
+	c3, err := DialRpc("gowindows_test_rpc_clients", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c3.Close()
+	sum := 0
+	if err := c3.Call(context.Background(), "add", rpcTestArgs{1, 1}, &sum); err != nil || sum != 2 {
+		t.Errorf("%v %v", sum, err)
+	}
+
+	// The client finds out the server is closed
+	s.Close()
+	if err := c2.Call(context.Background(), "add", rpcTestArgs{1, 1}, &sum); err != ErrRpcNotInitialized {
+		t.Errorf("%v!=%v", err, ErrRpcNotInitialized)
+	}
+	c2.Close()
+}
+
+func TestDialRpc_noServer(t *testing.T) {
+	if _, err := DialRpc("gowindows_test_rpc_none", nil); err == nil {
+		t.Error("DialRpc == nil")
+	}
+}
+
+// The client of TestRpc_process
+func TestRpcHelperProcess(t *testing.T) {
+	name := os.Getenv("GOWINDOWS_RPC_HELPER")
+	if name == "" {
+		t.Skip("helper process")
+	}
+
+	c, err := DialRpc(name, &RpcConfig{Codec: GobCodec})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	sum := 0
+	if err := c.Call(context.Background(), "add", rpcTestArgs{os.Getpid(), 1}, &sum); err != nil {
+		t.Fatal(err)
+	}
+	if sum != os.Getpid()+1 {
+		t.Fatalf("%v!=%v", sum, os.Getpid()+1)
+	}
+}
+
+func TestRpc_process(t *testing.T) {
+	if os.Getenv("GOWINDOWS_RPC_HELPER") != "" {
+		t.Skip("helper process")
+	}
+
+	_, stop := startRpcTestServer(t, "gowindows_test_rpc_process", &RpcConfig{Codec: GobCodec})
+	defer stop()
+
+	cmds := make([]*exec.Cmd, 3)
+	for i := range cmds {
+		cmds[i] = exec.Command(os.Args[0], "-test.run=^TestRpcHelperProcess$", "-test.count=1")
+		cmds[i].Env = append(os.Environ(), "GOWINDOWS_RPC_HELPER=gowindows_test_rpc_process")
+		if err := cmds[i].Start(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, cmd := range cmds {
+		if err := cmd.Wait(); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestRpc_corruptedRequest(t *testing.T) {
+	s, stop := startRpcTestServer(t, "gowindows_test_rpc_corrupted", nil)
+	defer stop()
+
+	c, err := DialRpc("gowindows_test_rpc_corrupted", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	// A record longer than the rest of the ring
+	mem := s.layout.request(s.m.GetBytes())
+	binary.LittleEndian.PutUint32(mem[RingHeaderSize:], 1<<20)
+	binary.LittleEndian.PutUint64(mem[ringOffsetHead:], ringRecordHeaderSize)
+	if err := s.requestE.Set(); err != nil {
+		t.Fatal(err)
+	}
+
+	// The server resets the ring and keeps serving
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+		sum := 0
+		err := c.Call(ctx, "add", rpcTestArgs{1, 2}, &sum)
+		cancel()
+		if err == nil && sum == 3 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("%v %v", sum, err)
+		}
+	}
+}
+
+// The server of TestRpc_crashedServer, it is killed by the test.
+func TestRpcServerHelperProcess(t *testing.T) {
+	name := os.Getenv("GOWINDOWS_RPC_SERVER_HELPER")
+	if name == "" {
+		t.Skip("helper process")
+	}
+
+	startRpcTestServer(t, name, nil)
+	time.Sleep(time.Hour)
+}
+
+func TestRpc_crashedServer(t *testing.T) {
+	if os.Getenv("GOWINDOWS_RPC_SERVER_HELPER") != "" {
+		t.Skip("helper process")
+	}
+	const name = "gowindows_test_rpc_crashed"
+
+	cmd := exec.Command(os.Args[0], "-test.run=^TestRpcServerHelperProcess$", "-test.count=1")
+	cmd.Env = append(os.Environ(), "GOWINDOWS_RPC_SERVER_HELPER="+name)
+	if err := cmd.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer cmd.Process.Kill()
+
+	var c *RpcClient
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		var err error
+		if c, err = DialRpc(name, nil); err == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal(err)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	defer c.Close()
+
+	sum := 0
+	if err := c.Call(context.Background(), "add", rpcTestArgs{1, 2}, &sum); err != nil || sum != 3 {
+		t.Fatalf("%v %v", sum, err)
+	}
+
+	cmd.Process.Kill()
+	cmd.Wait()
+
+	// The call does not wait for the timeout
+	start := time.Now()
+	if err := c.Call(context.Background(), "add", rpcTestArgs{1, 2}, &sum); err != ErrRpcServerDown {
+		t.Errorf("%v!=%v", err, ErrRpcServerDown)
+	}
+	if d := time.Since(start); d > 5*time.Second {
+		t.Errorf("call to the crashed server takes %v", d)
+	}
+
+	// The client keeps the Mmap, the next server takes over
+	_, stop := startRpcTestServer(t, name, nil)
+	defer stop()
+	c2, err := DialRpc(name, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c2.Close()
+	if err := c2.Call(context.Background(), "add", rpcTestArgs{3, 4}, &sum); err != nil || sum != 7 {
+		t.Errorf("%v %v", sum, err)
+	}
+	if err := c.Call(context.Background(), "add", rpcTestArgs{1, 2}, &sum); err != ErrRpcServerDown {
+		t.Errorf("%v!=%v", err, ErrRpcServerDown)
+	}
+}
@@ -1,7 +1,6 @@
 package gowindows
 
 import (
-	"syscall"
 	"unsafe"
 
 	"golang.org/x/sys/windows"
@@ -16,13 +15,10 @@ var (
 //UUID *Uuid
 //);
 func UuidCreate(v *GUID) error {
-	r1, _, e1 := uuidCreate.Call(uintptr(unsafe.Pointer(v)))
+	r1, _, _ := uuidCreate.Call(uintptr(unsafe.Pointer(v)))
 	if r1 != RPC_S_OK {
-		if e1 != ERROR_SUCCESS {
-			return e1
-		} else {
-			return syscall.EINVAL
-		}
+		// RPC_STATUS is the win32 error
+		return newErrnoCallError(rpcrt4.Name, uuidCreate.Name, r1)
 	}
 	return nil
 }
@@ -0,0 +1,508 @@
+package gowindows
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Security Descriptor String Format
+// https://docs.microsoft.com/en-us/windows/win32/secauthz/security-descriptor-string-format
+// The conditional expression of the callback ace and the resource attribute are not supported,
+// their ApplicationData is not included in the SDDL string.
+
+// SID strings
+// https://docs.microsoft.com/en-us/windows/win32/secauthz/sid-strings
+var sddlAliasToSid = map[string]string{
+	"AA": "S-1-5-32-579",
+	"AC": "S-1-15-2-1",
+	"AN": "S-1-5-7",
+	"AO": "S-1-5-32-548",
+	"AS": "S-1-18-1",
+	"AU": "S-1-5-11",
+	"BA": "S-1-5-32-544",
+	"BG": "S-1-5-32-546",
+	"BO": "S-1-5-32-551",
+	"BU": "S-1-5-32-545",
+	"CG": "S-1-3-1",
+	"CO": "S-1-3-0",
+	"CY": "S-1-5-32-569",
+	"ED": "S-1-5-9",
+	"ER": "S-1-5-32-573",
+	"ES": "S-1-5-32-576",
+	"HA": "S-1-5-32-578",
+	"HI": "S-1-16-12288",
+	"IS": "S-1-5-32-568",
+	"IU": "S-1-5-4",
+	"LS": "S-1-5-19",
+	"LU": "S-1-5-32-559",
+	"LW": "S-1-16-4096",
+	"ME": "S-1-16-8192",
+	"MP": "S-1-16-8448",
+	"MS": "S-1-5-32-577",
+	"MU": "S-1-5-32-558",
+	"NO": "S-1-5-32-556",
+	"NS": "S-1-5-20",
+	"NU": "S-1-5-2",
+	"OW": "S-1-3-4",
+	"PS": "S-1-5-10",
+	"PU": "S-1-5-32-547",
+	"RA": "S-1-5-32-575",
+	"RC": "S-1-5-12",
+	"RD": "S-1-5-32-555",
+	"RE": "S-1-5-32-552",
+	"RM": "S-1-5-32-580",
+	"RU": "S-1-5-32-554",
+	"SI": "S-1-16-16384",
+	"SO": "S-1-5-32-549",
+	"SS": "S-1-18-2",
+	"SU": "S-1-5-6",
+	"SY": "S-1-5-18",
+	"WD": "S-1-1-0",
+	"WR": "S-1-5-33",
+}
+
+var sddlSidToAlias = func() map[string]string {
+	m := make(map[string]string, len(sddlAliasToSid))
+	for k, v := range sddlAliasToSid {
+		m[v] = k
+	}
+	return m
+}()
+
+// ACE strings
+// https://docs.microsoft.com/en-us/windows/win32/secauthz/ace-strings
+var sddlAceTypes = []struct {
+	name string
+	t    byte
+}{
+	{"A", ACCESS_ALLOWED_ACE_TYPE},
+	{"D", ACCESS_DENIED_ACE_TYPE},
+	{"AU", SYSTEM_AUDIT_ACE_TYPE},
+	{"AL", SYSTEM_ALARM_ACE_TYPE},
+	{"OA", ACCESS_ALLOWED_OBJECT_ACE_TYPE},
+	{"OD", ACCESS_DENIED_OBJECT_ACE_TYPE},
+	{"OU", SYSTEM_AUDIT_OBJECT_ACE_TYPE},
+	{"OL", SYSTEM_ALARM_OBJECT_ACE_TYPE},
+	{"XA", ACCESS_ALLOWED_CALLBACK_ACE_TYPE},
+	{"XD", ACCESS_DENIED_CALLBACK_ACE_TYPE},
+	{"ZA", ACCESS_ALLOWED_CALLBACK_OBJECT_ACE_TYPE},
+	{"XU", SYSTEM_AUDIT_CALLBACK_ACE_TYPE},
+	{"ML", SYSTEM_MANDATORY_LABEL_ACE_TYPE},
+	{"RA", SYSTEM_RESOURCE_ATTRIBUTE_ACE_TYPE},
+	{"SP", SYSTEM_SCOPED_POLICY_ID_ACE_TYPE},
+	{"TL", SYSTEM_PROCESS_TRUST_LABEL_ACE_TYPE},
+}
+
+var sddlAceFlags = []struct {
+	name string
+	f    byte
+}{
+	{"OI", OBJECT_INHERIT_ACE},
+	{"CI", CONTAINER_INHERIT_ACE},
+	{"NP", NO_PROPAGATE_INHERIT_ACE},
+	{"IO", INHERIT_ONLY_ACE},
+	{"ID", INHERITED_ACE},
+	{"SA", SUCCESSFUL_ACCESS_ACE_FLAG},
+	{"FA", FAILED_ACCESS_ACE_FLAG},
+}
+
+// The composite rights are only used when the mask matches exactly.
+var sddlCompositeRights = []struct {
+	name string
+	mask uint32
+}{
+	{"FA", 0x1F01FF},
+	{"FR", 0x120089},
+	{"FW", 0x120116},
+	{"FX", 0x1200A0},
+	{"KA", 0xF003F},
+	{"KR", 0x20019},
+	{"KW", 0x20006},
+	{"KX", 0x20019},
+}
+
+var sddlRights = []struct {
+	name string
+	mask uint32
+}{
+	{"GA", GENERIC_ALL},
+	{"GR", GENERIC_READ},
+	{"GW", GENERIC_WRITE},
+	{"GX", GENERIC_EXECUTE},
+	{"CC", 0x1},
+	{"DC", 0x2},
+	{"LC", 0x4},
+	{"SW", 0x8},
+	{"RP", 0x10},
+	{"WP", 0x20},
+	{"DT", 0x40},
+	{"LO", 0x80},
+	{"CR", 0x100},
+	{"SD", DELETE},
+	{"RC", READ_CONTROL},
+	{"WD", WRITE_DAC},
+	{"WO", WRITE_OWNER},
+}
+
+var sddlMandatoryLabelRights = []struct {
+	name string
+	mask uint32
+}{
+	{"NR", uint32(SYSTEM_MANDATORY_LABEL_NO_READ_UP)},
+	{"NW", uint32(SYSTEM_MANDATORY_LABEL_NO_WRITE_UP)},
+	{"NX", uint32(SYSTEM_MANDATORY_LABEL_NO_EXECUTE_UP)},
+}
+
+func sddlRightsString(aceType byte, mask uint32) string {
+	if mask == 0 {
+		return ""
+	}
+
+	rights := sddlRights
+	if aceType == SYSTEM_MANDATORY_LABEL_ACE_TYPE {
+		rights = sddlMandatoryLabelRights
+	} else {
+		for _, v := range sddlCompositeRights {
+			if v.mask == mask {
+				return v.name
+			}
+		}
+	}
+
+	var b strings.Builder
+	rest := mask
+	for _, v := range rights {
+		if rest&v.mask != 0 {
+			b.WriteString(v.name)
+			rest &^= v.mask
+		}
+	}
+	if rest != 0 {
+		return fmt.Sprintf("0x%x", mask)
+	}
+	return b.String()
+}
+
+func parseSddlRights(aceType byte, s string) (uint32, error) {
+	if s == "" {
+		return 0, nil
+	}
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		v, err := strconv.ParseUint(s[2:], 16, 32)
+		if err != nil {
+			return 0, fmt.Errorf("invalid rights %q, %v", s, err)
+		}
+		return uint32(v), nil
+	}
+	if len(s)%2 != 0 {
+		return 0, fmt.Errorf("invalid rights %q", s)
+	}
+
+	var mask uint32
+next:
+	for i := 0; i < len(s); i += 2 {
+		name := strings.ToUpper(s[i : i+2])
+		if aceType == SYSTEM_MANDATORY_LABEL_ACE_TYPE {
+			for _, v := range sddlMandatoryLabelRights {
+				if v.name == name {
+					mask |= v.mask
+					continue next
+				}
+			}
+		}
+		for _, v := range sddlCompositeRights {
+			if v.name == name {
+				mask |= v.mask
+				continue next
+			}
+		}
+		for _, v := range sddlRights {
+			if v.name == name {
+				mask |= v.mask
+				continue next
+			}
+		}
+		return 0, fmt.Errorf("unknown right %q", name)
+	}
+	return mask, nil
+}
+
+// (ace_type;ace_flags;rights;object_guid;inherit_object_guid;account_sid)
+func (a *AceInfo) SDDL() string {
+	var b strings.Builder
+	b.WriteString("(")
+
+	typeName := ""
+	for _, v := range sddlAceTypes {
+		if v.t == a.Type {
+			typeName = v.name
+			break
+		}
+	}
+	if typeName == "" {
+		typeName = fmt.Sprintf("0x%x", a.Type)
+	}
+	b.WriteString(typeName)
+	b.WriteString(";")
+
+	rest := a.Flags
+	for _, v := range sddlAceFlags {
+		if rest&v.f != 0 {
+			b.WriteString(v.name)
+			rest &^= v.f
+		}
+	}
+	b.WriteString(";")
+
+	b.WriteString(sddlRightsString(a.Type, a.Mask))
+	b.WriteString(";")
+
+	if a.ObjectType != nil {
+		b.WriteString(GUIDToString(*a.ObjectType))
+	}
+	b.WriteString(";")
+
+	if a.InheritedObjectType != nil {
+		b.WriteString(GUIDToString(*a.InheritedObjectType))
+	}
+	b.WriteString(";")
+
+	if a.Sid != nil {
+		b.WriteString(a.Sid.SDDL())
+	}
+	b.WriteString(")")
+	return b.String()
+}
+
+func parseSddlAce(s string) (*AceInfo, error) {
+	fields := strings.Split(s, ";")
+	if len(fields) < 6 {
+		return nil, fmt.Errorf("invalid ace %q", s)
+	}
+
+	ace := new(AceInfo)
+
+	typeName := strings.ToUpper(fields[0])
+	found := false
+	for _, v := range sddlAceTypes {
+		if v.name == typeName {
+			ace.Type = v.t
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("unknown ace type %q", fields[0])
+	}
+
+	flags := strings.ToUpper(fields[1])
+	if len(flags)%2 != 0 {
+		return nil, fmt.Errorf("invalid ace flags %q", fields[1])
+	}
+next:
+	for i := 0; i < len(flags); i += 2 {
+		for _, v := range sddlAceFlags {
+			if v.name == flags[i:i+2] {
+				ace.Flags |= v.f
+				continue next
+			}
+		}
+		return nil, fmt.Errorf("unknown ace flag %q", flags[i:i+2])
+	}
+
+	var err error
+	if ace.Mask, err = parseSddlRights(ace.Type, fields[2]); err != nil {
+		return nil, err
+	}
+
+	if fields[3] != "" {
+		g, err := GUIDFormString(fields[3])
+		if err != nil {
+			return nil, fmt.Errorf("invalid object guid %q, %v", fields[3], err)
+		}
+		ace.ObjectType = &g
+	}
+	if fields[4] != "" {
+		g, err := GUIDFormString(fields[4])
+		if err != nil {
+			return nil, fmt.Errorf("invalid inherit object guid %q, %v", fields[4], err)
+		}
+		ace.InheritedObjectType = &g
+	}
+
+	if ace.Sid, err = ParseSid(fields[5]); err != nil {
+		return nil, err
+	}
+	return ace, nil
+}
+
+func sddlAclFlags(control SecurityDescriptorControl, protected, autoInherited, autoInheritReq SecurityDescriptorControl) string {
+	s := ""
+	if control&protected != 0 {
+		s += "P"
+	}
+	if control&autoInheritReq != 0 {
+		s += "AR"
+	}
+	if control&autoInherited != 0 {
+		s += "AI"
+	}
+	return s
+}
+
+// Format as SDDL, the same as ConvertSecurityDescriptorToStringSecurityDescriptor, but without the windows api.
+func (sd *SecurityDescriptorInfo) SDDL() string {
+	var b strings.Builder
+	if sd.Owner != nil {
+		b.WriteString("O:")
+		b.WriteString(sd.Owner.SDDL())
+	}
+	if sd.Group != nil {
+		b.WriteString("G:")
+		b.WriteString(sd.Group.SDDL())
+	}
+	if sd.Control&SE_DACL_PRESENT != 0 || sd.Dacl != nil {
+		b.WriteString("D:")
+		b.WriteString(sddlAclFlags(sd.Control, SE_DACL_PROTECTED, SE_DACL_AUTO_INHERITED, SE_DACL_AUTO_INHERIT_REQ))
+		if sd.Dacl == nil {
+			b.WriteString("NO_ACCESS_CONTROL")
+		} else {
+			for i := range sd.Dacl.Aces {
+				b.WriteString(sd.Dacl.Aces[i].SDDL())
+			}
+		}
+	}
+	if sd.Control&SE_SACL_PRESENT != 0 || sd.Sacl != nil {
+		b.WriteString("S:")
+		b.WriteString(sddlAclFlags(sd.Control, SE_SACL_PROTECTED, SE_SACL_AUTO_INHERITED, SE_SACL_AUTO_INHERIT_REQ))
+		if sd.Sacl == nil {
+			b.WriteString("NO_ACCESS_CONTROL")
+		} else {
+			for i := range sd.Sacl.Aces {
+				b.WriteString(sd.Sacl.Aces[i].SDDL())
+			}
+		}
+	}
+	return b.String()
+}
+
+// Parse "P", "AI", "AR", "NO_ACCESS_CONTROL" and the ace list
+func parseSddlAcl(s string, protected, autoInherited, autoInheritReq SecurityDescriptorControl) (*AclInfo, SecurityDescriptorControl, error) {
+	var control SecurityDescriptorControl
+
+	nullAcl := false
+	for len(s) != 0 && s[0] != '(' {
+		switch {
+		case strings.HasPrefix(s, "NO_ACCESS_CONTROL"):
+			nullAcl = true
+			s = s[len("NO_ACCESS_CONTROL"):]
+		case strings.HasPrefix(s, "P"):
+			control |= protected
+			s = s[1:]
+		case strings.HasPrefix(s, "AI"):
+			control |= autoInherited
+			s = s[2:]
+		case strings.HasPrefix(s, "AR"):
+			control |= autoInheritReq
+			s = s[2:]
+		default:
+			return nil, 0, fmt.Errorf("invalid acl flags %q", s)
+		}
+	}
+
+	if nullAcl {
+		if len(s) != 0 {
+			return nil, 0, fmt.Errorf("NO_ACCESS_CONTROL with aces")
+		}
+		return nil, control, nil
+	}
+
+	acl := &AclInfo{}
+	for len(s) != 0 {
+		if s[0] != '(' {
+			return nil, 0, fmt.Errorf("invalid ace list %q", s)
+		}
+		end := strings.IndexByte(s, ')')
+		if end == -1 {
+			return nil, 0, fmt.Errorf("unterminated ace %q", s)
+		}
+		ace, err := parseSddlAce(s[1:end])
+		if err != nil {
+			return nil, 0, err
+		}
+		acl.Aces = append(acl.Aces, *ace)
+		s = s[end+1:]
+	}
+	return acl, control, nil
+}
+
+// Parse the SDDL string, the same as ConvertStringSecurityDescriptorToSecurityDescriptor, but without the windows api.
+// The result can be encoded to the binary self-relative security descriptor by Bytes().
+func ParseSDDL(s string) (*SecurityDescriptorInfo, error) {
+	s = strings.Join(strings.Fields(s), "")
+
+	// Split into O: G: D: S: components
+	parts := make(map[byte]string)
+	for len(s) != 0 {
+		if len(s) < 2 || s[1] != ':' {
+			return nil, fmt.Errorf("invalid sddl component %q", s)
+		}
+		tag := s[0]
+		if _, ok := parts[tag]; ok {
+			return nil, fmt.Errorf("duplicate sddl component %c", tag)
+		}
+		s = s[2:]
+
+		// the next component starts with X: outside of the parentheses
+		end := len(s)
+		depth := 0
+		for i := 0; i < len(s); i++ {
+			switch s[i] {
+			case '(':
+				depth++
+			case ')':
+				depth--
+			case ':':
+				if depth == 0 && i > 0 {
+					end = i - 1
+				}
+			}
+			if end != len(s) {
+				break
+			}
+		}
+		parts[tag] = s[:end]
+		s = s[end:]
+	}
+
+	sd := &SecurityDescriptorInfo{Revision: SECURITY_DESCRIPTOR_REVISION, Control: SE_SELF_RELATIVE}
+	var err error
+	for tag, v := range parts {
+		switch tag {
+		case 'O':
+			if sd.Owner, err = ParseSid(v); err != nil {
+				return nil, fmt.Errorf("owner, %v", err)
+			}
+		case 'G':
+			if sd.Group, err = ParseSid(v); err != nil {
+				return nil, fmt.Errorf("group, %v", err)
+			}
+		case 'D':
+			var control SecurityDescriptorControl
+			if sd.Dacl, control, err = parseSddlAcl(v, SE_DACL_PROTECTED, SE_DACL_AUTO_INHERITED, SE_DACL_AUTO_INHERIT_REQ); err != nil {
+				return nil, fmt.Errorf("dacl, %v", err)
+			}
+			sd.Control |= control | SE_DACL_PRESENT
+		case 'S':
+			var control SecurityDescriptorControl
+			if sd.Sacl, control, err = parseSddlAcl(v, SE_SACL_PROTECTED, SE_SACL_AUTO_INHERITED, SE_SACL_AUTO_INHERIT_REQ); err != nil {
+				return nil, fmt.Errorf("sacl, %v", err)
+			}
+			sd.Control |= control | SE_SACL_PRESENT
+		default:
+			return nil, fmt.Errorf("unknown sddl component %c", tag)
+		}
+	}
+	return sd, nil
+}
@@ -1 +1,139 @@
 package gowindows
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strings"
+)
+
+func (l IntegrityLevel) String() string {
+	switch l {
+	case SECURITY_MANDATORY_UNTRUSTED_RID:
+		return "Untrusted"
+	case SECURITY_MANDATORY_LOW_RID:
+		return "Low"
+	case SECURITY_MANDATORY_MEDIUM_RID:
+		return "Medium"
+	case SECURITY_MANDATORY_MEDIUM_PLUS_RID:
+		return "MediumPlus"
+	case SECURITY_MANDATORY_HIGH_RID:
+		return "High"
+	case SECURITY_MANDATORY_SYSTEM_RID:
+		return "System"
+	case SECURITY_MANDATORY_PROTECTED_PROCESS_RID:
+		return "Protected"
+	default:
+		return fmt.Sprintf("0x%X", uint32(l))
+	}
+}
+
+func (p MandatoryPolicy) String() string {
+	var ss []string
+	if p&SYSTEM_MANDATORY_LABEL_NO_WRITE_UP != 0 {
+		ss = append(ss, "NO_WRITE_UP")
+	}
+	if p&SYSTEM_MANDATORY_LABEL_NO_READ_UP != 0 {
+		ss = append(ss, "NO_READ_UP")
+	}
+	if p&SYSTEM_MANDATORY_LABEL_NO_EXECUTE_UP != 0 {
+		ss = append(ss, "NO_EXECUTE_UP")
+	}
+	if other := p &^ SYSTEM_MANDATORY_LABEL_VALID_MASK; other != 0 {
+		ss = append(ss, fmt.Sprintf("0x%X", uint32(other)))
+	}
+	if len(ss) == 0 {
+		return "0"
+	}
+	return strings.Join(ss, "|")
+}
+
+// Size of ACL header, ACE_HEADER+ACCESS_MASK, and the mandatory label SID (one sub authority).
+const (
+	aclHeaderSize          = 8
+	aceHeaderAndMaskSize   = 8
+	mandatoryLabelSidSize  = 12
+	mandatoryLabelAceSize  = aceHeaderAndMaskSize + mandatoryLabelSidSize
+	mandatoryLabelSaclSize = aclHeaderSize + mandatoryLabelAceSize
+)
+
+// Build a SACL containing only one SYSTEM_MANDATORY_LABEL_ACE
+// The result is the binary ACL structure, which can be passed to SetSecurityInfo as pSacl,
+// the purpose is to replace the conversion of fixed SDDL strings such as LOW_INTEGRITY_SDDL_SACL_W.
+func NewMandatoryLabelSacl(level IntegrityLevel, policy MandatoryPolicy) ([]byte, error) {
+	if policy&^SYSTEM_MANDATORY_LABEL_VALID_MASK != 0 {
+		return nil, fmt.Errorf("invalid mandatory policy %v", policy)
+	}
+
+	b := make([]byte, mandatoryLabelSaclSize)
+
+	// ACL
+	b[0] = ACL_REVISION
+	binary.LittleEndian.PutUint16(b[2:], mandatoryLabelSaclSize)
+	binary.LittleEndian.PutUint16(b[4:], 1)
+
+	// ACE_HEADER
+	ace := b[aclHeaderSize:]
+	ace[0] = SYSTEM_MANDATORY_LABEL_ACE_TYPE
+	ace[1] = 0
+	binary.LittleEndian.PutUint16(ace[2:], mandatoryLabelAceSize)
+	binary.LittleEndian.PutUint32(ace[4:], uint32(policy))
+
+	// SID S-1-16-RID
+	sid := ace[aceHeaderAndMaskSize:]
+	sid[0] = 1
+	sid[1] = 1
+	copy(sid[2:8], SECURITY_MANDATORY_LABEL_AUTHORITY[:])
+	binary.LittleEndian.PutUint32(sid[8:], uint32(level))
+
+	return b, nil
+}
+
+// Find the mandatory label in the binary SACL
+// found is false if the SACL does not contain SYSTEM_MANDATORY_LABEL_ACE.
+func ParseMandatoryLabelSacl(acl []byte) (level IntegrityLevel, policy MandatoryPolicy, found bool, err error) {
+	if len(acl) < aclHeaderSize {
+		return 0, 0, false, fmt.Errorf("acl size %v < %v", len(acl), aclHeaderSize)
+	}
+
+	aclSize := int(binary.LittleEndian.Uint16(acl[2:]))
+	aceCount := int(binary.LittleEndian.Uint16(acl[4:]))
+	if aclSize < aclHeaderSize || aclSize > len(acl) {
+		return 0, 0, false, fmt.Errorf("invalid AclSize %v", aclSize)
+	}
+	acl = acl[:aclSize]
+
+	offset := aclHeaderSize
+	for i := 0; i < aceCount; i++ {
+		if offset+4 > len(acl) {
+			return 0, 0, false, fmt.Errorf("ace %v out of range", i)
+		}
+
+		aceType := acl[offset]
+		aceSize := int(binary.LittleEndian.Uint16(acl[offset+2:]))
+		if aceSize < 4 || offset+aceSize > len(acl) {
+			return 0, 0, false, fmt.Errorf("ace %v invalid AceSize %v", i, aceSize)
+		}
+
+		if aceType == SYSTEM_MANDATORY_LABEL_ACE_TYPE {
+			ace := acl[offset : offset+aceSize]
+			if len(ace) < mandatoryLabelAceSize {
+				return 0, 0, false, fmt.Errorf("mandatory label ace size %v < %v", len(ace), mandatoryLabelAceSize)
+			}
+			sid, _, err := ParseSidBytes(ace[aceHeaderAndMaskSize:])
+			if err != nil {
+				return 0, 0, false, err
+			}
+			level, ok := sid.mandatoryLevel()
+			if ok == false {
+				return 0, 0, false, fmt.Errorf("mandatory label ace does not contain a mandatory label sid")
+			}
+
+			policy = MandatoryPolicy(binary.LittleEndian.Uint32(ace[4:]))
+			return level, policy, true, nil
+		}
+
+		offset += aceSize
+	}
+
+	return 0, 0, false, nil
+}
@@ -0,0 +1,579 @@
+package gowindows
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// The go form of the security descriptor, SID, ACL and ACE
+// It is decoded from the binary self-relative security descriptor returned by GetSecurityInfo,
+// so it does not depend on the windows api and can be used on all platforms.
+
+// SID
+// https://docs.microsoft.com/en-us/windows/win32/api/winnt/ns-winnt-sid
+type SidInfo struct {
+	Revision            byte
+	IdentifierAuthority [6]byte
+	SubAuthority        []uint32
+}
+
+// ACE, only the common fields are decoded, the rest is retained in ApplicationData.
+// https://docs.microsoft.com/en-us/windows/win32/api/winnt/ns-winnt-ace_header
+type AceInfo struct {
+	Type  byte
+	Flags byte
+	Mask  uint32
+
+	// Only valid for object ace types (ACCESS_ALLOWED_OBJECT_ACE_TYPE ...)
+	ObjectType          *GUID
+	InheritedObjectType *GUID
+
+	Sid *SidInfo
+
+	// The data after the SID, such as the condition of callback ace.
+	ApplicationData []byte
+}
+
+// ACL
+// https://docs.microsoft.com/en-us/windows/win32/api/winnt/ns-winnt-acl
+type AclInfo struct {
+	Revision byte
+	Aces     []AceInfo
+}
+
+// Self-relative security descriptor
+// Dacl == nil && Control&SE_DACL_PRESENT != 0 means NULL DACL (everyone has full access).
+// https://docs.microsoft.com/en-us/windows/win32/api/winnt/ns-winnt-security_descriptor
+type SecurityDescriptorInfo struct {
+	Revision byte
+	Control  SecurityDescriptorControl
+	Owner    *SidInfo
+	Group    *SidInfo
+	Dacl     *AclInfo
+	Sacl     *AclInfo
+}
+
+const securityDescriptorRelativeSize = 20
+
+func (s *SidInfo) authority() uint64 {
+	var v uint64
+	for _, b := range s.IdentifierAuthority {
+		v = v<<8 | uint64(b)
+	}
+	return v
+}
+
+// S-R-I-S-S...
+func (s *SidInfo) String() string {
+	var b strings.Builder
+	b.WriteString("S-")
+	b.WriteString(strconv.Itoa(int(s.Revision)))
+	b.WriteString("-")
+	if a := s.authority(); a >= 1<<32 {
+		fmt.Fprintf(&b, "0x%012X", a)
+	} else {
+		b.WriteString(strconv.FormatUint(a, 10))
+	}
+	for _, v := range s.SubAuthority {
+		b.WriteString("-")
+		b.WriteString(strconv.FormatUint(uint64(v), 10))
+	}
+	return b.String()
+}
+
+func (s *SidInfo) Equal(o *SidInfo) bool {
+	if s == nil || o == nil {
+		return s == o
+	}
+	if s.Revision != o.Revision || s.IdentifierAuthority != o.IdentifierAuthority || len(s.SubAuthority) != len(o.SubAuthority) {
+		return false
+	}
+	for i := range s.SubAuthority {
+		if s.SubAuthority[i] != o.SubAuthority[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// The SDDL form of the SID, prefer the abbreviation such as "BA"
+func (s *SidInfo) SDDL() string {
+	str := s.String()
+	if alias, ok := sddlSidToAlias[str]; ok {
+		return alias
+	}
+	return str
+}
+
+func (s *SidInfo) Bytes() []byte {
+	b := make([]byte, 8+4*len(s.SubAuthority))
+	b[0] = s.Revision
+	b[1] = byte(len(s.SubAuthority))
+	copy(b[2:8], s.IdentifierAuthority[:])
+	for i, v := range s.SubAuthority {
+		binary.LittleEndian.PutUint32(b[8+4*i:], v)
+	}
+	return b
+}
+
+// Parse the string SID, supports "S-1-5-32-544" and SDDL abbreviations such as "BA".
+func ParseSid(s string) (*SidInfo, error) {
+	if v, ok := sddlAliasToSid[strings.ToUpper(s)]; ok {
+		s = v
+	}
+
+	ss := strings.Split(s, "-")
+	if len(ss) < 3 || (ss[0] != "S" && ss[0] != "s") {
+		return nil, fmt.Errorf("invalid sid %q", s)
+	}
+
+	revision, err := strconv.ParseUint(ss[1], 10, 8)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sid %q revision, %v", s, err)
+	}
+
+	authority, err := strconv.ParseUint(ss[2], 0, 48)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sid %q authority, %v", s, err)
+	}
+
+	if len(ss)-3 > 15 {
+		return nil, fmt.Errorf("invalid sid %q, too many sub authorities", s)
+	}
+
+	sid := &SidInfo{Revision: byte(revision)}
+	for i := 5; i >= 0; i-- {
+		sid.IdentifierAuthority[i] = byte(authority)
+		authority >>= 8
+	}
+	for _, v := range ss[3:] {
+		sub, err := strconv.ParseUint(v, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid sid %q sub authority, %v", s, err)
+		}
+		sid.SubAuthority = append(sid.SubAuthority, uint32(sub))
+	}
+	return sid, nil
+}
+
+// The integrity level of the mandatory label SID S-1-16-RID, the RID is the last subauthority.
+func (s *SidInfo) mandatoryLevel() (IntegrityLevel, bool) {
+	if s.IdentifierAuthority != SECURITY_MANDATORY_LABEL_AUTHORITY || len(s.SubAuthority) == 0 {
+		return 0, false
+	}
+	return IntegrityLevel(s.SubAuthority[len(s.SubAuthority)-1]), true
+}
+
+// Decode binary SID
+// Returns the number of bytes used.
+func ParseSidBytes(b []byte) (*SidInfo, int, error) {
+	if len(b) < 8 {
+		return nil, 0, fmt.Errorf("sid size %v < 8", len(b))
+	}
+	count := int(b[1])
+	size := 8 + 4*count
+	if len(b) < size {
+		return nil, 0, fmt.Errorf("sid size %v < %v", len(b), size)
+	}
+
+	sid := &SidInfo{Revision: b[0], SubAuthority: make([]uint32, count)}
+	copy(sid.IdentifierAuthority[:], b[2:8])
+	for i := 0; i < count; i++ {
+		sid.SubAuthority[i] = binary.LittleEndian.Uint32(b[8+4*i:])
+	}
+	return sid, size, nil
+}
+
+func isObjectAceType(t byte) bool {
+	switch t {
+	case ACCESS_ALLOWED_OBJECT_ACE_TYPE, ACCESS_DENIED_OBJECT_ACE_TYPE, SYSTEM_AUDIT_OBJECT_ACE_TYPE, SYSTEM_ALARM_OBJECT_ACE_TYPE,
+		ACCESS_ALLOWED_CALLBACK_OBJECT_ACE_TYPE, ACCESS_DENIED_CALLBACK_OBJECT_ACE_TYPE,
+		SYSTEM_AUDIT_CALLBACK_OBJECT_ACE_TYPE, SYSTEM_ALARM_CALLBACK_OBJECT_ACE_TYPE:
+		return true
+	}
+	return false
+}
+
+func guidBytes(g *GUID) []byte {
+	b := make([]byte, 16)
+	binary.LittleEndian.PutUint32(b, g.Data1)
+	binary.LittleEndian.PutUint16(b[4:], g.Data2)
+	binary.LittleEndian.PutUint16(b[6:], g.Data3)
+	copy(b[8:], g.Data4[:])
+	return b
+}
+
+func guidFromBytes(b []byte) *GUID {
+	g := new(GUID)
+	g.Data1 = binary.LittleEndian.Uint32(b)
+	g.Data2 = binary.LittleEndian.Uint16(b[4:])
+	g.Data3 = binary.LittleEndian.Uint16(b[6:])
+	copy(g.Data4[:], b[8:16])
+	return g
+}
+
+func (a *AceInfo) Bytes() []byte {
+	b := make([]byte, 8, 64)
+	b[0] = a.Type
+	b[1] = a.Flags
+	binary.LittleEndian.PutUint32(b[4:], a.Mask)
+
+	if isObjectAceType(a.Type) {
+		var flags uint32
+		if a.ObjectType != nil {
+			flags |= ACE_OBJECT_TYPE_PRESENT
+		}
+		if a.InheritedObjectType != nil {
+			flags |= ACE_INHERITED_OBJECT_TYPE_PRESENT
+		}
+		b = append(b, 0, 0, 0, 0)
+		binary.LittleEndian.PutUint32(b[8:], flags)
+		if a.ObjectType != nil {
+			b = append(b, guidBytes(a.ObjectType)...)
+		}
+		if a.InheritedObjectType != nil {
+			b = append(b, guidBytes(a.InheritedObjectType)...)
+		}
+	}
+
+	if a.Sid != nil {
+		b = append(b, a.Sid.Bytes()...)
+	}
+	b = append(b, a.ApplicationData...)
+
+	// AceSize must be a multiple of DWORD
+	for len(b)%4 != 0 {
+		b = append(b, 0)
+	}
+	binary.LittleEndian.PutUint16(b[2:], uint16(len(b)))
+	return b
+}
+
+func parseAce(b []byte) (*AceInfo, error) {
+	if len(b) < 8 {
+		return nil, fmt.Errorf("ace size %v < 8", len(b))
+	}
+
+	ace := &AceInfo{
+		Type:  b[0],
+		Flags: b[1],
+		Mask:  binary.LittleEndian.Uint32(b[4:]),
+	}
+
+	if ace.Type == ACCESS_ALLOWED_COMPOUND_ACE_TYPE {
+		// Not used by the system, keep the raw data
+		ace.ApplicationData = append([]byte(nil), b[8:]...)
+		return ace, nil
+	}
+
+	rest := b[8:]
+	if isObjectAceType(ace.Type) {
+		if len(rest) < 4 {
+			return nil, fmt.Errorf("object ace too short")
+		}
+		flags := binary.LittleEndian.Uint32(rest)
+		rest = rest[4:]
+		if flags&ACE_OBJECT_TYPE_PRESENT != 0 {
+			if len(rest) < 16 {
+				return nil, fmt.Errorf("object ace too short")
+			}
+			ace.ObjectType = guidFromBytes(rest)
+			rest = rest[16:]
+		}
+		if flags&ACE_INHERITED_OBJECT_TYPE_PRESENT != 0 {
+			if len(rest) < 16 {
+				return nil, fmt.Errorf("object ace too short")
+			}
+			ace.InheritedObjectType = guidFromBytes(rest)
+			rest = rest[16:]
+		}
+	}
+
+	sid, n, err := ParseSidBytes(rest)
+	if err != nil {
+		return nil, fmt.Errorf("ParseSidBytes, %v", err)
+	}
+	ace.Sid = sid
+	rest = rest[n:]
+
+	// Only the callback and resource attribute ace carry meaningful data after the SID,
+	// the others may only have alignment padding.
+	if len(rest) != 0 && hasApplicationData(ace.Type) {
+		ace.ApplicationData = append([]byte(nil), rest...)
+	}
+	return ace, nil
+}
+
+func hasApplicationData(t byte) bool {
+	switch t {
+	case ACCESS_ALLOWED_CALLBACK_ACE_TYPE, ACCESS_DENIED_CALLBACK_ACE_TYPE,
+		ACCESS_ALLOWED_CALLBACK_OBJECT_ACE_TYPE, ACCESS_DENIED_CALLBACK_OBJECT_ACE_TYPE,
+		SYSTEM_AUDIT_CALLBACK_ACE_TYPE, SYSTEM_ALARM_CALLBACK_ACE_TYPE,
+		SYSTEM_AUDIT_CALLBACK_OBJECT_ACE_TYPE, SYSTEM_ALARM_CALLBACK_OBJECT_ACE_TYPE,
+		SYSTEM_RESOURCE_ATTRIBUTE_ACE_TYPE:
+		return true
+	}
+	return false
+}
+
+// Binary ACL, can be passed to SetSecurityInfo.
+func (a *AclInfo) Bytes() []byte {
+	revision := a.Revision
+	if revision == 0 {
+		revision = ACL_REVISION
+		for _, ace := range a.Aces {
+			if isObjectAceType(ace.Type) {
+				revision = ACL_REVISION_DS
+			}
+		}
+	}
+
+	b := make([]byte, aclHeaderSize, 64)
+	b[0] = revision
+	for i := range a.Aces {
+		b = append(b, a.Aces[i].Bytes()...)
+	}
+	binary.LittleEndian.PutUint16(b[2:], uint16(len(b)))
+	binary.LittleEndian.PutUint16(b[4:], uint16(len(a.Aces)))
+	return b
+}
+
+// Decode binary ACL
+func ParseAcl(b []byte) (*AclInfo, error) {
+	if len(b) < aclHeaderSize {
+		return nil, fmt.Errorf("acl size %v < %v", len(b), aclHeaderSize)
+	}
+
+	aclSize := int(binary.LittleEndian.Uint16(b[2:]))
+	aceCount := int(binary.LittleEndian.Uint16(b[4:]))
+	if aclSize < aclHeaderSize || aclSize > len(b) {
+		return nil, fmt.Errorf("invalid AclSize %v", aclSize)
+	}
+	b = b[:aclSize]
+
+	acl := &AclInfo{Revision: b[0], Aces: make([]AceInfo, 0, aceCount)}
+	offset := aclHeaderSize
+	for i := 0; i < aceCount; i++ {
+		if offset+4 > len(b) {
+			return nil, fmt.Errorf("ace %v out of range", i)
+		}
+		aceSize := int(binary.LittleEndian.Uint16(b[offset+2:]))
+		if aceSize < 8 || offset+aceSize > len(b) {
+			return nil, fmt.Errorf("ace %v invalid AceSize %v", i, aceSize)
+		}
+
+		ace, err := parseAce(b[offset : offset+aceSize])
+		if err != nil {
+			return nil, fmt.Errorf("ace %v, %v", i, err)
+		}
+		acl.Aces = append(acl.Aces, *ace)
+		offset += aceSize
+	}
+	return acl, nil
+}
+
+// Decode the self-relative security descriptor
+func ParseSecurityDescriptor(b []byte) (*SecurityDescriptorInfo, error) {
+	if len(b) < securityDescriptorRelativeSize {
+		return nil, fmt.Errorf("security descriptor size %v < %v", len(b), securityDescriptorRelativeSize)
+	}
+
+	sd := &SecurityDescriptorInfo{
+		Revision: b[0],
+		Control:  SecurityDescriptorControl(binary.LittleEndian.Uint16(b[2:])),
+	}
+	if sd.Control&SE_SELF_RELATIVE == 0 {
+		return nil, fmt.Errorf("not a self-relative security descriptor")
+	}
+
+	offsetOwner := binary.LittleEndian.Uint32(b[4:])
+	offsetGroup := binary.LittleEndian.Uint32(b[8:])
+	offsetSacl := binary.LittleEndian.Uint32(b[12:])
+	offsetDacl := binary.LittleEndian.Uint32(b[16:])
+
+	sub := func(name string, offset uint32) ([]byte, error) {
+		if offset < securityDescriptorRelativeSize || int(offset) >= len(b) {
+			return nil, fmt.Errorf("%v offset %v out of range", name, offset)
+		}
+		return b[offset:], nil
+	}
+
+	if offsetOwner != 0 {
+		v, err := sub("owner", offsetOwner)
+		if err != nil {
+			return nil, err
+		}
+		if sd.Owner, _, err = ParseSidBytes(v); err != nil {
+			return nil, fmt.Errorf("owner, %v", err)
+		}
+	}
+	if offsetGroup != 0 {
+		v, err := sub("group", offsetGroup)
+		if err != nil {
+			return nil, err
+		}
+		if sd.Group, _, err = ParseSidBytes(v); err != nil {
+			return nil, fmt.Errorf("group, %v", err)
+		}
+	}
+	if offsetDacl != 0 && sd.Control&SE_DACL_PRESENT != 0 {
+		v, err := sub("dacl", offsetDacl)
+		if err != nil {
+			return nil, err
+		}
+		if sd.Dacl, err = ParseAcl(v); err != nil {
+			return nil, fmt.Errorf("dacl, %v", err)
+		}
+	}
+	if offsetSacl != 0 && sd.Control&SE_SACL_PRESENT != 0 {
+		v, err := sub("sacl", offsetSacl)
+		if err != nil {
+			return nil, err
+		}
+		if sd.Sacl, err = ParseAcl(v); err != nil {
+			return nil, fmt.Errorf("sacl, %v", err)
+		}
+	}
+	return sd, nil
+}
+
+// Encode as a self-relative security descriptor
+// SE_DACL_PRESENT/SE_SACL_PRESENT are set when Dacl/Sacl is not nil.
+func (sd *SecurityDescriptorInfo) Bytes() []byte {
+	b := make([]byte, securityDescriptorRelativeSize, 128)
+
+	revision := sd.Revision
+	if revision == 0 {
+		revision = SECURITY_DESCRIPTOR_REVISION
+	}
+	control := sd.Control | SE_SELF_RELATIVE
+	if sd.Dacl != nil {
+		control |= SE_DACL_PRESENT
+	}
+	if sd.Sacl != nil {
+		control |= SE_SACL_PRESENT
+	}
+	b[0] = revision
+	binary.LittleEndian.PutUint16(b[2:], uint16(control))
+
+	if sd.Sacl != nil {
+		binary.LittleEndian.PutUint32(b[12:], uint32(len(b)))
+		b = append(b, sd.Sacl.Bytes()...)
+	}
+	if sd.Dacl != nil {
+		binary.LittleEndian.PutUint32(b[16:], uint32(len(b)))
+		b = append(b, sd.Dacl.Bytes()...)
+	}
+	if sd.Owner != nil {
+		binary.LittleEndian.PutUint32(b[4:], uint32(len(b)))
+		b = append(b, sd.Owner.Bytes()...)
+	}
+	if sd.Group != nil {
+		binary.LittleEndian.PutUint32(b[8:], uint32(len(b)))
+		b = append(b, sd.Group.Bytes()...)
+	}
+	return b
+}
+
+// The mandatory label in the SACL
+// found is false if the SACL does not contain SYSTEM_MANDATORY_LABEL_ACE.
+func (sd *SecurityDescriptorInfo) MandatoryLabel() (level IntegrityLevel, policy MandatoryPolicy, found bool) {
+	if sd.Sacl == nil {
+		return 0, 0, false
+	}
+	for _, ace := range sd.Sacl.Aces {
+		if ace.Type != SYSTEM_MANDATORY_LABEL_ACE_TYPE || ace.Sid == nil {
+			continue
+		}
+		if level, ok := ace.Sid.mandatoryLevel(); ok {
+			return level, MandatoryPolicy(ace.Mask), true
+		}
+	}
+	return 0, 0, false
+}
+
+func (t SeObjectType) String() string {
+	switch t {
+	case SE_UNKNOWN_OBJECT_TYPE:
+		return "SE_UNKNOWN_OBJECT_TYPE"
+	case SE_FILE_OBJECT:
+		return "SE_FILE_OBJECT"
+	case SE_SERVICE:
+		return "SE_SERVICE"
+	case SE_PRINTER:
+		return "SE_PRINTER"
+	case SE_REGISTRY_KEY:
+		return "SE_REGISTRY_KEY"
+	case SE_LMSHARE:
+		return "SE_LMSHARE"
+	case SE_KERNEL_OBJECT:
+		return "SE_KERNEL_OBJECT"
+	case SE_WINDOW_OBJECT:
+		return "SE_WINDOW_OBJECT"
+	case SE_DS_OBJECT:
+		return "SE_DS_OBJECT"
+	case SE_DS_OBJECT_ALL:
+		return "SE_DS_OBJECT_ALL"
+	case SE_PROVIDER_DEFINED_OBJECT:
+		return "SE_PROVIDER_DEFINED_OBJECT"
+	case SE_WMIGUID_OBJECT:
+		return "SE_WMIGUID_OBJECT"
+	case SE_REGISTRY_WOW64_32KEY:
+		return "SE_REGISTRY_WOW64_32KEY"
+	default:
+		return strconv.Itoa(int(t))
+	}
+}
+
+// Map the DACL to the unix permission bits (rwx for owner, group and other)
+// Only a subset of the model is supported: OW, CO and the owner SID map to owner, CG and the group SID map to group,
+// WD, AU, IU and BU map to everyone (owner, group and other), other SIDs such as SY and BA are ignored.
+// readMask/writeMask/executeMask are the specific rights of the object, the generic rights are always mapped.
+// The deny aces remove the bits, regardless of the order of the aces.
+// NULL DACL gives full access to everyone, no DACL (the default DACL) gives access only to the owner.
+func (sd *SecurityDescriptorInfo) UnixMode(readMask, writeMask, executeMask uint32) uint32 {
+	if sd.Dacl == nil {
+		if sd.Control&SE_DACL_PRESENT != 0 {
+			return 0777
+		}
+		return 0700
+	}
+
+	var allow, deny uint32
+	for _, ace := range sd.Dacl.Aces {
+		if ace.Flags&INHERIT_ONLY_ACE != 0 || ace.Sid == nil {
+			continue
+		}
+
+		var perm uint32
+		if ace.Mask&(GENERIC_READ|GENERIC_ALL) != 0 || ace.Mask&readMask != 0 {
+			perm |= 04
+		}
+		if ace.Mask&(GENERIC_WRITE|GENERIC_ALL) != 0 || ace.Mask&writeMask != 0 {
+			perm |= 02
+		}
+		if ace.Mask&(GENERIC_EXECUTE|GENERIC_ALL) != 0 || ace.Mask&executeMask != 0 {
+			perm |= 01
+		}
+
+		var bits uint32
+		switch alias := ace.Sid.SDDL(); {
+		case alias == "OW" || alias == "CO" || ace.Sid.Equal(sd.Owner):
+			bits = perm << 6
+		case alias == "CG" || ace.Sid.Equal(sd.Group):
+			bits = perm << 3
+		case alias == "WD" || alias == "AU" || alias == "IU" || alias == "BU":
+			bits = perm<<6 | perm<<3 | perm
+		}
+
+		switch ace.Type {
+		case ACCESS_ALLOWED_ACE_TYPE:
+			allow |= bits
+		case ACCESS_DENIED_ACE_TYPE:
+			deny |= bits
+		}
+	}
+	return allow &^ deny
+}
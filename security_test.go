@@ -1 +1,270 @@
 package gowindows
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestNewMandatoryLabelSacl(t *testing.T) {
+	sacl, err := NewMandatoryLabelSacl(SECURITY_MANDATORY_LOW_RID, SYSTEM_MANDATORY_LABEL_NO_WRITE_UP)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// S:(ML;;NW;;;LW)
+	want := []byte{
+		0x02, 0x00, 0x1c, 0x00, 0x01, 0x00, 0x00, 0x00, // ACL
+		0x11, 0x00, 0x14, 0x00, 0x01, 0x00, 0x00, 0x00, // ACE_HEADER + Mask
+		0x01, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x10, 0x00, 0x10, 0x00, 0x00, // S-1-16-4096
+	}
+	if bytes.Equal(sacl, want) == false {
+		t.Errorf("%#v!=%#v", sacl, want)
+	}
+
+	_, err = NewMandatoryLabelSacl(SECURITY_MANDATORY_LOW_RID, 0x8)
+	if err == nil {
+		t.Error("err==nil")
+	}
+}
+
+func TestParseMandatoryLabelSacl(t *testing.T) {
+	levels := []IntegrityLevel{
+		SECURITY_MANDATORY_UNTRUSTED_RID,
+		SECURITY_MANDATORY_LOW_RID,
+		SECURITY_MANDATORY_MEDIUM_RID,
+		SECURITY_MANDATORY_MEDIUM_PLUS_RID,
+		SECURITY_MANDATORY_HIGH_RID,
+		SECURITY_MANDATORY_SYSTEM_RID,
+		SECURITY_MANDATORY_PROTECTED_PROCESS_RID,
+	}
+	policies := []MandatoryPolicy{
+		0,
+		SYSTEM_MANDATORY_LABEL_NO_WRITE_UP,
+		SYSTEM_MANDATORY_LABEL_NO_WRITE_UP | SYSTEM_MANDATORY_LABEL_NO_READ_UP,
+		SYSTEM_MANDATORY_LABEL_VALID_MASK,
+	}
+
+	for _, level := range levels {
+		for _, policy := range policies {
+			sacl, err := NewMandatoryLabelSacl(level, policy)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			l, p, found, err := ParseMandatoryLabelSacl(sacl)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if found == false || l != level || p != policy {
+				t.Errorf("%v %v %v != %v %v", found, l, p, level, policy)
+			}
+		}
+	}
+}
+
+func TestParseMandatoryLabelSacl_notFound(t *testing.T) {
+	// Empty ACL
+	_, _, found, err := ParseMandatoryLabelSacl([]byte{0x02, 0x00, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found {
+		t.Error("found")
+	}
+
+	// AclSize out of range
+	_, _, _, err = ParseMandatoryLabelSacl([]byte{0x02, 0x00, 0x40, 0x00, 0x01, 0x00, 0x00, 0x00})
+	if err == nil {
+		t.Error("err==nil")
+	}
+}
+
+// The level is the last subauthority of the label SID, the same as MandatoryLabel
+func TestParseMandatoryLabelSacl_subAuthorities(t *testing.T) {
+	sacl := []byte{
+		0x02, 0x00, 0x20, 0x00, 0x01, 0x00, 0x00, 0x00, // ACL
+		0x11, 0x00, 0x18, 0x00, 0x01, 0x00, 0x00, 0x00, // ACE_HEADER + Mask
+		0x01, 0x02, 0x00, 0x00, 0x00, 0x00, 0x00, 0x10, 0x00, 0x00, 0x00, 0x00, 0x00, 0x20, 0x00, 0x00, // S-1-16-0-8192
+	}
+	level, policy, found, err := ParseMandatoryLabelSacl(sacl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found == false || level != SECURITY_MANDATORY_MEDIUM_RID || policy != SYSTEM_MANDATORY_LABEL_NO_WRITE_UP {
+		t.Errorf("%v %v %v", level, policy, found)
+	}
+
+	acl, err := ParseAcl(sacl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sd := SecurityDescriptorInfo{Sacl: acl}
+	if l, p, found := sd.MandatoryLabel(); found == false || l != level || p != policy {
+		t.Errorf("%v %v %v != %v %v", found, l, p, level, policy)
+	}
+}
+
+func TestIntegrityLevel_String(t *testing.T) {
+	if s := SECURITY_MANDATORY_MEDIUM_PLUS_RID.String(); s != "MediumPlus" {
+		t.Error(s)
+	}
+	if s := IntegrityLevel(0x1500).String(); s != "0x1500" {
+		t.Error(s)
+	}
+	if s := (SYSTEM_MANDATORY_LABEL_NO_WRITE_UP | SYSTEM_MANDATORY_LABEL_NO_EXECUTE_UP).String(); s != "NO_WRITE_UP|NO_EXECUTE_UP" {
+		t.Error(s)
+	}
+}
+
+func TestParseSid(t *testing.T) {
+	for _, v := range []struct{ s, want string }{
+		{"S-1-5-32-544", "S-1-5-32-544"},
+		{"BA", "S-1-5-32-544"},
+		{"sy", "S-1-5-18"},
+		{"S-1-16-4096", "S-1-16-4096"},
+		{"S-1-5-21-1004336348-1177238915-682003330-512", "S-1-5-21-1004336348-1177238915-682003330-512"},
+	} {
+		sid, err := ParseSid(v.s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if sid.String() != v.want {
+			t.Errorf("%v!=%v", sid.String(), v.want)
+		}
+
+		sid2, n, err := ParseSidBytes(sid.Bytes())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n != len(sid.Bytes()) || sid2.Equal(sid) == false {
+			t.Errorf("%v!=%v", sid2, sid)
+		}
+	}
+
+	for _, s := range []string{"", "S-1", "X-1-5", "S-1-5-x", "S-1-5-1-2-3-4-5-6-7-8-9-10-11-12-13-14-15-16"} {
+		if _, err := ParseSid(s); err == nil {
+			t.Errorf("ParseSid(%q) == nil", s)
+		}
+	}
+
+	sid, _ := ParseSid("S-1-5-32-544")
+	if sid.SDDL() != "BA" {
+		t.Errorf("%v!=BA", sid.SDDL())
+	}
+}
+
+func TestParseSecurityDescriptor(t *testing.T) {
+	// O:SYD:(A;;GA;;;WD)
+	b := []byte{
+		// SECURITY_DESCRIPTOR_RELATIVE
+		1, 0, 0x04, 0x80, 48, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 20, 0, 0, 0,
+		// DACL
+		2, 0, 28, 0, 1, 0, 0, 0,
+		0, 0, 20, 0, 0, 0, 0, 0x10, 1, 1, 0, 0, 0, 0, 0, 1, 0, 0, 0, 0,
+		// Owner
+		1, 1, 0, 0, 0, 0, 0, 5, 18, 0, 0, 0,
+	}
+
+	sd, err := ParseSecurityDescriptor(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if s := sd.SDDL(); s != "O:SYD:(A;;GA;;;WD)" {
+		t.Errorf("%v!=O:SYD:(A;;GA;;;WD)", s)
+	}
+	if sd.Group != nil || sd.Sacl != nil {
+		t.Errorf("group or sacl != nil")
+	}
+
+	if v := sd.Bytes(); bytes.Equal(v, b) == false {
+		t.Errorf("%v!=%v", v, b)
+	}
+
+	for i := 0; i < len(b); i++ {
+		// must not panic
+		ParseSecurityDescriptor(b[:i])
+	}
+}
+
+func TestParseSDDL(t *testing.T) {
+	for _, s := range []string{
+		"O:BAG:SYD:(A;;FA;;;SY)(A;OICI;GR;;;BU)(D;CIIO;0x12345;;;S-1-5-21-1-2-3-500)S:(ML;;NW;;;LW)",
+		"D:PAI(A;OICIID;FA;;;BA)(A;;FR;;;AU)",
+		"D:NO_ACCESS_CONTROL",
+		"D:(OA;CI;RPWP;bf967a7f-0de6-11d0-a285-00aa003049e2;bf967aba-0de6-11d0-a285-00aa003049e2;PS)",
+		"S:AI(AU;SAFA;GAWD;;;WD)(ML;;NRNWNX;;;HI)",
+		"O:S-1-5-21-1-2-3-1001G:S-1-5-21-1-2-3-513",
+		"D:(A;;KA;;;BA)(A;;CCDCLCSWRPWPDTLOCRSDRCWDWO;;;SY)",
+	} {
+		sd, err := ParseSDDL(s)
+		if err != nil {
+			t.Fatalf("ParseSDDL(%q), %v", s, err)
+		}
+		if v := sd.SDDL(); v != s {
+			t.Errorf("%v!=%v", v, s)
+		}
+
+		sd2, err := ParseSecurityDescriptor(sd.Bytes())
+		if err != nil {
+			t.Fatalf("ParseSecurityDescriptor(%q), %v", s, err)
+		}
+		if v := sd2.SDDL(); v != s {
+			t.Errorf("%v!=%v", v, s)
+		}
+	}
+
+	for _, s := range []string{"X:BA", "O:", "D:(A;;FA;;SY)", "D:(Q;;FA;;;SY)", "D:(A;XX;FA;;;SY)", "D:(A;;QQ;;;SY)", "D:(A;;FA;;;SY", "O:BAO:BA"} {
+		if _, err := ParseSDDL(s); err == nil {
+			t.Errorf("ParseSDDL(%q) == nil", s)
+		}
+	}
+}
+
+func TestSecurityDescriptorInfo_MandatoryLabel(t *testing.T) {
+	sd, err := ParseSDDL(LOW_INTEGRITY_SDDL_SACL_W)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	level, policy, found := sd.MandatoryLabel()
+	if found == false || level != SECURITY_MANDATORY_LOW_RID || policy != SYSTEM_MANDATORY_LABEL_NO_WRITE_UP {
+		t.Errorf("%v %v %v", level, policy, found)
+	}
+
+	sacl, err := NewMandatoryLabelSacl(SECURITY_MANDATORY_LOW_RID, SYSTEM_MANDATORY_LABEL_NO_WRITE_UP)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := sd.Sacl.Bytes(); bytes.Equal(v, sacl) == false {
+		t.Errorf("%v!=%v", v, sacl)
+	}
+}
+
+func TestSecurityDescriptorInfo_UnixMode(t *testing.T) {
+	for _, v := range []struct {
+		sddl string
+		mode uint32
+	}{
+		{"", 0700},
+		{"D:NO_ACCESS_CONTROL", 0777},
+		{"D:", 0},
+		{"D:(A;;GA;;;SY)(A;;GA;;;BA)", 0},
+		{"D:(A;;GA;;;OW)", 0700},
+		{"D:(A;;GRGW;;;OW)(A;;GR;;;CG)", 0640},
+		{"D:(A;;GA;;;WD)", 0777},
+		{"D:(A;;GR;;;IU)(A;;GW;;;OW)", 0644},
+		{"D:(A;;GA;;;WD)(D;;GW;;;CG)", 0757},
+		{"D:(A;OICIIO;GA;;;WD)", 0},
+		{"D:(A;;0x4;;;AU)(A;;0x2;;;CO)", 0644},
+		{"O:S-1-5-21-1-2-3-1001G:S-1-5-21-1-2-3-513D:(A;;GA;;;S-1-5-21-1-2-3-1001)(A;;GR;;;S-1-5-21-1-2-3-513)", 0740},
+	} {
+		sd, err := ParseSDDL(v.sddl)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if mode := sd.UnixMode(0x4, 0x2, 0x20); mode != v.mode {
+			t.Errorf("%v: %o!=%o", v.sddl, mode, v.mode)
+		}
+	}
+}
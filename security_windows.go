@@ -16,7 +16,7 @@ func SetPrivilege(hToken windows.Token, privilege Privilege, bEnablePrivilege bo
 
 	err := LookupPrivilegeValue(nil, windows.StringToUTF16Ptr(string(privilege)), &luid)
 	if err != nil {
-		return fmt.Errorf("LookupPrivilegeValue, %v", err)
+		return fmt.Errorf("LookupPrivilegeValue, %w", err)
 	}
 
 	// The first step is to obtain the current permission settings
@@ -26,7 +26,7 @@ func SetPrivilege(hToken windows.Token, privilege Privilege, bEnablePrivilege bo
 
 	err = AdjustTokenPrivileges(hToken, false, &tp, uint32(unsafe.Sizeof(tp)), &tpPrevious, &cbPrevious)
 	if err != nil {
-		return fmt.Errorf("AdjustTokenPrivileges1, %v", err)
+		return fmt.Errorf("AdjustTokenPrivileges1, %w", err)
 	}
 
 	// The second step is to set permissions according to the original permissions
@@ -41,7 +41,7 @@ func SetPrivilege(hToken windows.Token, privilege Privilege, bEnablePrivilege bo
 
 	err = AdjustTokenPrivileges(hToken, false, &tpPrevious, cbPrevious, nil, nil)
 	if err != nil {
-		return fmt.Errorf("AdjustTokenPrivileges2 ,%v", err)
+		return fmt.Errorf("AdjustTokenPrivileges2 ,%w", err)
 	}
 	return nil
 }
@@ -50,7 +50,7 @@ func SetPrivilege(hToken windows.Token, privilege Privilege, bEnablePrivilege bo
 func SetSelfProcessPrivilege(privilege Privilege, bEnablePrivilege bool) error {
 	pHandle, err := windows.GetCurrentProcess()
 	if err != nil {
-		return fmt.Errorf("windows.GetCurrentProcess, %v", err)
+		return fmt.Errorf("windows.GetCurrentProcess, %w", err)
 	}
 	defer windows.CloseHandle(pHandle)
 
@@ -58,13 +58,13 @@ func SetSelfProcessPrivilege(privilege Privilege, bEnablePrivilege bool) error {
 
 	err = windows.OpenProcessToken(pHandle, windows.TOKEN_ADJUST_PRIVILEGES|windows.TOKEN_QUERY, &hToken)
 	if err != nil {
-		return fmt.Errorf("windows.OpenProcessToken, %v", err)
+		return fmt.Errorf("windows.OpenProcessToken, %w", err)
 	}
 	defer windows.CloseHandle(windows.Handle(hToken))
 
 	err = SetPrivilege(hToken, privilege, bEnablePrivilege)
 	if err != nil {
-		return fmt.Errorf("SetPrivilege, %v", err)
+		return fmt.Errorf("SetPrivilege, %w", err)
 	}
 
 	return nil
@@ -0,0 +1,268 @@
+package gowindows
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"reflect"
+	"runtime"
+	"sync/atomic"
+	"time"
+	"unsafe"
+)
+
+// Fixed-size struct in shared memory protected by a sequence lock
+// Memory layout:
+//
+//	0  magic  uint32 "GWSV"
+//	4  size   uint32 size of the struct
+//	8  seq    uint64 odd while the writer is storing
+//	16 layout uint64 hash of the struct layout
+//	24 data   the struct, padded to 8 bytes
+//
+// The data is copied by 8 byte atomic operations, the reader retries if seq changed during the copy,
+// so the reader never gets a torn value and never blocks the writer.
+// The struct must not contain pointers (including string, slice, map, interface...),
+// since the pointers are meaningless in other processes.
+const (
+	SharedValueHeaderSize = 24
+
+	sharedValueMagic        = 0x56535747 // "GWSV"
+	sharedValueOffsetMagic  = 0
+	sharedValueOffsetSize   = 4
+	sharedValueOffsetSeq    = 8
+	sharedValueOffsetLayout = 16
+)
+
+var (
+	ErrSharedValueType = errors.New("shared value type mismatch")
+	ErrSharedValueBusy = errors.New("shared value is being stored")
+)
+
+// How long Load waits for the writer, and Store waits for another writer.
+// A writer crashed while storing leaves seq odd, Store takes over after the timeout.
+// A writer stalled longer than the timeout finds out by seq before storing each word, the rest is dropped
+// and its Store returns ErrSharedValueBusy, only a word being stored at the moment of the takeover is lost.
+var SharedValueSpinTimeout = time.Second
+
+type SharedValue struct {
+	mem    []byte
+	typ    reflect.Type
+	size   int
+	words  int
+	layout uint64
+}
+
+// Check that the type contains no pointers
+func checkPointerFree(t reflect.Type) error {
+	switch t.Kind() {
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		return nil
+	case reflect.Array:
+		if err := checkPointerFree(t.Elem()); err != nil {
+			return fmt.Errorf("%v, %v", t, err)
+		}
+		return nil
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if err := checkPointerFree(f.Type); err != nil {
+				return fmt.Errorf("field %v, %v", f.Name, err)
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("%v contains pointers", t)
+	}
+}
+
+// Hash of the memory layout (kind, offset and size of every field), the names are not included.
+func layoutHash(t reflect.Type) uint64 {
+	h := fnv.New64a()
+	var walk func(t reflect.Type, offset uintptr)
+	walk = func(t reflect.Type, offset uintptr) {
+		fmt.Fprintf(h, "%v@%v:%v;", t.Kind(), offset, t.Size())
+		switch t.Kind() {
+		case reflect.Array:
+			fmt.Fprintf(h, "[%v]", t.Len())
+			walk(t.Elem(), 0)
+		case reflect.Struct:
+			for i := 0; i < t.NumField(); i++ {
+				f := t.Field(i)
+				walk(f.Type, offset+f.Offset)
+			}
+		}
+	}
+	walk(t, 0)
+	return h.Sum64()
+}
+
+// Attach the shared value of the type of v to mem
+// v is a value or a pointer of the struct, mem is initialized if it is not initialized,
+// otherwise the size and the layout must be the same.
+func NewSharedValue(mem []byte, v interface{}) (*SharedValue, error) {
+	t := reflect.TypeOf(v)
+	if t == nil {
+		return nil, fmt.Errorf("v is nil")
+	}
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if err := checkPointerFree(t); err != nil {
+		return nil, err
+	}
+	if t.Size() == 0 {
+		return nil, fmt.Errorf("size of %v is 0", t)
+	}
+
+	sv := &SharedValue{
+		mem:    mem,
+		typ:    t,
+		size:   int(t.Size()),
+		words:  (int(t.Size()) + 7) / 8,
+		layout: layoutHash(t),
+	}
+
+	if len(mem) < SharedValueHeaderSize+sv.words*8 {
+		return nil, fmt.Errorf("memory size %v < %v", len(mem), SharedValueHeaderSize+sv.words*8)
+	}
+	if uintptr(unsafe.Pointer(&mem[0]))%8 != 0 {
+		return nil, fmt.Errorf("memory is not 8 byte aligned")
+	}
+
+	magicPtr := sv.uint32(sharedValueOffsetMagic)
+	if atomic.LoadUint32(magicPtr) != sharedValueMagic {
+		atomic.StoreUint32(sv.uint32(sharedValueOffsetSize), uint32(sv.size))
+		atomic.StoreUint64(sv.uint64(sharedValueOffsetLayout), sv.layout)
+		atomic.StoreUint32(magicPtr, sharedValueMagic)
+	}
+
+	if size := atomic.LoadUint32(sv.uint32(sharedValueOffsetSize)); size != uint32(sv.size) {
+		return nil, fmt.Errorf("%w, size %v != %v", ErrSharedValueType, size, sv.size)
+	}
+	if atomic.LoadUint64(sv.uint64(sharedValueOffsetLayout)) != sv.layout {
+		return nil, fmt.Errorf("%w, layout of %v is different", ErrSharedValueType, t)
+	}
+
+	return sv, nil
+}
+
+func (sv *SharedValue) uint64(offset int) *uint64 {
+	return (*uint64)(unsafe.Pointer(&sv.mem[offset]))
+}
+
+func (sv *SharedValue) uint32(offset int) *uint32 {
+	return (*uint32)(unsafe.Pointer(&sv.mem[offset]))
+}
+
+func (sv *SharedValue) word(i int) *uint64 {
+	return sv.uint64(SharedValueHeaderSize + i*8)
+}
+
+// Pointer to the struct of v, v is *T or T
+func (sv *SharedValue) pointer(v interface{}, write bool) (unsafe.Pointer, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Ptr && rv.Type().Elem() == sv.typ {
+		if rv.IsNil() {
+			return nil, fmt.Errorf("v is nil")
+		}
+		return unsafe.Pointer(rv.Pointer()), nil
+	}
+	if write == false && rv.Type() == sv.typ {
+		p := reflect.New(sv.typ)
+		p.Elem().Set(rv)
+		return unsafe.Pointer(p.Pointer()), nil
+	}
+	return nil, fmt.Errorf("%w, %v is not %v", ErrSharedValueType, rv.Type(), sv.typ)
+}
+
+// Store the value, v is *T or T
+// ErrSharedValueBusy is returned if another writer took over after SharedValueSpinTimeout.
+func (sv *SharedValue) Store(v interface{}) error {
+	p, err := sv.pointer(v, false)
+	if err != nil {
+		return err
+	}
+
+	buf := make([]uint64, sv.words)
+	copy(ToBytes(uintptr(unsafe.Pointer(&buf[0])), sv.size, sv.size), ToBytes(uintptr(p), sv.size, sv.size))
+	runtime.KeepAlive(v)
+
+	return sv.write(sv.lock(), buf)
+}
+
+// Make seq odd, the odd seq owned by this writer is returned.
+func (sv *SharedValue) lock() uint64 {
+	seqPtr := sv.uint64(sharedValueOffsetSeq)
+	deadline := time.Now().Add(SharedValueSpinTimeout)
+	for {
+		seq := atomic.LoadUint64(seqPtr)
+		if seq%2 == 0 {
+			if atomic.CompareAndSwapUint64(seqPtr, seq, seq+1) {
+				return seq + 1
+			}
+		} else if time.Now().After(deadline) {
+			// The other writer crashed, or stalled too long, it finds out by the changed seq.
+			if atomic.CompareAndSwapUint64(seqPtr, seq, seq+2) {
+				return seq + 2
+			}
+		}
+		runtime.Gosched()
+	}
+}
+
+// Copy buf to the data while seq is still owned, and make seq even.
+// ErrSharedValueBusy is returned if another writer took over, the rest of buf is dropped.
+func (sv *SharedValue) write(seq uint64, buf []uint64) error {
+	seqPtr := sv.uint64(sharedValueOffsetSeq)
+	for i, w := range buf {
+		if atomic.LoadUint64(seqPtr) != seq {
+			return ErrSharedValueBusy
+		}
+		atomic.StoreUint64(sv.word(i), w)
+	}
+
+	if atomic.CompareAndSwapUint64(seqPtr, seq, seq+1) == false {
+		return ErrSharedValueBusy
+	}
+	return nil
+}
+
+// Load the snapshot into v, v must be *T
+func (sv *SharedValue) Load(v interface{}) error {
+	p, err := sv.pointer(v, true)
+	if err != nil {
+		return err
+	}
+
+	buf := make([]uint64, sv.words)
+	seqPtr := sv.uint64(sharedValueOffsetSeq)
+	deadline := time.Now().Add(SharedValueSpinTimeout)
+	for {
+		seq := atomic.LoadUint64(seqPtr)
+		if seq%2 == 0 {
+			for i := range buf {
+				buf[i] = atomic.LoadUint64(sv.word(i))
+			}
+			if atomic.LoadUint64(seqPtr) == seq {
+				break
+			}
+		}
+		if time.Now().After(deadline) {
+			return ErrSharedValueBusy
+		}
+		runtime.Gosched()
+	}
+
+	copy(ToBytes(uintptr(p), sv.size, sv.size), ToBytes(uintptr(unsafe.Pointer(&buf[0])), sv.size, sv.size))
+	runtime.KeepAlive(v)
+	return nil
+}
+
+// Sequence number, it is increased by 2 after each Store.
+// Readers can compare it to find out whether the value has changed.
+func (sv *SharedValue) Seq() uint64 {
+	return atomic.LoadUint64(sv.uint64(sharedValueOffsetSeq))
+}
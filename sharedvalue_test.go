@@ -0,0 +1,234 @@
+package gowindows
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+type testStatus struct {
+	Pid     uint32
+	Healthy bool
+	Version [16]byte
+	Flags   uint64
+	// Always equal to Flags, to detect torn reads
+	Check uint64
+}
+
+func TestSharedValue(t *testing.T) {
+	mem := make([]byte, 1024)
+	w, err := NewSharedValue(mem, testStatus{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := NewSharedValue(mem, (*testStatus)(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var v testStatus
+	if err := r.Load(&v); err != nil {
+		t.Fatal(err)
+	}
+	if v != (testStatus{}) {
+		t.Errorf("%v", v)
+	}
+
+	s := testStatus{Pid: 123, Healthy: true, Flags: 7, Check: 7}
+	copy(s.Version[:], "1.2.3")
+	if err := w.Store(s); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Load(&v); err != nil {
+		t.Fatal(err)
+	}
+	if v != s {
+		t.Errorf("%v!=%v", v, s)
+	}
+	if seq := r.Seq(); seq != 2 {
+		t.Errorf("%v!=2", seq)
+	}
+
+	if err := r.Load(v); errors.Is(err, ErrSharedValueType) == false {
+		t.Errorf("%v!=ErrSharedValueType", err)
+	}
+	if err := r.Store(1); errors.Is(err, ErrSharedValueType) == false {
+		t.Errorf("%v!=ErrSharedValueType", err)
+	}
+}
+
+func TestNewSharedValue_validation(t *testing.T) {
+	mem := make([]byte, 1024)
+
+	for _, v := range []interface{}{
+		struct{ A *int }{},
+		struct{ S string }{},
+		struct{ B []byte }{},
+		struct{ M map[int]int }{},
+		struct{ I interface{} }{},
+		struct{ A [2]struct{ P *int } }{},
+		struct{}{},
+		nil,
+	} {
+		if _, err := NewSharedValue(mem, v); err == nil {
+			t.Errorf("NewSharedValue(%T) == nil", v)
+		}
+	}
+
+	if _, err := NewSharedValue(mem[:SharedValueHeaderSize+8], testStatus{}); err == nil {
+		t.Error("small memory == nil")
+	}
+	if _, err := NewSharedValue(mem[4:], testStatus{}); err == nil {
+		t.Error("unaligned memory == nil")
+	}
+
+	if _, err := NewSharedValue(mem, struct{ A, B uint32 }{}); err != nil {
+		t.Fatal(err)
+	}
+	// The same size but different layout
+	if _, err := NewSharedValue(mem, struct{ A uint64 }{}); errors.Is(err, ErrSharedValueType) == false {
+		t.Errorf("%v!=ErrSharedValueType", err)
+	}
+	if _, err := NewSharedValue(mem, struct{ A, B, C uint32 }{}); errors.Is(err, ErrSharedValueType) == false {
+		t.Errorf("%v!=ErrSharedValueType", err)
+	}
+	// Different names, the same layout
+	if _, err := NewSharedValue(mem, struct{ X, Y uint32 }{}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSharedValue_concurrent(t *testing.T) {
+	mem := make([]byte, 1024)
+	sv, err := NewSharedValue(mem, testStatus{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stop := make(chan struct{})
+	wg := sync.WaitGroup{}
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for n := uint64(0); ; n++ {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				v := n<<1 | uint64(i)
+				if err := sv.Store(&testStatus{Pid: uint32(v), Flags: v, Check: v}); err != nil {
+					panic(err)
+				}
+			}
+		}(i)
+	}
+
+	deadline := time.Now().Add(200 * time.Millisecond)
+	for time.Now().Before(deadline) {
+		var v testStatus
+		if err := sv.Load(&v); err != nil {
+			t.Fatal(err)
+		}
+		if v.Flags != v.Check || uint32(v.Flags) != v.Pid {
+			t.Fatalf("torn read %v", v)
+		}
+	}
+	close(stop)
+	wg.Wait()
+}
+
+func TestSharedValue_crashedWriter(t *testing.T) {
+	mem := make([]byte, 1024)
+	sv, err := NewSharedValue(mem, testStatus{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	old := SharedValueSpinTimeout
+	SharedValueSpinTimeout = 10 * time.Millisecond
+	defer func() { SharedValueSpinTimeout = old }()
+
+	// seq is left odd
+	mem[sharedValueOffsetSeq] = 1
+
+	var v testStatus
+	if err := sv.Load(&v); err != ErrSharedValueBusy {
+		t.Errorf("%v!=ErrSharedValueBusy", err)
+	}
+	if err := sv.Store(testStatus{Pid: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if err := sv.Load(&v); err != nil || v.Pid != 1 {
+		t.Errorf("%v %v", v, err)
+	}
+	if seq := sv.Seq(); seq%2 != 0 {
+		t.Errorf("seq %v is odd", seq)
+	}
+}
+
+func TestSharedValue_stalledWriter(t *testing.T) {
+	mem := make([]byte, 1024)
+	sv, err := NewSharedValue(mem, testStatus{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	old := SharedValueSpinTimeout
+	SharedValueSpinTimeout = 10 * time.Millisecond
+	defer func() { SharedValueSpinTimeout = old }()
+
+	// The first writer stalls after making seq odd
+	seq := sv.lock()
+	if err := sv.Store(testStatus{Pid: 2, Flags: 2, Check: 2}); err != nil {
+		t.Fatal(err)
+	}
+	after := sv.Seq()
+
+	// The first writer resumes
+	if err := sv.write(seq, []uint64{1, 1, 1, 1, 1}); err != ErrSharedValueBusy {
+		t.Errorf("%v!=ErrSharedValueBusy", err)
+	}
+	if seq := sv.Seq(); seq != after {
+		t.Errorf("seq %v!=%v", seq, after)
+	}
+
+	var v testStatus
+	if err := sv.Load(&v); err != nil || v.Pid != 2 || v.Flags != 2 || v.Check != 2 {
+		t.Errorf("%v %v", v, err)
+	}
+}
+
+func TestSharedValue_mmap(t *testing.T) {
+	name := fmt.Sprintf("gowindows_test_shared_value_%v", time.Now().UnixNano())
+	m1, err := CreateMmap(name, 4096, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer m1.Close()
+	m2, err := OpenMmap(name, 4096, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer m2.Close()
+
+	w, err := NewSharedValue(m1.GetBytes(), testStatus{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Store(testStatus{Pid: 42}); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := NewSharedValue(m2.GetBytes(), testStatus{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var v testStatus
+	if err := r.Load(&v); err != nil || v.Pid != 42 {
+		t.Errorf("%v %v", v, err)
+	}
+}
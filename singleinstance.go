@@ -0,0 +1,75 @@
+package gowindows
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// Scope of the single instance
+type InstanceScope int
+
+const (
+	// One instance per logon session (windows) or per user (linux)
+	InstanceScopeSession InstanceScope = iota
+	// One instance on the machine
+	InstanceScopeGlobal
+)
+
+var (
+	ErrInstanceRunning     = errors.New("an instance has been run")
+	ErrInstanceNotAcquired = errors.New("the instance is not acquired")
+)
+
+// The timeout of Forward
+var InstanceForwardTimeout = 5 * time.Second
+
+// Guard that only one instance of the application runs
+// The first instance holds it by Acquire until Release or exit (including crash),
+// the other instances get ErrInstanceRunning, and can forward their args to the first instance by Forward.
+//
+//	si := NewSingleInstance("myapp", InstanceScopeSession)
+//	si.HandleForward(func(args []string) { /* focus the window, open the file */ })
+//	if err := si.Acquire(); err == ErrInstanceRunning {
+//		si.Forward(os.Args[1:])
+//		return
+//	}
+//	defer si.Release()
+type SingleInstance struct {
+	name    string
+	scope   InstanceScope
+	handler func(args []string)
+
+	mu       sync.Mutex
+	acquired bool
+	singleInstanceSys
+}
+
+func NewSingleInstance(name string, scope InstanceScope) *SingleInstance {
+	return &SingleInstance{name: name, scope: scope}
+}
+
+// Set the handler of the args forwarded by the other instances, it must be called before Acquire.
+// The handler is called in a new goroutine.
+func (s *SingleInstance) HandleForward(handler func(args []string)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handler = handler
+}
+
+// Whether Acquire succeeded and Release is not called
+func (s *SingleInstance) Acquired() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.acquired
+}
+
+func (s *SingleInstance) forwarded(args []string) {
+	s.mu.Lock()
+	handler := s.handler
+	s.mu.Unlock()
+
+	if handler != nil {
+		go handler(args)
+	}
+}
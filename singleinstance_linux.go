@@ -0,0 +1,177 @@
+package gowindows
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// The linux implementation of SingleInstance
+// The instance holds the flock of <dir>/gowindows.<name>.lock, the lock is released by the kernel when the process exits,
+// the lock file is never deleted, since deleting it while another process is waiting breaks the lock.
+// The args are forwarded by the unix socket <dir>/gowindows.<name>.sock as a json array.
+// dir is $XDG_RUNTIME_DIR (or $TMPDIR/gowindows-<uid>) for InstanceScopeSession, $TMPDIR for InstanceScopeGlobal.
+type singleInstanceSys struct {
+	lockFile *os.File
+	listener net.Listener
+}
+
+func (s *SingleInstance) dir() (string, error) {
+	if s.scope == InstanceScopeGlobal {
+		return os.TempDir(), nil
+	}
+	if dir := os.Getenv("XDG_RUNTIME_DIR"); dir != "" {
+		return dir, nil
+	}
+	dir := filepath.Join(os.TempDir(), "gowindows-"+strconv.Itoa(os.Getuid()))
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
+func (s *SingleInstance) path(ext string) (string, error) {
+	dir, err := s.dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "gowindows."+url.PathEscape(s.name)+ext), nil
+}
+
+// Hold the instance, ErrInstanceRunning is returned if another instance holds it.
+func (s *SingleInstance) Acquire() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.acquired {
+		return nil
+	}
+
+	lockPath, err := s.path(".lock")
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(lockPath, os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return err
+	}
+	if s.scope == InstanceScopeGlobal {
+		// All users must be able to open the lock file, the mode of open is affected by umask
+		f.Chmod(0666)
+	}
+
+	if err := unix.Flock(int(f.Fd()), unix.LOCK_EX|unix.LOCK_NB); err != nil {
+		f.Close()
+		if err == unix.EWOULDBLOCK {
+			return ErrInstanceRunning
+		}
+		return fmt.Errorf("flock %v, %w", lockPath, err)
+	}
+
+	sockPath, err := s.path(".sock")
+	if err != nil {
+		f.Close()
+		return err
+	}
+	// Left over by the previous instance, it is safe to delete it while holding the lock.
+	os.Remove(sockPath)
+	l, err := net.Listen("unix", sockPath)
+	if err != nil {
+		f.Close()
+		return err
+	}
+	if s.scope == InstanceScopeGlobal {
+		os.Chmod(sockPath, 0666)
+	}
+
+	s.lockFile = f
+	s.listener = l
+	s.acquired = true
+
+	go s.serve(l)
+	return nil
+}
+
+func (s *SingleInstance) serve(l net.Listener) {
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+
+		go func() {
+			defer conn.Close()
+			conn.SetDeadline(time.Now().Add(InstanceForwardTimeout))
+
+			data, err := ioutil.ReadAll(conn)
+			if err != nil {
+				return
+			}
+			var args []string
+			if err := json.Unmarshal(data, &args); err != nil {
+				return
+			}
+			conn.Write([]byte{1})
+			s.forwarded(args)
+		}()
+	}
+}
+
+// Release the instance, the socket is deleted and the lock is released.
+func (s *SingleInstance) Release() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.acquired == false {
+		return ErrInstanceNotAcquired
+	}
+
+	s.acquired = false
+	// The socket file is deleted by Close
+	s.listener.Close()
+	err := s.lockFile.Close()
+	s.listener = nil
+	s.lockFile = nil
+	return err
+}
+
+// Send args to the instance holding it
+func (s *SingleInstance) Forward(args []string) error {
+	if args == nil {
+		args = []string{}
+	}
+	data, err := json.Marshal(args)
+	if err != nil {
+		return err
+	}
+
+	sockPath, err := s.path(".sock")
+	if err != nil {
+		return err
+	}
+	conn, err := net.DialTimeout("unix", sockPath, InstanceForwardTimeout)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(InstanceForwardTimeout))
+
+	if _, err := conn.Write(data); err != nil {
+		return err
+	}
+	if err := conn.(*net.UnixConn).CloseWrite(); err != nil {
+		return err
+	}
+
+	ack := make([]byte, 1)
+	if _, err := conn.Read(ack); err != nil {
+		return fmt.Errorf("read ack, %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package gowindows
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSingleInstance(t *testing.T) {
+	for _, scope := range []InstanceScope{InstanceScopeSession, InstanceScopeGlobal} {
+		args := make(chan []string, 1)
+		first := NewSingleInstance("gowindows_test_instance", scope)
+		first.HandleForward(func(v []string) {
+			args <- v
+		})
+		if err := first.Acquire(); err != nil {
+			if scope == InstanceScopeGlobal {
+				// Creating the global objects may need the privilege
+				t.Logf("global scope, %v", err)
+				continue
+			}
+			t.Fatal(err)
+		}
+		if first.Acquired() == false {
+			t.Error("Acquired() == false")
+		}
+
+		second := NewSingleInstance("gowindows_test_instance", scope)
+		if err := second.Acquire(); err != ErrInstanceRunning {
+			t.Errorf("%v!=%v", err, ErrInstanceRunning)
+		}
+		if err := second.Release(); err != ErrInstanceNotAcquired {
+			t.Errorf("%v!=%v", err, ErrInstanceNotAcquired)
+		}
+
+		want := []string{"open", `C:\a b.txt`, ""}
+		if err := second.Forward(want); err != nil {
+			t.Error(err)
+		}
+		select {
+		case v := <-args:
+			if reflect.DeepEqual(v, want) == false {
+				t.Errorf("%#v!=%#v", v, want)
+			}
+		case <-time.After(5 * time.Second):
+			t.Error("args are not forwarded")
+		}
+
+		if err := first.Release(); err != nil {
+			t.Error(err)
+		}
+		if err := second.Forward(nil); err == nil {
+			t.Error("Forward after Release == nil")
+		}
+
+		// Acquired by the second after Release
+		if err := second.Acquire(); err != nil {
+			t.Error(err)
+		} else {
+			second.Release()
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package gowindows
+
+import (
+	"context"
+)
+
+// The windows implementation of SingleInstance
+// The instance holds the named mutex Local\<name> (InstanceScopeSession) or Global\<name> (InstanceScopeGlobal),
+// the mutex abandoned by the crashed instance is acquired by the next instance.
+// The args are forwarded by the RpcServer <mutex name>.forward.
+type singleInstanceSys struct {
+	release  chan struct{}
+	released chan struct{}
+	server   *RpcServer
+	cancel   context.CancelFunc
+}
+
+// The objects of the global scope are accessible for all the authenticated users
+const singleInstanceGlobalSecurityDescriptor = "D:(A;;GA;;;SY)(A;;GA;;;BA)(A;;GA;;;AU)"
+
+func (s *SingleInstance) objectName() (string, string) {
+	if s.scope == InstanceScopeGlobal {
+		return `Global\` + s.name, singleInstanceGlobalSecurityDescriptor
+	}
+	return `Local\` + s.name, ""
+}
+
+// Hold the instance, ErrInstanceRunning is returned if another instance holds it.
+func (s *SingleInstance) Acquire() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.acquired {
+		return nil
+	}
+
+	name, sd := s.objectName()
+
+	// The owner of the mutex is the thread, it is held by a goroutine locked to the thread.
+	result := make(chan error, 1)
+	release := make(chan struct{})
+	released := make(chan struct{})
+	go func() {
+		defer close(released)
+
+		m, err := CreateNamedMutex(name, sd)
+		if err != nil {
+			result <- err
+			return
+		}
+		defer m.Close()
+
+		// The mutex abandoned by the crashed instance is acquired too
+		ok, err := m.TryLock()
+		if ok == false {
+			if err == nil {
+				err = ErrInstanceRunning
+			}
+			result <- err
+			return
+		}
+
+		result <- nil
+		<-release
+		m.Unlock()
+	}()
+
+	if err := <-result; err != nil {
+		return err
+	}
+
+	server, err := NewRpcServer(name+".forward", &RpcConfig{Slots: 4, SecurityDescriptor: sd})
+	if err != nil {
+		close(release)
+		<-released
+		return err
+	}
+	server.Handle("forward", func(ctx context.Context, decode func(v interface{}) error) (interface{}, error) {
+		var args []string
+		if err := decode(&args); err != nil {
+			return nil, err
+		}
+		s.forwarded(args)
+		return nil, nil
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	go server.Serve(ctx)
+
+	s.release = release
+	s.released = released
+	s.server = server
+	s.cancel = cancel
+	s.acquired = true
+	return nil
+}
+
+// Release the instance
+func (s *SingleInstance) Release() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.acquired == false {
+		return ErrInstanceNotAcquired
+	}
+
+	s.acquired = false
+	s.cancel()
+	err := s.server.Close()
+	close(s.release)
+	<-s.released
+	s.server = nil
+	return err
+}
+
+// Send args to the instance holding it
+func (s *SingleInstance) Forward(args []string) error {
+	if args == nil {
+		args = []string{}
+	}
+
+	name, sd := s.objectName()
+	c, err := DialRpc(name+".forward", &RpcConfig{SecurityDescriptor: sd, Timeout: InstanceForwardTimeout})
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	return c.Call(context.Background(), "forward", args, nil)
+}
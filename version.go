@@ -0,0 +1,231 @@
+package gowindows
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// The product type of OSVERSIONINFOEXW
+const (
+	VER_NT_WORKSTATION       = 0x1
+	VER_NT_DOMAIN_CONTROLLER = 0x2
+	VER_NT_SERVER            = 0x3
+)
+
+// The version of the system, GetVersion on windows, ParseVersion for the version of another system.
+// The major, minor and build are RtlGetVersion, it is not affected by the compatibility mode of the manifest.
+//
+//	v, err := GetVersion()
+//	fmt.Println(v.Name(), v) // Windows 11 23H2 10.0.22631.4317
+//	if v.Supports(FeatureWfpBindRedirect) { ... }
+type Version struct {
+	Major uint32
+	Minor uint32
+	Build uint32
+	// Update Build Revision, the number of the cumulative update of the build, 0 if it is unknown.
+	UBR uint32
+	// VER_NT_*, 0 if it is unknown, the version is the client.
+	ProductType uint8
+	// EditionID of the registry, such as Professional, Enterprise or ServerDatacenter, empty if it is unknown.
+	Edition string
+}
+
+// The release of windows 10 and later, the build number is the same for all the updates of the release.
+// https://docs.microsoft.com/en-us/windows/release-health/release-information
+// https://docs.microsoft.com/en-us/windows/release-health/windows-server-release-info
+type Release struct {
+	Build   uint32
+	Server  bool
+	Name    string // such as Windows 10, Windows 11 or Windows Server 2022
+	Version string // such as 1607 or 22H2, empty for the server
+}
+
+// Sorted by the build, the client and the server of the same build are different releases.
+var releaseTable = []Release{
+	{10240, false, "Windows 10", "1507"},
+	{10586, false, "Windows 10", "1511"},
+	{14393, false, "Windows 10", "1607"},
+	{14393, true, "Windows Server 2016", ""},
+	{15063, false, "Windows 10", "1703"},
+	{16299, false, "Windows 10", "1709"},
+	{17134, false, "Windows 10", "1803"},
+	{17763, false, "Windows 10", "1809"},
+	{17763, true, "Windows Server 2019", ""},
+	{18362, false, "Windows 10", "1903"},
+	{18363, false, "Windows 10", "1909"},
+	{19041, false, "Windows 10", "2004"},
+	{19042, false, "Windows 10", "20H2"},
+	{19043, false, "Windows 10", "21H1"},
+	{19044, false, "Windows 10", "21H2"},
+	{19045, false, "Windows 10", "22H2"},
+	{20348, true, "Windows Server 2022", ""},
+	{22000, false, "Windows 11", "21H2"},
+	{22621, false, "Windows 11", "22H2"},
+	{22631, false, "Windows 11", "23H2"},
+	{26100, false, "Windows 11", "24H2"},
+	{26100, true, "Windows Server 2025", ""},
+}
+
+// The first build of windows 11, windows 11 is still 10.0.
+const windows11Build = 22000
+
+// Parse the version as 10.0.19045 or 10.0.19045.3803, the product type is VER_NT_WORKSTATION.
+func ParseVersion(s string) (Version, error) {
+	fields := strings.Split(s, ".")
+	if len(fields) < 2 || len(fields) > 4 {
+		return Version{}, fmt.Errorf("invalid version %q", s)
+	}
+	var numbers [4]uint32
+	for i, field := range fields {
+		n, err := strconv.ParseUint(field, 10, 32)
+		if err != nil {
+			return Version{}, fmt.Errorf("invalid version %q, %v", s, err)
+		}
+		numbers[i] = uint32(n)
+	}
+	return Version{Major: numbers[0], Minor: numbers[1], Build: numbers[2], UBR: numbers[3], ProductType: VER_NT_WORKSTATION}, nil
+}
+
+// Such as 10.0.22631.4317, the UBR is omitted if it is unknown.
+func (v Version) String() string {
+	if v.UBR == 0 {
+		return fmt.Sprintf("%v.%v.%v", v.Major, v.Minor, v.Build)
+	}
+	return fmt.Sprintf("%v.%v.%v.%v", v.Major, v.Minor, v.Build, v.UBR)
+}
+
+// The domain controller is the server too.
+func (v Version) IsServer() bool {
+	return v.ProductType == VER_NT_SERVER || v.ProductType == VER_NT_DOMAIN_CONTROLLER
+}
+
+// Compare major.minor.build, the UBR is ignored.
+func (v Version) AtLeast(major, minor, build uint32) bool {
+	if v.Major != major {
+		return v.Major > major
+	}
+	if v.Minor != minor {
+		return v.Minor > minor
+	}
+	return v.Build >= build
+}
+
+func (v Version) IsWindows10OrGreater() bool {
+	return v.AtLeast(10, 0, 0)
+}
+
+// Windows 11 is the client of build 22000 or greater, the server of the same build is not windows 11.
+func (v Version) IsWindows11OrGreater() bool {
+	return v.IsServer() == false && v.AtLeast(10, 0, windows11Build)
+}
+
+// The release of the build, false if the build is not a release, such as windows 8.1 or the insider build.
+func (v Version) Release() (Release, bool) {
+	for _, r := range releaseTable {
+		if r.Build == v.Build && r.Server == v.IsServer() && v.Major == 10 && v.Minor == 0 {
+			return r, true
+		}
+	}
+	return Release{}, false
+}
+
+// The name of the version, such as Windows 10 22H2, Windows Server 2019 or Windows 8.1.
+func (v Version) Name() string {
+	if r, ok := v.Release(); ok {
+		if r.Version == "" {
+			return r.Name
+		}
+		return r.Name + " " + r.Version
+	}
+
+	client, server := "", ""
+	switch {
+	case v.Major == 10 && v.Minor == 0:
+		// The insider build or the semi-annual server
+		client, server = "Windows 10", "Windows Server"
+		if v.Build >= windows11Build {
+			client = "Windows 11"
+		}
+		client = fmt.Sprintf("%v build %v", client, v.Build)
+		server = fmt.Sprintf("%v build %v", server, v.Build)
+	case v.Major == 6 && v.Minor == 3:
+		client, server = "Windows 8.1", "Windows Server 2012 R2"
+	case v.Major == 6 && v.Minor == 2:
+		client, server = "Windows 8", "Windows Server 2012"
+	case v.Major == 6 && v.Minor == 1:
+		client, server = "Windows 7", "Windows Server 2008 R2"
+	case v.Major == 6 && v.Minor == 0:
+		client, server = "Windows Vista", "Windows Server 2008"
+	case v.Major == 5 && v.Minor == 2:
+		client, server = "Windows XP x64", "Windows Server 2003"
+	case v.Major == 5 && v.Minor == 1:
+		client, server = "Windows XP", "Windows XP"
+	default:
+		return "Windows " + v.String()
+	}
+	if v.IsServer() {
+		return server
+	}
+	return client
+}
+
+// The feature of the system, Version.Supports checks the minimum version of it.
+type Feature int
+
+const (
+	// FWPM_LAYER_ALE_CONNECT_REDIRECT_V4, windows 7
+	FeatureWfpConnectRedirect Feature = iota + 1
+	// FWPM_LAYER_ALE_BIND_REDIRECT_V4, windows 8
+	FeatureWfpBindRedirect
+	// NtQueryInformationProcess ProcessCommandLineInformation, windows 8.1
+	FeatureProcessCommandLine
+	// NtQueryInformationProcess ProcessProtectionInformation, windows 8.1
+	FeatureProcessProtection
+	// PROC_THREAD_ATTRIBUTE_PARENT_PROCESS of CreateProcessAsUser, vista
+	FeatureParentProcessAttribute
+	// AF_UNIX of winsock, windows 10 1803
+	FeatureAfUnix
+	// CreatePseudoConsole, windows 10 1809
+	FeaturePseudoConsole
+)
+
+type featureInfo struct {
+	name                string
+	major, minor, build uint32
+}
+
+var featureTable = map[Feature]featureInfo{
+	FeatureWfpConnectRedirect:     {"WfpConnectRedirect", 6, 1, 0},
+	FeatureWfpBindRedirect:        {"WfpBindRedirect", 6, 2, 0},
+	FeatureProcessCommandLine:     {"ProcessCommandLine", 6, 3, 0},
+	FeatureProcessProtection:      {"ProcessProtection", 6, 3, 0},
+	FeatureParentProcessAttribute: {"ParentProcessAttribute", 6, 0, 0},
+	FeatureAfUnix:                 {"AfUnix", 10, 0, 17134},
+	FeaturePseudoConsole:          {"PseudoConsole", 10, 0, 17763},
+}
+
+func (f Feature) String() string {
+	if info, ok := featureTable[f]; ok {
+		return info.name
+	}
+	return fmt.Sprintf("Feature(%d)", int(f))
+}
+
+// The minimum version of the feature, false if the feature is unknown.
+func (f Feature) MinVersion() (Version, bool) {
+	info, ok := featureTable[f]
+	if ok == false {
+		return Version{}, false
+	}
+	return Version{Major: info.major, Minor: info.minor, Build: info.build}, true
+}
+
+// The version is the minimum version of the feature or greater, false if the feature is unknown.
+func (v Version) Supports(f Feature) bool {
+	info, ok := featureTable[f]
+	if ok == false {
+		return false
+	}
+	return v.AtLeast(info.major, info.minor, info.build)
+}
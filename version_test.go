@@ -0,0 +1,156 @@
+package gowindows
+
+import (
+	"testing"
+)
+
+func TestParseVersion(t *testing.T) {
+	data := []struct {
+		s    string
+		want Version
+		err  bool
+	}{
+		{"10.0.19045.3803", Version{10, 0, 19045, 3803, VER_NT_WORKSTATION, ""}, false},
+		{"10.0.22631", Version{10, 0, 22631, 0, VER_NT_WORKSTATION, ""}, false},
+		{"6.1", Version{6, 1, 0, 0, VER_NT_WORKSTATION, ""}, false},
+		{"10", Version{}, true},
+		{"10.0.x", Version{}, true},
+		{"10.0.1.2.3", Version{}, true},
+	}
+	for i, v := range data {
+		r, err := ParseVersion(v.s)
+		if (err != nil) != v.err {
+			t.Errorf("%v: %v", i, err)
+			continue
+		}
+		if r != v.want {
+			t.Errorf("%v: %+v!=%+v", i, r, v.want)
+		}
+	}
+}
+
+func TestVersionString(t *testing.T) {
+	data := []struct {
+		v    Version
+		want string
+	}{
+		{Version{Major: 10, Build: 22631, UBR: 4317}, "10.0.22631.4317"},
+		{Version{Major: 6, Minor: 3, Build: 9600}, "6.3.9600"},
+	}
+	for i, v := range data {
+		if s := v.v.String(); s != v.want {
+			t.Errorf("%v: %v!=%v", i, s, v.want)
+		}
+	}
+}
+
+func TestVersionName(t *testing.T) {
+	data := []struct {
+		v    Version
+		want string
+	}{
+		{Version{Major: 10, Build: 10240, ProductType: VER_NT_WORKSTATION}, "Windows 10 1507"},
+		{Version{Major: 10, Build: 14393, ProductType: VER_NT_WORKSTATION}, "Windows 10 1607"},
+		{Version{Major: 10, Build: 14393, ProductType: VER_NT_SERVER}, "Windows Server 2016"},
+		{Version{Major: 10, Build: 17763, ProductType: VER_NT_DOMAIN_CONTROLLER}, "Windows Server 2019"},
+		{Version{Major: 10, Build: 19045, UBR: 3803, ProductType: VER_NT_WORKSTATION}, "Windows 10 22H2"},
+		{Version{Major: 10, Build: 20348, ProductType: VER_NT_SERVER}, "Windows Server 2022"},
+		{Version{Major: 10, Build: 22000, ProductType: VER_NT_WORKSTATION}, "Windows 11 21H2"},
+		{Version{Major: 10, Build: 22631, ProductType: VER_NT_WORKSTATION}, "Windows 11 23H2"},
+		{Version{Major: 10, Build: 26100, ProductType: VER_NT_WORKSTATION}, "Windows 11 24H2"},
+		{Version{Major: 10, Build: 26100, ProductType: VER_NT_SERVER}, "Windows Server 2025"},
+		{Version{Major: 10, Build: 22635, ProductType: VER_NT_WORKSTATION}, "Windows 11 build 22635"},
+		{Version{Major: 10, Build: 19044, ProductType: VER_NT_SERVER}, "Windows Server build 19044"},
+		{Version{Major: 6, Minor: 3, Build: 9600, ProductType: VER_NT_WORKSTATION}, "Windows 8.1"},
+		{Version{Major: 6, Minor: 3, Build: 9600, ProductType: VER_NT_SERVER}, "Windows Server 2012 R2"},
+		{Version{Major: 6, Minor: 1, Build: 7601}, "Windows 7"},
+		{Version{Major: 4, Minor: 0, Build: 1381}, "Windows 4.0.1381"},
+	}
+	for i, v := range data {
+		if s := v.v.Name(); s != v.want {
+			t.Errorf("%v: %v!=%v", i, s, v.want)
+		}
+	}
+}
+
+func TestVersionRelease(t *testing.T) {
+	v := Version{Major: 10, Build: 19042, ProductType: VER_NT_WORKSTATION}
+	r, ok := v.Release()
+	if ok == false || r.Version != "20H2" || r.Server {
+		t.Errorf("%+v!=20H2", r)
+	}
+
+	// The server of 19042 is not a long-term release
+	v.ProductType = VER_NT_SERVER
+	if r, ok := v.Release(); ok {
+		t.Errorf("%+v is not a release", r)
+	}
+
+	// Windows 8.1 is not in the table
+	v = Version{Major: 6, Minor: 3, Build: 9600}
+	if r, ok := v.Release(); ok {
+		t.Errorf("%+v is not a release", r)
+	}
+
+	for i := 1; i < len(releaseTable); i++ {
+		if releaseTable[i-1].Build > releaseTable[i].Build {
+			t.Errorf("%v: the table is not sorted, %v>%v", i, releaseTable[i-1].Build, releaseTable[i].Build)
+		}
+	}
+}
+
+func TestVersionIsWindows11OrGreater(t *testing.T) {
+	data := []struct {
+		v    Version
+		want bool
+	}{
+		{Version{Major: 10, Build: 19045, ProductType: VER_NT_WORKSTATION}, false},
+		{Version{Major: 10, Build: 22000, ProductType: VER_NT_WORKSTATION}, true},
+		{Version{Major: 10, Build: 26100, ProductType: VER_NT_SERVER}, false},
+		{Version{Major: 6, Minor: 3, Build: 9600, ProductType: VER_NT_WORKSTATION}, false},
+	}
+	for i, v := range data {
+		if r := v.v.IsWindows11OrGreater(); r != v.want {
+			t.Errorf("%v: %v!=%v", i, r, v.want)
+		}
+	}
+}
+
+func TestVersionSupports(t *testing.T) {
+	data := []struct {
+		v       string
+		feature Feature
+		want    bool
+	}{
+		{"6.1.7601", FeatureWfpBindRedirect, false},
+		{"6.2.9200", FeatureWfpBindRedirect, true},
+		{"10.0.19045", FeatureWfpBindRedirect, true},
+		{"6.1.7601", FeatureWfpConnectRedirect, true},
+		{"6.2.9200", FeatureProcessCommandLine, false},
+		{"6.3.9600", FeatureProcessCommandLine, true},
+		{"10.0.17134", FeatureAfUnix, true},
+		{"10.0.16299", FeatureAfUnix, false},
+		{"10.0.17134", FeaturePseudoConsole, false},
+		{"10.0.17763", FeaturePseudoConsole, true},
+		{"10.0.17763", Feature(0), false},
+	}
+	for i, v := range data {
+		version, err := ParseVersion(v.v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if r := version.Supports(v.feature); r != v.want {
+			t.Errorf("%v: %v %v %v!=%v", i, v.v, v.feature, r, v.want)
+		}
+	}
+
+	if s := FeatureWfpBindRedirect.String(); s != "WfpBindRedirect" {
+		t.Errorf("%v!=WfpBindRedirect", s)
+	}
+	if s := Feature(100).String(); s != "Feature(100)" {
+		t.Errorf("%v!=Feature(100)", s)
+	}
+	if v, ok := FeaturePseudoConsole.MinVersion(); ok == false || v.String() != "10.0.17763" {
+		t.Errorf("%v!=10.0.17763", v)
+	}
+}
@@ -0,0 +1,47 @@
+package gowindows
+
+import (
+	"errors"
+	"fmt"
+
+	"golang.org/x/sys/windows/registry"
+)
+
+// The key of UBR and EditionID, the values do not exist before windows 10 and windows 8.1 respectively.
+const currentVersionKey = `SOFTWARE\Microsoft\Windows NT\CurrentVersion`
+
+// The version of the current system, it is RtlGetVersion and the registry.
+// The UBR and the edition are empty if they are not in the registry.
+func GetVersion() (Version, error) {
+	info, err := RtlGetVersionEx()
+	if err != nil {
+		return Version{}, fmt.Errorf("RtlGetVersionEx, %w", err)
+	}
+	v := Version{
+		Major:       info.MajorVersion,
+		Minor:       info.MinorVersion,
+		Build:       info.BuildNumber,
+		ProductType: info.ProductType,
+	}
+
+	// The 64-bit view for the 32-bit program
+	key, err := registry.OpenKey(registry.LOCAL_MACHINE, currentVersionKey, registry.QUERY_VALUE|registry.WOW64_64KEY)
+	if err != nil {
+		return v, nil
+	}
+	defer key.Close()
+
+	ubr, _, err := key.GetIntegerValue("UBR")
+	if err != nil && errors.Is(err, registry.ErrNotExist) == false {
+		return Version{}, fmt.Errorf("read UBR, %w", err)
+	}
+	v.UBR = uint32(ubr)
+
+	edition, _, err := key.GetStringValue("EditionID")
+	if err != nil && errors.Is(err, registry.ErrNotExist) == false {
+		return Version{}, fmt.Errorf("read EditionID, %w", err)
+	}
+	v.Edition = edition
+
+	return v, nil
+}
@@ -0,0 +1,33 @@
+package gowindows
+
+import (
+	"testing"
+	"unsafe"
+)
+
+func TestGetVersion(t *testing.T) {
+	if size := unsafe.Sizeof(OsVersionInfoExw{}); size != 284 {
+		t.Errorf("%v!=284", size)
+	}
+
+	info, err := RtlGetVersion()
+	if err != nil {
+		t.Fatal(err)
+	}
+	v, err := GetVersion()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.Major != info.MajorVersion || v.Minor != info.MinorVersion || v.Build != info.BuildNumber {
+		t.Errorf("%v!=%v.%v", v, info.GetString(), info.BuildNumber)
+	}
+	if v.ProductType < VER_NT_WORKSTATION || v.ProductType > VER_NT_SERVER {
+		t.Errorf("product type %v", v.ProductType)
+	}
+	if v.IsWindows10OrGreater() && v.Edition == "" {
+		t.Errorf("the edition of %v is empty", v)
+	}
+	if v.Supports(FeatureWfpConnectRedirect) != info.IsWindows7OrGreater() {
+		t.Errorf("%v: FeatureWfpConnectRedirect!=IsWindows7OrGreater", v)
+	}
+}
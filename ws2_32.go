@@ -1,3 +1,5 @@
+// +build windows
+
 package gowindows
 
 import (
@@ -21,11 +23,7 @@ const (
 func WSACreateEvent() (WSAEvent, error) {
 	r1, _, e1 := wSACreateEvent.Call()
 	if WSAEvent(r1) == WSA_INVALID_EVENT {
-		if e1 != ERROR_SUCCESS {
-			return 0, e1
-		} else {
-			return 0, newCallError(DWord(r1))
-		}
+		return 0, newLastCallError(ws2_32.Name, wSACreateEvent.Name, r1, e1)
 	}
 	return WSAEvent(r1), nil
 }
@@ -37,11 +35,7 @@ func WSACreateEvent() (WSAEvent, error) {
 func WSACloseEvent(event WSAEvent) error {
 	r1, _, e1 := wSACloseEvent.Call(uintptr(event))
 	if r1 == 0 {
-		if e1 != ERROR_SUCCESS {
-			return e1
-		} else {
-			return newCallError(DWord(r1))
-		}
+		return newLastCallError(ws2_32.Name, wSACloseEvent.Name, r1, e1)
 	} else {
 		return nil
 	}
@@ -54,11 +48,7 @@ func WSACloseEvent(event WSAEvent) error {
 func WSAResetEvent(event WSAEvent) error {
 	r1, _, e1 := wSAResetEvent.Call(uintptr(event))
 	if r1 == 0 {
-		if e1 != ERROR_SUCCESS {
-			return e1
-		} else {
-			return newCallError(DWord(r1))
-		}
+		return newLastCallError(ws2_32.Name, wSAResetEvent.Name, r1, e1)
 	} else {
 		return nil
 	}